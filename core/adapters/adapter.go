@@ -55,6 +55,13 @@ type BaseAdapter interface {
 	Perform(models.RunInput, *store.Store) models.RunOutput
 }
 
+// Simulator is implemented by adapters whose Perform has side effects outside
+// of the node, such as sending an ethereum transaction. Simulate must return
+// a report of what Perform would have done without doing it.
+type Simulator interface {
+	Simulate(models.RunInput, *store.Store) models.RunOutput
+}
+
 // PipelineAdapter wraps a BaseAdapter with requirements for execution in the pipeline.
 type PipelineAdapter struct {
 	BaseAdapter
@@ -72,13 +79,48 @@ func (p PipelineAdapter) MinPayment() *assets.Link {
 	return p.minPayment
 }
 
+// Simulate reports what the wrapped adapter would do for the given input.
+// Adapters without side effects are performed as normal.
+func (p PipelineAdapter) Simulate(input models.RunInput, store *store.Store) models.RunOutput {
+	if simulator, ok := p.BaseAdapter.(Simulator); ok {
+		return simulator.Simulate(input, store)
+	}
+	return p.Perform(input, store)
+}
+
 // For determines the adapter type to use for a given task.
 func For(task models.TaskSpec, config orm.ConfigReader, orm *orm.ORM) (*PipelineAdapter, error) {
-	var ba BaseAdapter
-	var err error
 	mic := config.MinIncomingConfirmations()
 	var mp *assets.Link
 
+	ba, ok, err := ForCore(task)
+	if !ok {
+		bt, err := orm.FindBridge(task.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a supported adapter type", task.Type)
+		}
+		b := Bridge{BridgeType: bt, Params: task.Params}
+		ba = &b
+		mp = bt.MinimumContractPayment
+		mic = b.Confirmations
+	}
+
+	pa := &PipelineAdapter{
+		BaseAdapter: ba,
+		minConfs:    mic,
+		minPayment:  mp,
+	}
+
+	return pa, err
+}
+
+// ForCore returns the core adapter for the task, with its params, and false
+// if the task's type is not a core adapter. Unlike For, it does not need the
+// store, so it can be used to check jobs offline.
+func ForCore(task models.TaskSpec) (BaseAdapter, bool, error) {
+	var ba BaseAdapter
+	var err error
+
 	switch task.Type {
 	case TaskTypeCopy:
 		ba = &Copy{}
@@ -135,23 +177,9 @@ func For(task models.TaskSpec, config orm.ConfigReader, orm *orm.ORM) (*Pipeline
 		ba = &Quotient{}
 		err = unmarshalParams(task.Params, ba)
 	default:
-		bt, err := orm.FindBridge(task.Type)
-		if err != nil {
-			return nil, fmt.Errorf("%s is not a supported adapter type", task.Type)
-		}
-		b := Bridge{BridgeType: bt, Params: task.Params}
-		ba = &b
-		mp = bt.MinimumContractPayment
-		mic = b.Confirmations
+		return nil, false, nil
 	}
-
-	pa := &PipelineAdapter{
-		BaseAdapter: ba,
-		minConfs:    mic,
-		minPayment:  mp,
-	}
-
-	return pa, err
+	return ba, true, err
 }
 
 func unmarshalParams(params models.JSON, dst interface{}) error {
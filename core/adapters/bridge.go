@@ -36,7 +36,8 @@ func (ba *Bridge) Perform(input models.RunInput, store *store.Store) models.RunO
 		return models.NewRunOutputInProgress(input.Data())
 	}
 	meta := getMeta(store, input.JobRunID())
-	return ba.handleNewRun(input, meta, store.Config.BridgeResponseURL())
+	transport := httpTransport(http.DefaultTransport, input, store)
+	return ba.handleNewRun(input, meta, store.Config.BridgeResponseURL(), transport)
 }
 
 func getMeta(store *store.Store, jobRunID *models.ID) *models.JSON {
@@ -59,7 +60,7 @@ func getMeta(store *store.Store, jobRunID *models.ID) *models.JSON {
 	return &models.JSON{gjson.Parse(meta)}
 }
 
-func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, bridgeResponseURL *url.URL) models.RunOutput {
+func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, bridgeResponseURL *url.URL, transport http.RoundTripper) models.RunOutput {
 	data, err := models.Merge(input.Data(), ba.Params)
 	if err != nil {
 		return models.NewRunOutputError(baRunResultError("handling data param", err))
@@ -70,7 +71,7 @@ func (ba *Bridge) handleNewRun(input models.RunInput, meta *models.JSON, bridgeR
 		responseURL.Path += fmt.Sprintf("/v2/runs/%s", input.JobRunID().String())
 	}
 
-	body, err := ba.postToExternalAdapter(input, meta, responseURL)
+	body, err := ba.postToExternalAdapter(input, meta, responseURL, transport)
 	if err != nil {
 		return models.NewRunOutputError(baRunResultError("post to external adapter", err))
 	}
@@ -106,7 +107,7 @@ func (ba *Bridge) responseToRunResult(body []byte, input models.RunInput) models
 	return models.NewRunOutputCompleteWithResult(brr.Data.String())
 }
 
-func (ba *Bridge) postToExternalAdapter(input models.RunInput, meta *models.JSON, bridgeResponseURL *url.URL) ([]byte, error) {
+func (ba *Bridge) postToExternalAdapter(input models.RunInput, meta *models.JSON, bridgeResponseURL *url.URL, transport http.RoundTripper) ([]byte, error) {
 	data, err := models.Merge(input.Data(), ba.Params)
 	if err != nil {
 		return nil, errors.Wrap(err, "error merging bridge params with input params")
@@ -128,7 +129,7 @@ func (ba *Bridge) postToExternalAdapter(input models.RunInput, meta *models.JSON
 	request.Header.Set("Authorization", "Bearer "+ba.BridgeType.OutgoingToken)
 	request.Header.Set("Content-Type", "application/json")
 
-	client := http.Client{}
+	client := http.Client{Transport: transport}
 	resp, err := client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("POST request: %v", err)
@@ -76,3 +76,8 @@ func getValues(result string, d string) (float64, float64, error) {
 	}
 	return value, desired, nil
 }
+
+// Signature returns the kinds of result Compare accepts and produces.
+func (c *Compare) Signature() Signature {
+	return Signature{Produces: KindBool}
+}
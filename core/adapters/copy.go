@@ -22,3 +22,8 @@ func (c *Copy) Perform(input models.RunInput, store *store.Store) models.RunOutp
 	input = *models.NewRunInput(input.JobRunID(), data, input.Status())
 	return jp.Perform(input, store)
 }
+
+// Signature returns the kinds of result Copy accepts and produces.
+func (c *Copy) Signature() Signature {
+	return Signature{Produces: KindAny}
+}
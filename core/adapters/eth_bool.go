@@ -34,3 +34,8 @@ func boolean(t gjson.Type) bool {
 		return true
 	}
 }
+
+// Signature returns the kinds of result EthBool accepts and produces.
+func (*EthBool) Signature() Signature {
+	return Signature{Produces: KindBytes}
+}
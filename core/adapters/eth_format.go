@@ -65,3 +65,30 @@ func (*EthUint256) Perform(input models.RunInput, _ *store.Store) models.RunOutp
 
 	return models.NewRunOutputCompleteWithResult(hexutil.Encode(value))
 }
+
+// Signature returns the kinds of result EthBytes32 accepts and produces.
+func (*EthBytes32) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindText, KindNumber},
+		Produces: KindBytes,
+		Hint:     "use ethbool to format a boolean, and leave out ethbytes32 if the result is already formatted",
+	}
+}
+
+// Signature returns the kinds of result EthInt256 accepts and produces.
+func (*EthInt256) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindNumber, KindText},
+		Produces: KindBytes,
+		Hint:     "it formats a number, such as one extracted with a jsonparse task; use ethbool to format a boolean",
+	}
+}
+
+// Signature returns the kinds of result EthUint256 accepts and produces.
+func (*EthUint256) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindNumber, KindText},
+		Produces: KindBytes,
+		Hint:     "it formats a number, such as one extracted with a jsonparse task; use ethbool to format a boolean",
+	}
+}
@@ -23,9 +23,13 @@ const (
 )
 
 // EthTx holds the Address to send the result to and the FunctionSelector
-// to execute.
+// to execute. It is sent from FromAddress, if given, and otherwise from the
+// node's keys in turn. FromAddress is taken only from the task spec, or from
+// the initiators of flux monitors, never from run requests, and must be one of
+// the node's keys.
 type EthTx struct {
 	Address          common.Address       `json:"address"`
+	FromAddress      common.Address       `json:"fromAddress"`
 	FunctionSelector eth.FunctionSelector `json:"functionSelector"`
 	DataPrefix       hexutil.Bytes        `json:"dataPrefix"`
 	DataFormat       string               `json:"format"`
@@ -52,7 +56,19 @@ func (etx *EthTx) Perform(input models.RunInput, store *strpkg.Store) models.Run
 	}
 
 	data := utils.ConcatBytes(etx.FunctionSelector.Bytes(), etx.DataPrefix, value)
-	return createTxRunResult(etx.Address, etx.GasPrice, etx.GasLimit, data, input, store)
+	return createTxRunResult(etx.Address, etx.FromAddress, etx.GasPrice, etx.GasLimit, data, input, store)
+}
+
+// Simulate reports the transaction Perform would send, without sending it.
+func (etx *EthTx) Simulate(input models.RunInput, store *strpkg.Store) models.RunOutput {
+	value, err := getTxData(etx, input)
+	if err != nil {
+		err = errors.Wrap(err, "while constructing EthTx data")
+		return models.NewRunOutputError(err)
+	}
+
+	data := utils.ConcatBytes(etx.FunctionSelector.Bytes(), etx.DataPrefix, value)
+	return simulateTxRunResult(etx.Address, etx.FromAddress, etx.GasPrice, etx.GasLimit, data, input, store)
 }
 
 // getTxData returns the data to save against the callback encoded according to
@@ -76,20 +92,24 @@ func getTxData(e *EthTx, input models.RunInput) ([]byte, error) {
 
 func createTxRunResult(
 	address common.Address,
+	from common.Address,
 	gasPrice *utils.Big,
 	gasLimit uint64,
 	data []byte,
 	input models.RunInput,
 	store *strpkg.Store,
 ) models.RunOutput {
-	tx, err := store.TxManager.CreateTxWithGas(
-		null.StringFrom(input.JobRunID().String()),
-		address,
-		data,
-		gasPrice.ToInt(),
-		gasLimit,
-	)
-	if err != nil {
+	surrogateID := null.StringFrom(input.JobRunID().String())
+	var tx *models.Tx
+	var err error
+	if from == utils.ZeroAddress {
+		tx, err = store.TxManager.CreateTxWithGas(surrogateID, address, data, gasPrice.ToInt(), gasLimit)
+	} else {
+		tx, err = store.TxManager.CreateTxFromWithGas(surrogateID, from, address, data, gasPrice.ToInt(), gasLimit)
+	}
+	if errors.Cause(err) == strpkg.ErrUnknownAccount {
+		return models.NewRunOutputError(err)
+	} else if err != nil {
 		return models.NewRunOutputPendingConfirmationsWithData(input.Data())
 	}
 
@@ -125,6 +145,72 @@ func createTxRunResult(
 	return models.NewRunOutputPendingConfirmationsWithData(output)
 }
 
+// simulatedTx is the report produced in place of sending a transaction when
+// a run is simulated.
+type simulatedTx struct {
+	From             *common.Address `json:"from,omitempty"`
+	To               common.Address  `json:"to"`
+	Data             hexutil.Bytes   `json:"data"`
+	GasPrice         *utils.Big      `json:"gasPrice"`
+	GasLimit         uint64          `json:"gasLimit"`
+	GasEstimate      *hexutil.Uint64 `json:"gasEstimate,omitempty"`
+	GasEstimateError string          `json:"gasEstimateError,omitempty"`
+}
+
+func simulateTxRunResult(
+	address common.Address,
+	from common.Address,
+	gasPrice *utils.Big,
+	gasLimit uint64,
+	data []byte,
+	input models.RunInput,
+	store *strpkg.Store,
+) models.RunOutput {
+	gasPriceWei, gasLimit := strpkg.NormalizeGasParams(gasPrice.ToInt(), gasLimit, store.Config)
+	report := simulatedTx{
+		To:       address,
+		Data:     data,
+		GasPrice: utils.NewBig(gasPriceWei),
+		GasLimit: gasLimit,
+	}
+
+	if from != utils.ZeroAddress {
+		report.From = &from
+	} else if account := store.TxManager.NextActiveAccount(); account != nil {
+		next := account.Address
+		report.From = &next
+	}
+
+	estimate, err := estimateGas(report.From, address, data, store)
+	if err != nil {
+		report.GasEstimateError = err.Error()
+	} else {
+		report.GasEstimate = &estimate
+	}
+
+	output, err := input.Data().Add("simulatedTx", report)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return models.NewRunOutputComplete(output)
+}
+
+func estimateGas(from *common.Address, to common.Address, data []byte, store *strpkg.Store) (hexutil.Uint64, error) {
+	if !store.TxManager.Connected() {
+		return 0, strpkg.ErrPendingConnection
+	}
+
+	args := struct {
+		From *common.Address `json:"from,omitempty"`
+		To   common.Address  `json:"to"`
+		Data hexutil.Bytes   `json:"data"`
+	}{from, to, data}
+
+	var estimate hexutil.Uint64
+	err := store.TxManager.Call(&estimate, "eth_estimateGas", args)
+	return estimate, err
+}
+
 func ensureTxRunResult(input models.RunInput, str *strpkg.Store) models.RunOutput {
 	val, err := input.ResultString()
 	if err != nil {
@@ -210,3 +296,17 @@ func pendingConfirmationsOrConnection(input models.RunInput) models.RunOutput {
 	}
 	return models.NewRunOutputPendingConnection()
 }
+
+// Signature returns the kinds of result EthTx accepts and produces. Without a
+// format, the result is sent as a hex encoded bytes32 and must already be
+// formatted.
+func (etx *EthTx) Signature() Signature {
+	if etx.DataFormat != "" {
+		return Signature{Produces: KindText}
+	}
+	return Signature{
+		Accepts:  []ValueKind{KindBytes},
+		Produces: KindText,
+		Hint:     "format the result with an ethbytes32, ethint256, ethuint256 or ethbool task first, or set the ethtx format param",
+	}
+}
@@ -73,11 +73,21 @@ func (etx *EthTxABIEncode) Perform(input models.RunInput, store *strpkg.Store) m
 			err = errors.Wrap(err, "while constructing EthTxABIEncode data")
 			return models.NewRunOutputError(err)
 		}
-		return createTxRunResult(etx.Address, etx.GasPrice, etx.GasLimit, data, input, store)
+		return createTxRunResult(etx.Address, utils.ZeroAddress, etx.GasPrice, etx.GasLimit, data, input, store)
 	}
 	return ensureTxRunResult(input, store)
 }
 
+// Simulate reports the transaction Perform would send, without sending it.
+func (etx *EthTxABIEncode) Simulate(input models.RunInput, store *strpkg.Store) models.RunOutput {
+	data, err := etx.abiEncode(&input)
+	if err != nil {
+		err = errors.Wrap(err, "while constructing EthTxABIEncode data")
+		return models.NewRunOutputError(err)
+	}
+	return simulateTxRunResult(etx.Address, utils.ZeroAddress, etx.GasPrice, etx.GasLimit, data, input, store)
+}
+
 // abiEncode ABI-encodes the arguments passed in a RunResult's result field
 // according to etx.FunctionABI
 func (etx *EthTxABIEncode) abiEncode(input *models.RunInput) ([]byte, error) {
@@ -453,3 +463,8 @@ func assertPadded(b []byte) {
 		panic("ABI encoded data isn't padded properly")
 	}
 }
+
+// Signature returns the kinds of result EthTxABIEncode accepts and produces.
+func (etx *EthTxABIEncode) Signature() Signature {
+	return Signature{Produces: KindText}
+}
@@ -365,6 +365,65 @@ func TestEthTxAdapter_Perform_CreateTxWithGasErrorTreatsAsNotConnected(t *testin
 	txManager.AssertExpectations(t)
 }
 
+func TestEthTxAdapter_Perform_FromAddress(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	from := cltest.NewAddress()
+	to := cltest.NewAddress()
+	txManager := new(mocks.TxManager)
+	txManager.On("Connected").Return(true)
+	txManager.On("CreateTxFromWithGas",
+		mock.Anything,
+		from,
+		to,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(nil, syscall.ETIMEDOUT)
+	store.TxManager = txManager
+
+	adapter := adapters.EthTx{Address: to, FromAddress: from}
+	data := adapter.Perform(models.RunInput{}, store)
+
+	require.NoError(t, data.Error())
+	assert.Equal(t, models.RunStatusPendingConfirmations, data.Status())
+
+	txManager.AssertExpectations(t)
+	txManager.AssertNotCalled(t, "CreateTxWithGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEthTxAdapter_Perform_FromUnknownAccount(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	from := cltest.NewAddress()
+	to := cltest.NewAddress()
+	txManager := new(mocks.TxManager)
+	txManager.On("Connected").Return(true)
+	txManager.On("CreateTxFromWithGas",
+		mock.Anything,
+		from,
+		to,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(nil, strpkg.ErrUnknownAccount)
+	store.TxManager = txManager
+
+	adapter := adapters.EthTx{Address: to, FromAddress: from}
+	data := adapter.Perform(models.RunInput{}, store)
+
+	assert.Error(t, data.Error())
+	assert.Equal(t, models.RunStatusErrored, data.Status())
+
+	txManager.AssertExpectations(t)
+}
+
 func TestEthTxAdapter_Perform_CheckAttemptErrorTreatsAsNotConnected(t *testing.T) {
 	t.Parallel()
 
@@ -477,3 +536,33 @@ func TestEthTxAdapter_Perform_NoDoubleSpendOnSendTransactionFail(t *testing.T) {
 
 	txManager.AssertExpectations(t)
 }
+
+func TestEthTxAdapter_Simulate(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	txManager.On("NextActiveAccount").Return(nil)
+	txManager.On("Connected").Return(true)
+	txManager.On("Call", mock.Anything, "eth_estimateGas", mock.Anything).Return(nil)
+	store.TxManager = txManager
+
+	address := cltest.NewAddress()
+	adapter := adapters.EthTx{Address: address}
+	input := cltest.NewRunInputWithResult("0xf7fffff1")
+	output := adapter.Simulate(input, store)
+
+	require.NoError(t, output.Error())
+	assert.Equal(t, models.RunStatusCompleted, output.Status())
+	assert.Equal(t, "0xf7fffff1", output.Result().String())
+	assert.Equal(t, address.Hex(), output.Get("simulatedTx.to").String())
+	assert.Equal(t,
+		"0x0000000000000000000000000000000000000000000000000000000000000000f7fffff1",
+		output.Get("simulatedTx.data").String())
+	assert.Equal(t, strpkg.DefaultGasLimit, output.Get("simulatedTx.gasLimit").Uint())
+
+	txManager.AssertNotCalled(t, "CreateTxWithGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	txManager.AssertExpectations(t)
+}
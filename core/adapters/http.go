@@ -42,7 +42,7 @@ func (hga *HTTPGet) Perform(input models.RunInput, store *store.Store) models.Ru
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
-	return sendRequest(input, request, store.Config.DefaultHTTPLimit())
+	return sendRequest(input, request, store)
 }
 
 // GetURL retrieves the GET field if set otherwise returns the URL field
@@ -82,7 +82,7 @@ func (hpa *HTTPPost) Perform(input models.RunInput, store *store.Store) models.R
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
-	return sendRequest(input, request, store.Config.DefaultHTTPLimit())
+	return sendRequest(input, request, store)
 }
 
 // GetURL retrieves the POST field if set otherwise returns the URL field
@@ -141,11 +141,11 @@ func setHeaders(request *http.Request, headers http.Header, contentType string)
 	}
 }
 
-func sendRequest(input models.RunInput, request *http.Request, limit int64) models.RunOutput {
+func sendRequest(input models.RunInput, request *http.Request, store *store.Store) models.RunOutput {
 	tr := &http.Transport{
 		DisableCompression: true,
 	}
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: httpTransport(tr, input, store)}
 
 	response, err := withRetry(client, request)
 
@@ -155,7 +155,7 @@ func sendRequest(input models.RunInput, request *http.Request, limit int64) mode
 
 	defer response.Body.Close()
 
-	source := newMaxBytesReader(response.Body, limit)
+	source := newMaxBytesReader(response.Body, store.Config.DefaultHTTPLimit())
 	bytes, err := ioutil.ReadAll(source)
 	if err != nil {
 		return models.NewRunOutputError(err)
@@ -321,3 +321,13 @@ func (ep *ExtendedPath) UnmarshalJSON(input []byte) error {
 	*ep = ExtendedPath(values)
 	return err
 }
+
+// Signature returns the kinds of result HTTPGet accepts and produces.
+func (hga *HTTPGet) Signature() Signature {
+	return Signature{Produces: KindText}
+}
+
+// Signature returns the kinds of result HTTPPost accepts and produces.
+func (hpa *HTTPPost) Signature() Signature {
+	return Signature{Produces: KindText}
+}
@@ -0,0 +1,263 @@
+package adapters
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+
+	null "gopkg.in/guregu/null.v3"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveName matches the names of headers, query parameters and body
+// fields whose values are never written to the HTTP audit trail.
+var sensitiveName = regexp.MustCompile(`(?i)auth|token|secret|passw|key|cookie|session|signature|credential`)
+
+// sensitiveJSONField matches a JSON member whose name is sensitive, along
+// with its value unless that is an object or array, for bodies that cannot be
+// parsed, such as those cut short where they were truncated.
+var sensitiveJSONField = regexp.MustCompile(`("[^"]*(?i:auth|token|secret|passw|key|cookie|session|signature|credential)[^"]*"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s{\[][^,}\]\s]*)`)
+
+// formBody matches bodies in the form encoding, of one or more name=value
+// pairs.
+var formBody = regexp.MustCompile(`^[\w.\-%\[\]]+=[^&\s]*(&[\w.\-%\[\]]+=[^&\s]*)*$`)
+
+// httpTransport wraps the transport of an adapter's HTTP client so that each
+// request it sends, and the response it receives, is recorded against the
+// input's task run. Requests are sent unaudited unless HTTP auditing is
+// enabled and the input belongs to a saved task run, and are not sent at all
+// when the input is replayed against recorded responses.
+func httpTransport(transport http.RoundTripper, input models.RunInput, store *store.Store) http.RoundTripper {
+	if recorded := input.RecordedHTTP(); recorded != nil {
+		return newReplayTransport(recorded)
+	}
+	if !store.Config.HTTPAuditEnabled() || input.TaskRunID() == nil {
+		return transport
+	}
+	bodyLimit := store.Config.HTTPAuditBodyLimit()
+	if bodyLimit < 0 {
+		bodyLimit = 0
+	}
+	return &auditTransport{
+		transport: transport,
+		taskRunID: input.TaskRunID(),
+		orm:       store.ORM,
+		bodyLimit: bodyLimit,
+	}
+}
+
+type auditTransport struct {
+	transport http.RoundTripper
+	taskRunID *models.ID
+	orm       *orm.ORM
+	bodyLimit int64
+}
+
+func (at *auditTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	record := &models.HTTPAuditRecord{
+		TaskRunID:      at.taskRunID,
+		CreatedAt:      time.Now(),
+		Method:         request.Method,
+		URL:            redactURL(request.URL),
+		RequestHeaders: redactHeaders(request.Header),
+	}
+
+	// GetBody is set by http.NewRequest for in memory bodies, which is all
+	// that adapters send, and leaves the request's own body unread.
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			record.RequestBody, record.RequestBodyTruncated = at.truncate(redactBody(request.Header.Get("Content-Type"), b))
+			record.RequestBodyHash = hashBody(sha256.New(), b)
+		}
+	}
+
+	response, err := at.transport.RoundTrip(request)
+	if err != nil {
+		record.Error = null.StringFrom(err.Error())
+		at.save(record)
+		return nil, err
+	}
+
+	record.StatusCode = response.StatusCode
+	record.ResponseHeaders = redactHeaders(response.Header)
+	response.Body = &auditBody{
+		ReadCloser:  response.Body,
+		transport:   at,
+		record:      record,
+		hash:        sha256.New(),
+		contentType: response.Header.Get("Content-Type"),
+	}
+	return response, nil
+}
+
+func (at *auditTransport) truncate(body []byte) (string, bool) {
+	if int64(len(body)) > at.bodyLimit {
+		return string(body[:at.bodyLimit]), true
+	}
+	return string(body), false
+}
+
+func (at *auditTransport) save(record *models.HTTPAuditRecord) {
+	if err := at.orm.CreateHTTPAuditRecord(record); err != nil {
+		logger.Errorw("Unable to save HTTP audit record", "taskRun", at.taskRunID.String(), "url", record.URL, "error", err)
+	}
+}
+
+// auditBody captures a response body as the adapter reads it, saving the
+// audit record once the body is closed. Only what the adapter read is
+// hashed, so a body abandoned at the adapter's size limit is marked
+// truncated.
+type auditBody struct {
+	io.ReadCloser
+	transport   *auditTransport
+	record      *models.HTTPAuditRecord
+	hash        hash.Hash
+	contentType string
+	body        bytes.Buffer
+	truncated   bool
+	sawEOF      bool
+	once        sync.Once
+}
+
+func (ab *auditBody) Read(p []byte) (int, error) {
+	n, err := ab.ReadCloser.Read(p)
+	ab.hash.Write(p[:n])
+
+	remaining := ab.transport.bodyLimit - int64(ab.body.Len())
+	if int64(n) > remaining {
+		ab.body.Write(p[:remaining])
+		ab.truncated = true
+	} else {
+		ab.body.Write(p[:n])
+	}
+
+	if err == io.EOF {
+		ab.sawEOF = true
+	} else if err != nil {
+		ab.record.Error = null.StringFrom(err.Error())
+	}
+	return n, err
+}
+
+func (ab *auditBody) Close() error {
+	err := ab.ReadCloser.Close()
+	ab.once.Do(func() {
+		ab.record.ResponseBody = string(redactBody(ab.contentType, ab.body.Bytes()))
+		ab.record.ResponseBodyHash = hashBody(ab.hash, nil)
+		ab.record.ResponseBodyTruncated = ab.truncated || !ab.sawEOF
+		ab.transport.save(ab.record)
+	})
+	return err
+}
+
+func hashBody(h hash.Hash, body []byte) string {
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			redactedURL.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+
+	query := u.Query()
+	for name := range query {
+		if sensitiveName.MatchString(name) {
+			query[name] = []string{redacted}
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactBody replaces the values of the sensitive fields of a form or JSON
+// body. Bodies are hashed before they are redacted, so the hash still
+// identifies what was sent or received. Bodies that look like forms are
+// taken as forms whatever their content type, as httppost always sends JSON.
+func redactBody(contentType string, body []byte) []byte {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" || formBody.Match(body) {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for name := range form {
+				if sensitiveName.MatchString(name) {
+					form[name] = []string{redacted}
+				}
+			}
+			return []byte(form.Encode())
+		}
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) == nil && !decoder.More() {
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		if encoder.Encode(redactJSON(value)) == nil {
+			return bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))
+		}
+	}
+	return sensitiveJSONField.ReplaceAll(body, []byte(`${1}"`+redacted+`"`))
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if sensitiveName.MatchString(name) {
+				v[name] = redacted
+			} else {
+				v[name] = redactJSON(field)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactJSON(element)
+		}
+	}
+	return value
+}
+
+func redactHeaders(header http.Header) models.JSON {
+	redactedHeader := make(http.Header, len(header))
+	for name, values := range header {
+		if sensitiveName.MatchString(name) {
+			redactedHeader[name] = []string{redacted}
+		} else {
+			redactedHeader[name] = values
+		}
+	}
+
+	b, err := json.Marshal(redactedHeader)
+	if err != nil {
+		logger.Errorw("Unable to marshal HTTP headers for audit", "error", err)
+		return models.JSON{}
+	}
+	headers, err := models.ParseJSON(b)
+	if err != nil {
+		logger.Errorw("Unable to parse HTTP headers for audit", "error", err)
+	}
+	return headers
+}
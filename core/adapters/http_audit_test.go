@@ -0,0 +1,175 @@
+package adapters_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"chainlink/core/adapters"
+	"chainlink/core/internal/cltest"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPAdapters_Audit(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("HTTP_AUDIT_ENABLED", true)
+	store.Config.Set("HTTP_AUDIT_BODY_LIMIT", 24)
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httppost")}
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	response := `{"value":"a much longer response"}`
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", response)
+	defer assertCalled()
+
+	body := `{"secret":"hunter2","symbol":"ETH"}`
+	hpa := adapters.HTTPPost{
+		URL:         cltest.WebURL(t, mock.URL+"?apiKey=hunter2&symbol=ETH"),
+		Headers:     http.Header{"Authorization": []string{"Bearer hunter2"}, "Accept": []string{"application/json"}},
+		QueryParams: adapters.QueryParameters{"access_token": []string{"hunter2"}},
+		Body:        &body,
+	}
+	input := models.NewTaskRunInput(run.ID, run.TaskRuns[0].ID, models.JSON{}, models.RunStatusUnstarted)
+	result := hpa.Perform(*input, store)
+	require.NoError(t, result.Error())
+
+	records, err := store.HTTPAuditRecordsFor(run.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, run.TaskRuns[0].ID, record.TaskRunID)
+	assert.Equal(t, "POST", record.Method)
+	assert.NotContains(t, record.URL, "hunter2")
+	assert.Contains(t, record.URL, "symbol=ETH")
+	assert.NotContains(t, record.RequestHeaders.String(), "hunter2")
+	assert.Equal(t, "application/json", record.RequestHeaders.Get("Accept.0").String())
+
+	assert.Equal(t, `{"secret":"REDACTED","sy`, record.RequestBody)
+	assert.True(t, record.RequestBodyTruncated)
+	assert.Equal(t, sha256Hex(body), record.RequestBodyHash)
+
+	assert.Equal(t, http.StatusOK, record.StatusCode)
+	assert.Equal(t, response[:24], record.ResponseBody)
+	assert.True(t, record.ResponseBodyTruncated)
+	assert.Equal(t, sha256Hex(response), record.ResponseBodyHash)
+	assert.False(t, record.Error.Valid)
+}
+
+func TestHTTPAdapters_Audit_RedactsBodies(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("HTTP_AUDIT_ENABLED", true)
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httppost")}
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	response := `{"data":{"sessionToken":"hunter2","result":"100"}}`
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", response)
+	defer assertCalled()
+
+	body := "symbol=ETH&password=hunter2"
+	hpa := adapters.HTTPPost{
+		URL:  cltest.WebURL(t, mock.URL),
+		Body: &body,
+	}
+	input := models.NewTaskRunInput(run.ID, run.TaskRuns[0].ID, models.JSON{}, models.RunStatusUnstarted)
+	result := hpa.Perform(*input, store)
+	require.NoError(t, result.Error())
+
+	records, err := store.HTTPAuditRecordsFor(run.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, "password=REDACTED&symbol=ETH", record.RequestBody)
+	assert.Equal(t, sha256Hex(body), record.RequestBodyHash)
+	assert.Equal(t, `{"data":{"result":"100","sessionToken":"REDACTED"}}`, record.ResponseBody)
+	assert.Equal(t, sha256Hex(response), record.ResponseBodyHash)
+}
+
+func TestHTTPAdapters_Audit_Disabled(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httpget")}
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", "result")
+	defer assertCalled()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(t, mock.URL)}
+	input := models.NewTaskRunInput(run.ID, run.TaskRuns[0].ID, models.JSON{}, models.RunStatusUnstarted)
+	result := hga.Perform(*input, store)
+	require.NoError(t, result.Error())
+
+	records, err := store.HTTPAuditRecordsFor(run.ID)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestBridge_Audit(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("HTTP_AUDIT_ENABLED", true)
+
+	response := `{"data":{"result":"100"}}`
+	mock, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", response)
+	defer assertCalled()
+
+	_, bt := cltest.NewBridgeType(t, "auditedbridge", mock.URL)
+	require.NoError(t, store.CreateBridgeType(bt))
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "auditedbridge")}
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	ba := &adapters.Bridge{BridgeType: *bt}
+	input := models.NewTaskRunInput(run.ID, run.TaskRuns[0].ID, models.JSON{}, models.RunStatusUnstarted)
+	result := ba.Perform(*input, store)
+	require.NoError(t, result.Error())
+
+	records, err := store.HTTPAuditRecordsFor(run.ID)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "REDACTED", redactedHeader(t, records[0].RequestHeaders, "Authorization"))
+	assert.Contains(t, records[0].RequestBody, run.ID.String())
+	assert.Equal(t, response, records[0].ResponseBody)
+	assert.False(t, records[0].ResponseBodyTruncated)
+}
+
+func redactedHeader(t *testing.T, headers models.JSON, name string) string {
+	t.Helper()
+	values := headers.Get(name).Array()
+	require.Len(t, values, 1)
+	return values[0].String()
+}
+
+func sha256Hex(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"chainlink/core/store/models"
+)
+
+// replayTransport answers requests from the HTTP audit trail of a task run,
+// in the order they were originally made, and never sends them.
+type replayTransport struct {
+	recorded []models.HTTPAuditRecord
+	used     []bool
+	mutex    sync.Mutex
+}
+
+func newReplayTransport(recorded []models.HTTPAuditRecord) *replayTransport {
+	return &replayTransport{
+		recorded: recorded,
+		used:     make([]bool, len(recorded)),
+	}
+}
+
+func (rt *replayTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	url := redactURL(request.URL)
+
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	for i, record := range rt.recorded {
+		if rt.used[i] || record.Method != request.Method || record.URL != url {
+			continue
+		}
+		rt.used[i] = true
+		return recordedResponse(request, record)
+	}
+	return nil, fmt.Errorf("no recorded response to %s %s", request.Method, url)
+}
+
+func recordedResponse(request *http.Request, record models.HTTPAuditRecord) (*http.Response, error) {
+	if record.StatusCode == 0 {
+		return nil, errors.New(record.Error.ValueOrZero())
+	}
+	if record.ResponseBodyTruncated {
+		return nil, fmt.Errorf("recorded response to %s %s was truncated, so cannot be replayed", record.Method, record.URL)
+	}
+
+	header := http.Header{}
+	if record.ResponseHeaders.Exists() {
+		if err := json.Unmarshal(record.ResponseHeaders.Bytes(), &header); err != nil {
+			return nil, fmt.Errorf("recorded response to %s %s has invalid headers: %v", record.Method, record.URL, err)
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", record.StatusCode, http.StatusText(record.StatusCode)),
+		StatusCode:    record.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(record.ResponseBody)),
+		ContentLength: int64(len(record.ResponseBody)),
+		Request:       request,
+	}, nil
+}
@@ -142,3 +142,12 @@ func (jp *JSONPath) UnmarshalJSON(b []byte) error {
 	*jp = JSONPath(strs)
 	return err
 }
+
+// Signature returns the kinds of result JSONParse accepts and produces.
+func (jpa *JSONParse) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindText},
+		Produces: KindAny,
+		Hint:     "jsonparse needs JSON text, such as the response of an httpget or httppost task",
+	}
+}
@@ -8,3 +8,12 @@ import (
 type Multiply struct {
 	Times *decimal.Decimal `json:"times,omitempty"`
 }
+
+// Signature returns the kinds of result Multiply accepts and produces.
+func (ma *Multiply) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindNumber, KindText},
+		Produces: KindNumber,
+		Hint:     "multiply needs a number, such as one extracted with a jsonparse task",
+	}
+}
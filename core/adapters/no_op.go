@@ -22,3 +22,13 @@ type NoOpPend struct{}
 func (noa *NoOpPend) Perform(_ models.RunInput, _ *store.Store) models.RunOutput {
 	return models.NewRunOutputPendingConfirmationsWithData(models.JSON{})
 }
+
+// Signature returns the kinds of result NoOp accepts and produces.
+func (noa *NoOp) Signature() Signature {
+	return Signature{Produces: KindUnchanged}
+}
+
+// Signature returns the kinds of result NoOpPend accepts and produces.
+func (noa *NoOpPend) Signature() Signature {
+	return Signature{Produces: KindUnchanged}
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"fmt"
+
+	"chainlink/core/store/models"
+)
+
+// ValueKind is the kind of value an adapter takes as the result of the task
+// before it, or leaves as its own result.
+type ValueKind string
+
+const (
+	// KindAny is a value of any kind, or one that cannot be known until the
+	// job runs, such as the result of a bridge.
+	KindAny = ValueKind("any")
+	// KindText is a string, such as an HTTP response body.
+	KindText = ValueKind("text")
+	// KindNumber is a decimal number.
+	KindNumber = ValueKind("number")
+	// KindBool is a boolean.
+	KindBool = ValueKind("bool")
+	// KindBytes is a hex encoded value laid out for the EVM.
+	KindBytes = ValueKind("bytes")
+	// KindUnchanged is produced by adapters that leave the result of the task
+	// before them as it was.
+	KindUnchanged = ValueKind("unchanged")
+)
+
+// Signature describes the results an adapter accepts and produces.
+type Signature struct {
+	// Accepts lists the kinds of result the adapter can take as input. An
+	// empty list accepts any kind.
+	Accepts []ValueKind
+	// Produces is the kind of result the adapter leaves.
+	Produces ValueKind
+	// Hint tells the job author how to give the adapter a result it accepts.
+	Hint string
+}
+
+// Typed is implemented by adapters that declare their Signature. Adapters
+// that do not, such as bridges, accept and produce any kind of result.
+type Typed interface {
+	Signature() Signature
+}
+
+func (s Signature) accepts(kind ValueKind) bool {
+	if len(s.Accepts) == 0 || kind == KindAny {
+		return true
+	}
+	for _, k := range s.Accepts {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeCheckPipeline checks that each task accepts the kind of result left by
+// the task before it, and that at most one task sends a transaction. Tasks
+// that are not core adapters are assumed to be bridges, and tasks whose
+// params are invalid are left for validation of the task itself.
+func TypeCheckPipeline(tasks []models.TaskSpec) error {
+	fe := models.NewJSONAPIErrors()
+	current, source := KindAny, -1
+	var sender *models.TaskSpec
+	for i, task := range tasks {
+		adapter, ok, err := ForCore(task)
+		if !ok || err != nil {
+			current, source = KindAny, i
+			continue
+		}
+
+		switch adapter.(type) {
+		case *EthTx, *EthTxABIEncode:
+			if sender != nil {
+				fe.Add(fmt.Sprintf(
+					"task %d (%s) sends a second transaction after the %s task; a job can only send one, so split it into separate jobs",
+					i+1, task.Type, sender.Type))
+				continue
+			}
+			sender = &tasks[i]
+		}
+
+		typed, ok := adapter.(Typed)
+		if !ok {
+			current, source = KindAny, i
+			continue
+		}
+		sig := typed.Signature()
+		if !sig.accepts(current) {
+			fe.Add(fmt.Sprintf(
+				"task %d (%s) cannot take the %s result of task %d (%s): %s",
+				i+1, task.Type, current, source+1, tasks[source].Type, sig.Hint))
+		}
+		if sig.Produces != KindUnchanged {
+			current, source = sig.Produces, i
+		}
+	}
+	return fe.CoerceEmptyToNil()
+}
@@ -0,0 +1,78 @@
+package adapters_test
+
+import (
+	"strings"
+	"testing"
+
+	"chainlink/core/adapters"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeCheckPipeline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		tasks []string
+		want  []string
+	}{
+		{"hello world", []string{"httpget", "jsonparse", "multiply", "ethuint256", "ethtx"}, nil},
+		{"bridge result", []string{"prices", "ethtx"}, nil},
+		{"formatted ethtx", []string{"httpget", `ethtx {"format": "bytes"}`}, nil},
+		{"noop keeps result", []string{"multiply", "noop", "ethint256", "ethtx"}, nil},
+		{"randomness", []string{"random", `ethtx {"format": "preformatted"}`}, nil},
+		{
+			"unformatted text to ethtx",
+			[]string{"httpget", "ethtx"},
+			[]string{"task 2 (ethtx) cannot take the text result of task 1 (httpget): format the result with an ethbytes32, ethint256, ethuint256 or ethbool task first, or set the ethtx format param"},
+		},
+		{
+			"number to jsonparse",
+			[]string{"httpget", "jsonparse", "multiply", "noop", "jsonparse"},
+			[]string{"task 5 (jsonparse) cannot take the number result of task 3 (multiply): jsonparse needs JSON text, such as the response of an httpget or httppost task"},
+		},
+		{
+			"bool to ethuint256",
+			[]string{"compare", "ethuint256"},
+			[]string{"task 2 (ethuint256) cannot take the bool result of task 1 (compare): it formats a number, such as one extracted with a jsonparse task; use ethbool to format a boolean"},
+		},
+		{
+			"two transactions",
+			[]string{"ethbytes32", "ethtx", "ethtx"},
+			[]string{"task 3 (ethtx) sends a second transaction after the ethtx task; a job can only send one, so split it into separate jobs"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tasks []models.TaskSpec
+			for _, task := range test.tasks {
+				var params models.JSON
+				if i := strings.IndexByte(task, ' '); i >= 0 {
+					var err error
+					params, err = models.ParseJSON([]byte(task[i+1:]))
+					require.NoError(t, err)
+					task = task[:i]
+				}
+				tasks = append(tasks, models.TaskSpec{Type: models.MustNewTaskType(task), Params: params})
+			}
+
+			err := adapters.TypeCheckPipeline(tasks)
+			if test.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			want := models.NewJSONAPIErrors()
+			for _, detail := range test.want {
+				want.Add(detail)
+			}
+			assert.Equal(t, want, err)
+		})
+	}
+}
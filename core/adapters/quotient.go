@@ -55,3 +55,12 @@ func (q *Quotient) Perform(input models.RunInput, _ *store.Store) models.RunOutp
 	}
 	return models.NewRunOutputCompleteWithResult(i.String())
 }
+
+// Signature returns the kinds of result Quotient accepts and produces.
+func (q *Quotient) Signature() Signature {
+	return Signature{
+		Accepts:  []ValueKind{KindNumber, KindText},
+		Produces: KindNumber,
+		Hint:     "quotient needs a number, such as one extracted with a jsonparse task",
+	}
+}
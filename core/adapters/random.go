@@ -106,3 +106,8 @@ func extractHex(input models.RunInput, key string) ([]byte, error) {
 	}
 	return hexutil.Decode(rawValue.String())
 }
+
+// Signature returns the kinds of result Random accepts and produces.
+func (ra *Random) Signature() Signature {
+	return Signature{Produces: KindBytes}
+}
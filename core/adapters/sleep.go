@@ -25,7 +25,18 @@ func (adapter *Sleep) Perform(input models.RunInput, str *store.Store) models.Ru
 	return models.NewRunOutputComplete(models.JSON{})
 }
 
+// Simulate completes at once with an empty result, as Perform does once the
+// sleep is over, without waiting.
+func (adapter *Sleep) Simulate(input models.RunInput, _ *store.Store) models.RunOutput {
+	return models.NewRunOutputComplete(models.JSON{})
+}
+
 // Duration returns the amount of sleeping this task should be paused for.
 func (adapter *Sleep) Duration() time.Duration {
 	return utils.DurationFromNow(adapter.Until.Time)
 }
+
+// Signature returns the kinds of result Sleep accepts and produces.
+func (adapter *Sleep) Signature() Signature {
+	return Signature{Produces: KindUnchanged}
+}
@@ -232,3 +232,34 @@ func (*Eth) Symbol() string {
 func (e *Eth) ToInt() *big.Int {
 	return (*big.Int)(e)
 }
+
+// Value returns the Eth value for serialization to database.
+func (e Eth) Value() (driver.Value, error) {
+	b := (big.Int)(e)
+	return b.String(), nil
+}
+
+// Scan reads the database value and returns an instance.
+func (e *Eth) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		decoded, ok := e.SetString(v, 10)
+		if !ok {
+			return fmt.Errorf("Unable to set string %v of %T to base 10 big.Int for Eth", value, value)
+		}
+		*e = *decoded
+	case []uint8:
+		// The SQL library returns numeric() types as []uint8 of the string representation
+		decoded, ok := e.SetString(string(v), 10)
+		if !ok {
+			return fmt.Errorf("Unable to set string %v of %T to base 10 big.Int for Eth", value, value)
+		}
+		*e = *decoded
+	case int64:
+		return fmt.Errorf("Unable to convert %v of %T to Eth, is the sql type set to varchar?", value, value)
+	default:
+		return fmt.Errorf("Unable to convert %v of %T to Eth", value, value)
+	}
+
+	return nil
+}
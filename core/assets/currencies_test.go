@@ -125,3 +125,21 @@ func TestAssets_Eth_UnmarshalJsonError(t *testing.T) {
 	err = json.Unmarshal([]byte(`1`), &eth)
 	assert.Equal(t, assets.ErrNoQuotesForCurrency, err)
 }
+
+func TestAssets_Eth_ValueAndScan(t *testing.T) {
+	t.Parallel()
+
+	eth := assets.NewEth(123)
+	value, err := eth.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "123", value)
+
+	scanned := assets.Eth{}
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, eth, &scanned)
+
+	assert.NoError(t, scanned.Scan([]uint8("456")))
+	assert.Equal(t, assets.NewEth(456), &scanned)
+
+	assert.Error(t, scanned.Scan(int64(1)))
+}
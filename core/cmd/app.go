@@ -180,6 +180,17 @@ func NewApp(client *Client) *cli.App {
 			Name:  "jobs",
 			Usage: "Commands for managing Jobs",
 			Subcommands: []cli.Command{
+				{
+					Name:   "approval",
+					Usage:  "Show why a Job awaits approval, its changes since the last approved version, and the audit trail of its approvals",
+					Action: client.ShowJobApproval,
+				},
+				{
+					Name:   "approve",
+					Usage:  "Approve a proposed version of a Job as a job approver, starting its initiators",
+					Action: client.ApproveJobSpec,
+					Flags:  jobApprovalFlags,
+				},
 				{
 					Name:   "archive",
 					Usage:  "Archive a Job and all its associated Runs",
@@ -187,9 +198,39 @@ func NewApp(client *Client) *cli.App {
 				},
 				{
 					Name:   "create",
-					Usage:  "Create Job from a Job Specification JSON",
+					Usage:  "Create Job from a Job Specification JSON, or a .json, .toml or .yaml file",
 					Action: client.CreateJobSpec,
 				},
+				{
+					Name:   "diff",
+					Usage:  "Show the changes made to a Job between two of its versions",
+					Action: client.DiffJobSpec,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "from",
+							Usage: "version to compare from, defaults to the one before --to",
+						},
+						cli.IntFlag{
+							Name:  "to",
+							Usage: "version to compare to, defaults to the current version",
+						},
+					},
+				},
+				{
+					Name:   "fm-status",
+					Usage:  "Show what each of a Job's flux monitor initiators last polled, and why it did or did not submit",
+					Action: client.ShowJobFluxMonitorStatus,
+				},
+				{
+					Name:   "from-template",
+					Usage:  "Create a Job from a Spec Template for each set of variables in a JSON blob, or a .json, .toml or .yaml file",
+					Action: client.CreateJobSpecsFromTemplate,
+				},
+				{
+					Name:   "lint",
+					Usage:  "Check a Job Specification JSON, or a .json, .toml or .yaml file, for problems without a node",
+					Action: client.LintJobSpec,
+				},
 				{
 					Name:   "list",
 					Usage:  "List all jobs",
@@ -199,12 +240,79 @@ func NewApp(client *Client) *cli.App {
 							Name:  "page",
 							Usage: "page of results to display",
 						},
+						cli.StringFlag{
+							Name:  "cursor",
+							Usage: "page through results by cursor, starting after the cursor given, or at the first result if empty",
+						},
+						cli.StringSliceFlag{
+							Name:  "label",
+							Usage: "only list jobs with the label, may be repeated",
+						},
+						cli.StringFlag{
+							Name:  "initiator",
+							Usage: "only list jobs with an initiator of the type",
+						},
+						cli.StringFlag{
+							Name:  "task",
+							Usage: "only list jobs with a task of the type",
+						},
+						cli.StringFlag{
+							Name:  "bridge",
+							Usage: "only list jobs with a task using the bridge",
+						},
+						cli.StringFlag{
+							Name:  "archived",
+							Usage: "list archived jobs: true, false or all",
+						},
 					},
 				},
+				{
+					Name:   "pause",
+					Usage:  "Stop a Job from starting runs until it is resumed",
+					Action: client.PauseJobSpec,
+				},
+				{
+					Name:   "reject",
+					Usage:  "Reject a proposed version of a Job as a job approver",
+					Action: client.RejectJobSpec,
+					Flags:  jobApprovalFlags,
+				},
+				{
+					Name:   "resume",
+					Usage:  "Resume a paused Job, starting the runs queued while it was paused",
+					Action: client.ResumeJobSpec,
+				},
 				{
 					Name:   "show",
 					Usage:  "Show a specific Job's details",
 					Action: client.ShowJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "format",
+							Usage: "write the Job Specification as json, toml or yaml instead of showing its details",
+						},
+					},
+				},
+				{
+					Name:   "simulate",
+					Usage:  "Run a Job Specification JSON against sample input without creating the Job or sending transactions",
+					Action: client.SimulateJobSpec,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "input, i",
+							Usage: "JSON blob or file holding the request data to run the job with",
+						},
+					},
+				},
+				{
+					Name:   "update",
+					Usage:  "Replace a Job with a new version from a Job Specification JSON, or a .json, .toml or .yaml file, keeping its ID",
+					Action: client.UpdateJobSpec,
+				},
+				{
+					Name:   "versions",
+					Usage:  "List every version of a Job",
+					Action: client.IndexJobSpecVersions,
 				},
 			},
 		},
@@ -215,17 +323,76 @@ func NewApp(client *Client) *cli.App {
 			Usage:       "Commands for admin actions that must be run locally",
 			Description: "Commands can only be run from on the same machine as the Chainlink node.",
 			Subcommands: []cli.Command{
+				{
+					Name:  "approvers",
+					Usage: "Manage the approvers of Jobs held for approval by JOB_APPROVAL_POLICY",
+					Subcommands: []cli.Command{
+						{
+							Name:   "add",
+							Usage:  "Add a job approver with the given email, prompting for their password",
+							Action: client.AddJobApprover,
+						},
+						{
+							Name:   "list",
+							Usage:  "List the job approvers",
+							Action: client.ListJobApprovers,
+						},
+						{
+							Name:   "remove",
+							Usage:  "Remove the job approver with the given email",
+							Action: client.RemoveJobApprover,
+						},
+					},
+				},
 				{
 					Name:        "deleteuser",
 					Usage:       "Erase the *local node's* user and corresponding session to force recreation on next node launch.",
 					Description: "Does not work remotely over API.",
 					Action:      client.DeleteUser,
 				},
+				{
+					Name:  "export",
+					Usage: "Export jobs, service agreements, bridges, external initiators and stored configuration to a signed bundle",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "file, f",
+							Usage: "path of the bundle to write",
+						},
+						cli.StringFlag{
+							Name:  "password, p",
+							Usage: "text file holding the password for the node's account, which signs the bundle",
+						},
+						cli.StringFlag{
+							Name:  "bundle-password, bp",
+							Usage: "text file holding the password to encrypt the bundle's secrets with",
+						},
+						cli.BoolFlag{
+							Name:  "keys",
+							Usage: "include the node's ETH and VRF keys, encrypted with their own passwords",
+						},
+					},
+					Action: client.ExportNodeBundle,
+				},
 				{
 					Name:    "import",
 					Aliases: []string{"i"},
-					Usage:   "Import a key file to use with the node",
-					Action:  client.ImportKey,
+					Usage:   "Import a key file or node bundle to use with the node. Restart the node to use an imported bundle",
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "bundle-password, bp",
+							Usage: "text file holding the password the bundle's secrets were encrypted with",
+						},
+						cli.StringFlag{
+							Name:  "signer",
+							Usage: "only accept a bundle signed by this address, defaulting to the node's NODE_BUNDLE_SIGNER",
+						},
+						cli.StringFlag{
+							Name:  "on-conflict",
+							Usage: "what to do with items the node already has: fail, skip or overwrite",
+							Value: "fail",
+						},
+					},
+					Action: client.Import,
 				},
 				{
 					Name:    "start",
@@ -345,6 +512,17 @@ func NewApp(client *Client) *cli.App {
 			Usage:  "Commands for managing External Initiators",
 			Hidden: !client.Config.Dev() && !client.Config.FeatureExternalInitiators(),
 			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "List External Initiators, with their health",
+					Action: client.IndexExternalInitiators,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+					},
+				},
 				{
 					Name:   "create",
 					Usage:  "Create an authentication key for a user of External Initiators",
@@ -378,10 +556,38 @@ func NewApp(client *Client) *cli.App {
 							Name:  "page",
 							Usage: "page of results to display",
 						},
+						cli.StringFlag{
+							Name:  "cursor",
+							Usage: "page through results by cursor, starting after the cursor given, or at the first result if empty",
+						},
 						cli.StringFlag{
 							Name:  "jobid",
 							Usage: "filter all Runs to match the given jobid",
 						},
+						cli.StringSliceFlag{
+							Name:  "status",
+							Usage: "only list Runs with the status, may be repeated",
+						},
+						cli.StringFlag{
+							Name:  "initiator",
+							Usage: "only list Runs started by an initiator of the type",
+						},
+						cli.StringFlag{
+							Name:  "after",
+							Usage: "only list Runs created at or after the RFC3339 time",
+						},
+						cli.StringFlag{
+							Name:  "before",
+							Usage: "only list Runs created before the RFC3339 time",
+						},
+						cli.StringFlag{
+							Name:  "requester",
+							Usage: "only list Runs requested by the address",
+						},
+						cli.StringFlag{
+							Name:  "txhash",
+							Usage: "only list Runs requested in, or sending, the transaction",
+						},
 					},
 				},
 				{
@@ -390,6 +596,30 @@ func NewApp(client *Client) *cli.App {
 					Usage:   "Show a Run for a specific ID",
 					Action:  client.ShowJobRun,
 				},
+				{
+					Name:        "watch",
+					Usage:       "Watch Runs change status as they execute",
+					Description: "Takes an optional Run ID, stopping once that Run has finished",
+					Action:      client.WatchJobRuns,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "jobid",
+							Usage: "only watch Runs of the given jobid",
+						},
+					},
+				},
+				{
+					Name:        "replay",
+					Usage:       "Replay a Run without side effects and compare it with the original",
+					Description: "Takes a Run ID. Responses recorded in the Run's HTTP audit trail are replayed rather than fetched, and tasks without them error rather than sending requests",
+					Action:      client.ReplayJobRun,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "current",
+							Usage: "replay the Job's current tasks instead of the Run's original tasks",
+						},
+					},
+				},
 				{
 					Name:   "cancel",
 					Usage:  "Cancel a Run with a specified ID",
@@ -398,6 +628,50 @@ func NewApp(client *Client) *cli.App {
 			},
 		},
 
+		{
+			Name:  "templates",
+			Usage: "Commands for managing Job Spec Templates",
+			Subcommands: []cli.Command{
+				{
+					Name:   "create",
+					Usage:  "Create a Spec Template from JSON, or a .json, .toml or .yaml file",
+					Action: client.CreateSpecTemplate,
+				},
+				{
+					Name:   "list",
+					Usage:  "List all Spec Templates",
+					Action: client.IndexSpecTemplates,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+					},
+				},
+				{
+					Name:   "rollout",
+					Usage:  "Update the Jobs created from earlier versions of a Spec Template to its current version",
+					Action: client.RolloutSpecTemplate,
+				},
+				{
+					Name:   "show",
+					Usage:  "Show a Spec Template and the Jobs on earlier versions of it",
+					Action: client.ShowSpecTemplate,
+				},
+				{
+					Name:   "update",
+					Usage:  "Save a new version of a Spec Template from JSON, or a .json, .toml or .yaml file",
+					Action: client.UpdateSpecTemplate,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "rollout",
+							Usage: "also update the Jobs created from the Spec Template to the new version",
+						},
+					},
+				},
+			},
+		},
+
 		{
 			Name:  "txs",
 			Usage: "Commands for handling Ethereum transactions",
@@ -442,5 +716,21 @@ func format(s string) string {
 	return string(whitespace.ReplaceAll([]byte(s), []byte(" ")))
 }
 
+// jobApprovalFlags are the flags of an approver's decision on a Job
+var jobApprovalFlags = []cli.Flag{
+	cli.UintFlag{
+		Name:  "version",
+		Usage: "version of the Job being decided on, as shown by jobs approval",
+	},
+	cli.StringFlag{
+		Name:  "email",
+		Usage: "email of the job approver",
+	},
+	cli.StringFlag{
+		Name:  "reason",
+		Usage: "reason recorded in the Job's approval audit trail",
+	},
+}
+
 // flags is an abbreviated way to express a CLI flag
 func flags(s string) []cli.Flag { return []cli.Flag{cli.StringFlag{Name: s}} }
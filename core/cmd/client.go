@@ -204,7 +204,7 @@ func (h *authenticatedHTTPClient) doRequest(verb, path string, body io.Reader, h
 
 	request.Header.Set("Content-Type", "application/json")
 	for key, value := range headers {
-		request.Header.Add(key, value)
+		request.Header.Set(key, value)
 	}
 	request.AddCookie(cookie)
 	return h.client.Do(request)
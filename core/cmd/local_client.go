@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -12,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	"chainlink/core/logger"
+	"chainlink/core/services"
 	"chainlink/core/services/chainlink"
 	strpkg "chainlink/core/store"
 	"chainlink/core/store/models"
@@ -289,6 +291,63 @@ func (cli *Client) DeleteUser(c *clipkg.Context) error {
 	return err
 }
 
+// AddJobApprover adds an approver of the jobs held for approval, who must not
+// be the API user
+func (cli *Client) AddJobApprover(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the approver's email"))
+	}
+	email := c.Args().First()
+
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	app := cli.AppFactory.NewApplication(cli.Config)
+	defer app.Stop()
+	store := app.GetStore()
+
+	if user, err := store.FindUser(); err == nil && strings.EqualFold(user.Email, email) {
+		return cli.errorOut(errors.New("The API user cannot approve jobs"))
+	}
+	approver, err := models.NewJobApprover(email, cli.PasswordPrompter.Prompt())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if err := store.CreateJobApprover(&approver); err != nil {
+		return cli.errorOut(err)
+	}
+	logger.Info("Added job approver ", approver.Email)
+	return nil
+}
+
+// ListJobApprovers lists the approvers of the jobs held for approval
+func (cli *Client) ListJobApprovers(c *clipkg.Context) error {
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	app := cli.AppFactory.NewApplication(cli.Config)
+	defer app.Stop()
+
+	approvers, err := app.GetStore().JobApprovers()
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	return cli.errorOut(cli.Render(&approvers))
+}
+
+// RemoveJobApprover removes an approver of the jobs held for approval
+func (cli *Client) RemoveJobApprover(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the approver's email"))
+	}
+
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	app := cli.AppFactory.NewApplication(cli.Config)
+	defer app.Stop()
+
+	if err := app.GetStore().DeleteJobApprover(c.Args().First()); err != nil {
+		return cli.errorOut(err)
+	}
+	logger.Info("Removed job approver ", c.Args().First())
+	return nil
+}
+
 // ImportKey imports a key to be used with the chainlink node
 func (cli *Client) ImportKey(c *clipkg.Context) error {
 	logger.SetLogger(cli.Config.CreateProductionLogger())
@@ -321,6 +380,38 @@ func (cli *Client) ImportKey(c *clipkg.Context) error {
 	return app.GetStore().SyncDiskKeyStoreToDB()
 }
 
+// LintJobSpec checks a job spec from JSON input, or a JSON, TOML or YAML
+// file, without a node, logging each problem it finds.
+func (cli *Client) LintJobSpec(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJobSpec(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	var request models.JobSpecRequest
+	if err := json.Unmarshal(buf.Bytes(), &request); err != nil {
+		return cli.errorOut(fmt.Errorf("invalid job spec: %v", err))
+	}
+
+	warnings, err := services.LintJob(models.NewJobFromRequest(request))
+	for _, warning := range warnings {
+		logger.Warn(warning)
+	}
+	if problems, ok := err.(*models.JSONAPIErrors); ok {
+		for _, problem := range problems.Errors {
+			logger.Error(problem.Detail)
+		}
+		return cli.errorOut(fmt.Errorf("job spec has %d problem(s)", len(problems.Errors)))
+	} else if err != nil {
+		return cli.errorOut(err)
+	}
+	logger.Info("Job spec is valid")
+	return nil
+}
+
 func copyFile(src, dst string) error {
 	from, err := os.Open(src)
 	if err != nil {
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"chainlink/core/logger"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
+)
+
+// Import imports a file to be used with the chainlink node, either a node
+// bundle written by ExportNodeBundle or a key file.
+func (cli *Client) Import(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in filepath to key or node bundle"))
+	}
+	b, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if bundle, ok := parseNodeBundle(b); ok {
+		return cli.importNodeBundle(c, bundle)
+	}
+	return cli.ImportKey(c)
+}
+
+// ExportNodeBundle writes the node's jobs, service agreements, bridges,
+// external initiators and stored configuration to a signed bundle, for
+// importing into another node.
+func (cli *Client) ExportNodeBundle(c *clipkg.Context) error {
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	if !c.IsSet("file") {
+		return cli.errorOut(errors.New("must specify file to export to"))
+	}
+	path := c.String("file")
+	_, err := os.Stat(path)
+	if err == nil {
+		return cli.errorOut(fmt.Errorf(
+			"refusing to overwrite existing file %s. Please move it or change the save path", path))
+	}
+	if !os.IsNotExist(err) {
+		return cli.errorOut(errors.Wrapf(err, "while checking whether file %s exists", path))
+	}
+	passphrase, err := bundlePassword(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	pwd, err := passwordFromFile(c.String("password"))
+	if err != nil {
+		return cli.errorOut(errors.Wrap(err, "error reading password"))
+	}
+
+	app := cli.AppFactory.NewApplication(cli.Config)
+	store := app.GetStore()
+	if !store.KeyStore.HasAccounts() {
+		return cli.errorOut(errors.New("node has no account to sign the bundle with"))
+	}
+	if err := store.KeyStore.Unlock(pwd); err != nil {
+		return cli.errorOut(errors.Wrap(err, "error unlocking keystore"))
+	}
+
+	bundle, err := services.ExportNodeBundle(store, services.NodeBundleExportOptions{
+		Passphrase:  passphrase,
+		IncludeKeys: c.Bool("keys"),
+	})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	b, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return cli.errorOut(errors.Wrapf(err, "could not save node bundle to %s", path))
+	}
+	logger.Infow("Exported node bundle", "file", path, "signer", bundle.Signer.Hex())
+	return nil
+}
+
+func (cli *Client) importNodeBundle(c *clipkg.Context, bundle models.NodeBundle) error {
+	logger.SetLogger(cli.Config.CreateProductionLogger())
+	passphrase, err := bundlePassword(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	mode, err := services.ParseNodeBundleConflictMode(c.String("on-conflict"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	opts := services.NodeBundleImportOptions{Passphrase: passphrase, OnConflict: mode}
+	if c.IsSet("signer") {
+		if !common.IsHexAddress(c.String("signer")) {
+			return cli.errorOut(fmt.Errorf("signer %s is not a hex encoded address", c.String("signer")))
+		}
+		signer := common.HexToAddress(c.String("signer"))
+		opts.Signer = &signer
+	}
+
+	app := cli.AppFactory.NewApplication(cli.Config)
+	report, err := services.ImportNodeBundle(app.GetStore(), bundle, opts)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	logger.Infow("Imported node bundle",
+		"signer", report.Signer.Hex(),
+		"created", report.Created,
+		"updated", report.Updated,
+		"skipped", report.Skipped,
+	)
+	return nil
+}
+
+// parseNodeBundle returns the node bundle held by the file, if it holds one
+// rather than a key.
+func parseNodeBundle(b []byte) (models.NodeBundle, bool) {
+	var bundle models.NodeBundle
+	if err := json.Unmarshal(b, &bundle); err != nil {
+		return bundle, false
+	}
+	return bundle, bundle.Version != 0 && len(bundle.Contents) > 0
+}
+
+func bundlePassword(c *clipkg.Context) (string, error) {
+	if !c.IsSet("bundle-password") {
+		return "", errors.New("must specify bundle password file")
+	}
+	passphrase, err := passwordFromFile(c.String("bundle-password"))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read bundle password from file %s", c.String("bundle-password"))
+	}
+	return passphrase, nil
+}
@@ -232,6 +232,37 @@ func TestClient_ImportKey(t *testing.T) {
 	require.Equal(t, expectation, addresses)
 }
 
+func TestClient_LintJobSpec(t *testing.T) {
+	t.Parallel()
+
+	client := cmd.Client{}
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid file", "../internal/fixtures/web/end_at_job.toml", false},
+		{"bridge task", `{"initiators":[{"type":"web"}],"tasks":[{"type":"prices"},{"type":"ethtx"}]}`, false},
+		{"unformatted ethtx", `{"initiators":[{"type":"web"}],"tasks":[{"type":"httpget"},{"type":"ethtx"}]}`, true},
+		{"no initiators", `{"tasks":[{"type":"noop"}]}`, true},
+		{"missing file", "does_not_exist.json", true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			set := flag.NewFlagSet("lint", 0)
+			set.Parse([]string{test.input})
+			c := cli.NewContext(nil, set, nil)
+			if test.wantErr {
+				assert.Error(t, client.LintJobSpec(c))
+			} else {
+				assert.NoError(t, client.LintJobSpec(c))
+			}
+		})
+	}
+}
+
 func TestClient_LogToDiskOptionDisablesAsExpected(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -18,6 +18,7 @@ import (
 	"chainlink/core/web"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
 	"github.com/manyminds/api2go/jsonapi"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -66,6 +67,12 @@ func (cli *Client) CreateServiceAgreement(c *clipkg.Context) error {
 	return cli.renderAPIResponse(resp, &sa)
 }
 
+// IndexExternalInitiators lists the external initiators, with their health
+// and how many notifications to them are waiting to be sent
+func (cli *Client) IndexExternalInitiators(c *clipkg.Context) error {
+	return cli.getPage("/v2/external_initiators", c.Int("page"), &[]presenters.ExternalInitiator{})
+}
+
 // CreateExternalInitiator adds an external initiator
 func (cli *Client) CreateExternalInitiator(c *clipkg.Context) error {
 	if c.NArg() != 1 && c.NArg() != 2 {
@@ -132,11 +139,81 @@ func (cli *Client) ShowJobRun(c *clipkg.Context) error {
 // IndexJobRuns returns the list of all job runs for a specific job
 // if no jobid is passed, defaults to returning all jobruns
 func (cli *Client) IndexJobRuns(c *clipkg.Context) error {
-	jobID := c.String("jobid")
-	if jobID != "" {
-		return cli.getPage("/v2/runs?jobSpecId="+jobID, c.Int("page"), &[]presenters.JobRun{})
+	query := url.Values{}
+	setQuery(query, "jobSpecId", c.String("jobid"))
+	for _, status := range c.StringSlice("status") {
+		query.Add("status", status)
+	}
+	setQuery(query, "initiatorType", c.String("initiator"))
+	setQuery(query, "createdAfter", c.String("after"))
+	setQuery(query, "createdBefore", c.String("before"))
+	setQuery(query, "requester", c.String("requester"))
+	setQuery(query, "txHash", c.String("txhash"))
+	return cli.getFilteredPage(c, "/v2/runs", query, &[]presenters.JobRun{})
+}
+
+// WatchJobRuns renders job runs as they change. Given a RunID it stops once
+// that run has finished, otherwise it watches all runs, or those of jobid,
+// until the node closes the stream.
+func (cli *Client) WatchJobRuns(c *clipkg.Context) error {
+	query := url.Values{}
+	runID := c.Args().First()
+	if runID != "" {
+		query.Set("runId", runID)
+	}
+	if jobID := c.String("jobid"); jobID != "" {
+		query.Set("jobSpecId", jobID)
+	}
+
+	streamURL, err := url.Parse(cli.Config.ClientNodeURL())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	switch streamURL.Scheme {
+	case "https":
+		streamURL.Scheme = "wss"
+	default:
+		streamURL.Scheme = "ws"
+	}
+	streamURL.Path = "/v2/run_updates"
+	streamURL.RawQuery = query.Encode()
+
+	cookie, err := cli.CookieAuthenticator.Cookie()
+	if err != nil {
+		return cli.errorOut(err)
+	} else if cookie == nil {
+		return cli.errorOut(errUnauthorized)
+	}
+	header := http.Header{}
+	header.Set("Cookie", cookie.String())
+
+	conn, resp, err := websocket.DefaultDialer.Dial(streamURL.String(), header)
+	if err != nil {
+		if resp != nil {
+			defer resp.Body.Close()
+			if _, perr := cli.parseResponse(resp); perr != nil {
+				return perr
+			}
+		}
+		return cli.errorOut(err)
+	}
+	defer conn.Close()
+
+	for {
+		var run presenters.JobRun
+		if err := conn.ReadJSON(&run); err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return cli.errorOut(err)
+		}
+		if err := cli.Render(&run); err != nil {
+			return cli.errorOut(err)
+		}
+		if runID != "" && run.Status.Finished() {
+			return nil
+		}
 	}
-	return cli.getPage("/v2/runs", c.Int("page"), &[]presenters.JobRun{})
 }
 
 // ShowJobSpec returns the status of the given JobID.
@@ -144,27 +221,51 @@ func (cli *Client) ShowJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
 		return cli.errorOut(errors.New("Must pass the job id to be shown"))
 	}
+	format, err := models.ParseJobSpecFormat(c.String("format"))
+	if c.IsSet("format") && err != nil {
+		return cli.errorOut(err)
+	}
 	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
 	}
 	defer resp.Body.Close()
 	var job presenters.JobSpec
-	return cli.renderAPIResponse(resp, &job)
+	if !c.IsSet("format") {
+		return cli.renderAPIResponse(resp, &job)
+	}
+
+	if err = cli.deserializeAPIResponse(resp, &job, &jsonapi.Links{}); err != nil {
+		return cli.errorOut(err)
+	}
+	spec, err := models.FormatJobSpecRequest(job.Request(), format)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	return cli.errorOut(cli.Render(&models.JobSpecDocument{Format: format, Spec: string(spec)}))
 }
 
 // IndexJobSpecs returns all job specs.
 func (cli *Client) IndexJobSpecs(c *clipkg.Context) error {
-	return cli.getPage("/v2/specs", c.Int("page"), &[]models.JobSpec{})
+	query := url.Values{}
+	for _, label := range c.StringSlice("label") {
+		query.Add("label", label)
+	}
+	setQuery(query, "initiatorType", c.String("initiator"))
+	setQuery(query, "taskType", c.String("task"))
+	setQuery(query, "bridge", c.String("bridge"))
+	setQuery(query, "archived", c.String("archived"))
+	return cli.getFilteredPage(c, "/v2/specs", query, &[]models.JobSpec{})
 }
 
-// CreateJobSpec creates a JobSpec based on JSON input
+// CreateJobSpec creates a JobSpec based on JSON input, or a JSON, TOML or
+// YAML file
 func (cli *Client) CreateJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
 		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
 	}
 
-	buf, err := getBufferFromJSON(c.Args().First())
+	buf, err := getBufferFromJobSpec(c.Args().First())
 	if err != nil {
 		return cli.errorOut(err)
 	}
@@ -179,6 +280,332 @@ func (cli *Client) CreateJobSpec(c *clipkg.Context) error {
 	return cli.renderAPIResponse(resp, &js)
 }
 
+// UpdateJobSpec replaces a JobSpec with a new version from JSON input, or a
+// JSON, TOML or YAML file, keeping its ID
+func (cli *Client) UpdateJobSpec(c *clipkg.Context) error {
+	if len(c.Args()) != 2 {
+		return cli.errorOut(errors.New("Must pass the job id and JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJobSpec(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Patch("/v2/specs/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// PauseJobSpec stops a JobSpec from starting runs until it is resumed
+func (cli *Client) PauseJobSpec(c *clipkg.Context) error {
+	return cli.setJobSpecPaused(c, "pause")
+}
+
+// ResumeJobSpec restarts a paused JobSpec
+func (cli *Client) ResumeJobSpec(c *clipkg.Context) error {
+	return cli.setJobSpecPaused(c, "resume")
+}
+
+func (cli *Client) setJobSpecPaused(c *clipkg.Context, action string) error {
+	if !c.Args().Present() {
+		return cli.errorOut(fmt.Errorf("Must pass the job id to %s", action))
+	}
+
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/"+action, nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// ShowJobApproval shows what must be approved of a JobSpec: why it needs
+// approval, its changes since the last approved version, and the audit trail
+// of its approvals
+func (cli *Client) ShowJobApproval(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First() + "/approval")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var approval models.JobApproval
+	return cli.renderAPIResponse(resp, &approval)
+}
+
+// ShowJobFluxMonitorStatus shows what each of a JobSpec's flux monitor
+// initiators last did, and why it did or did not submit
+func (cli *Client) ShowJobFluxMonitorStatus(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First() + "/flux_monitor")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var states []models.FluxMonitorState
+	return cli.renderAPIResponse(resp, &states)
+}
+
+// ApproveJobSpec approves a proposed version of a JobSpec as a job approver,
+// prompting for the approver's password
+func (cli *Client) ApproveJobSpec(c *clipkg.Context) error {
+	return cli.decideJobApproval(c, "approve")
+}
+
+// RejectJobSpec rejects a proposed version of a JobSpec as a job approver,
+// prompting for the approver's password
+func (cli *Client) RejectJobSpec(c *clipkg.Context) error {
+	return cli.decideJobApproval(c, "reject")
+}
+
+func (cli *Client) decideJobApproval(c *clipkg.Context, action string) error {
+	if !c.Args().Present() {
+		return cli.errorOut(fmt.Errorf("Must pass the job id to %s", action))
+	}
+	if !c.IsSet("version") {
+		return cli.errorOut(fmt.Errorf("Must pass the version to %s", action))
+	}
+	if c.String("email") == "" {
+		return cli.errorOut(errors.New("Must pass the approver's email"))
+	}
+
+	requestData, err := json.Marshal(models.JobApprovalRequest{
+		Email:    c.String("email"),
+		Password: cli.PasswordPrompter.Prompt(),
+		Version:  uint32(c.Uint("version")),
+		Reason:   c.String("reason"),
+	})
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	buf := bytes.NewBuffer(requestData)
+	resp, err := cli.HTTP.Post("/v2/specs/"+c.Args().First()+"/"+action, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var js presenters.JobSpec
+	return cli.renderAPIResponse(resp, &js)
+}
+
+// IndexJobSpecVersions lists every version of a JobSpec
+func (cli *Client) IndexJobSpecVersions(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+
+	resp, err := cli.HTTP.Get("/v2/specs/" + c.Args().First() + "/versions")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var versions []models.JobSpecVersion
+	return cli.renderAPIResponse(resp, &versions)
+}
+
+// DiffJobSpec shows the changes made to a JobSpec between two versions,
+// by default its current version and the one before it
+func (cli *Client) DiffJobSpec(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the job id"))
+	}
+
+	query := url.Values{}
+	if c.IsSet("from") {
+		query.Set("from", strconv.Itoa(c.Int("from")))
+	}
+	if c.IsSet("to") {
+		query.Set("to", strconv.Itoa(c.Int("to")))
+	}
+	path := "/v2/specs/" + c.Args().First() + "/diff"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := cli.HTTP.Get(path)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var diff models.JobSpecDiff
+	return cli.renderAPIResponse(resp, &diff)
+}
+
+// CreateJobSpecsFromTemplate creates a JobSpec from a job spec template for
+// each set of variables in JSON input, or a JSON, TOML or YAML file. The
+// variables are given as a list, as an object listing them under
+// "instances", or as a single object for one job
+func (cli *Client) CreateJobSpecsFromTemplate(c *clipkg.Context) error {
+	if len(c.Args()) != 2 {
+		return cli.errorOut(errors.New("Must pass the template id and variables JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJobSpec(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	variables := gjson.ParseBytes(buf.Bytes())
+	switch {
+	case variables.IsArray():
+		buf = bytes.NewBufferString(`{"instances":` + variables.Raw + `}`)
+	case variables.IsObject() && !variables.Get("instances").Exists():
+		buf = bytes.NewBufferString(`{"instances":[` + variables.Raw + `]}`)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/spec_templates/"+c.Args().First()+"/instances", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var jobs []models.JobSpec
+	return cli.renderAPIResponse(resp, &jobs)
+}
+
+// IndexSpecTemplates returns all job spec templates.
+func (cli *Client) IndexSpecTemplates(c *clipkg.Context) error {
+	return cli.getPage("/v2/spec_templates", c.Int("page"), &[]models.SpecTemplate{})
+}
+
+// ShowSpecTemplate returns the details of a job spec template.
+func (cli *Client) ShowSpecTemplate(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the template id to be shown"))
+	}
+	resp, err := cli.HTTP.Get("/v2/spec_templates/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var template presenters.SpecTemplate
+	return cli.renderAPIResponse(resp, &template)
+}
+
+// CreateSpecTemplate creates a job spec template based on JSON input, or a
+// JSON, TOML or YAML file
+func (cli *Client) CreateSpecTemplate(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJobSpec(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/spec_templates", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var template presenters.SpecTemplate
+	return cli.renderAPIResponse(resp, &template)
+}
+
+// UpdateSpecTemplate saves a new version of a job spec template from JSON
+// input, or a JSON, TOML or YAML file. The jobs created from the template
+// keep their version unless the rollout flag is set
+func (cli *Client) UpdateSpecTemplate(c *clipkg.Context) error {
+	if len(c.Args()) != 2 {
+		return cli.errorOut(errors.New("Must pass the template id and JSON or filepath"))
+	}
+
+	buf, err := getBufferFromJobSpec(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Patch("/v2/spec_templates/"+c.Args().First(), buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var template presenters.SpecTemplate
+	if err = cli.renderAPIResponse(resp, &template); err != nil || !c.Bool("rollout") {
+		return err
+	}
+	return cli.RolloutSpecTemplate(c)
+}
+
+// RolloutSpecTemplate updates the jobs created from an earlier version of a
+// job spec template to its current version
+func (cli *Client) RolloutSpecTemplate(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the template id"))
+	}
+
+	resp, err := cli.HTTP.Post("/v2/spec_templates/"+c.Args().First()+"/rollout", nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var jobs []models.JobSpec
+	return cli.renderAPIResponse(resp, &jobs)
+}
+
+// SimulateJobSpec runs a JobSpec's tasks against sample input without saving
+// the job or its run, rendering each task's input and output
+func (cli *Client) SimulateJobSpec(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in JSON or filepath"))
+	}
+
+	spec, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	input := bytes.NewBufferString("{}")
+	if c.IsSet("input") {
+		input, err = getBufferFromJSON(c.String("input"))
+		if err != nil {
+			return cli.errorOut(err)
+		}
+	}
+
+	request := struct {
+		Spec  json.RawMessage `json:"spec"`
+		Input json.RawMessage `json:"input"`
+	}{spec.Bytes(), input.Bytes()}
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/spec_simulations", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var simulation models.RunSimulation
+	return cli.renderAPIResponse(resp, &simulation)
+}
+
 // ArchiveJobSpec soft deletes a job and its associated runs.
 func (cli *Client) ArchiveJobSpec(c *clipkg.Context) error {
 	if !c.Args().Present() {
@@ -269,6 +696,41 @@ func (cli *Client) getPage(requestURI string, page int, model interface{}) error
 	return cli.errorOut(cli.Render(model))
 }
 
+// getFilteredPage renders the page of the filtered resources chosen by the
+// page or cursor flag. When paging by cursor, the cursor of the next page is
+// printed after the page.
+func (cli *Client) getFilteredPage(c *clipkg.Context, path string, query url.Values, model interface{}) error {
+	if !c.IsSet("cursor") {
+		return cli.getPage(path+"?"+query.Encode(), c.Int("page"), model)
+	}
+	query.Set("cursor", c.String("cursor"))
+	resp, err := cli.HTTP.Get(path + "?" + query.Encode())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	links := jsonapi.Links{}
+	if err = cli.deserializeAPIResponse(resp, model, &links); err != nil {
+		return err
+	}
+	if err = cli.Render(model); err != nil {
+		return cli.errorOut(err)
+	}
+	if next, ok := links[web.KeyNextLink]; ok {
+		if u, err := url.Parse(next.Href); err == nil {
+			fmt.Printf("Next page: --cursor %s\n", u.Query().Get("cursor"))
+		}
+	}
+	return nil
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
 // ShowBridge returns the info for the given Bridge name.
 func (cli *Client) ShowBridge(c *clipkg.Context) error {
 	if !c.Args().Present() {
@@ -495,6 +957,20 @@ func getBufferFromJSON(s string) (*bytes.Buffer, error) {
 	return buf, nil
 }
 
+// getBufferFromJobSpec returns a JSON job spec given either JSON or the path
+// to a JSON, TOML or YAML file, telling the formats apart by file extension.
+func getBufferFromJobSpec(s string) (*bytes.Buffer, error) {
+	buf, err := getBufferFromJSON(s)
+	if err != nil || gjson.Valid(s) {
+		return buf, err
+	}
+	b, err := models.JobSpecToJSON(buf.Bytes(), models.JobSpecFormatFromPath(s))
+	if err != nil {
+		return nil, fmt.Errorf("Error reading from file '%s': %v", s, err)
+	}
+	return bytes.NewBuffer(b), nil
+}
+
 func fromFile(arg string) (*bytes.Buffer, error) {
 	dir, err := homedir.Expand(arg)
 	if err != nil {
@@ -653,3 +1129,24 @@ func (cli *Client) CancelJobRun(c *clipkg.Context) error {
 	}
 	return nil
 }
+
+// ReplayJobRun re-executes a run without side effects and renders how each
+// task's result compares with the original
+func (cli *Client) ReplayJobRun(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the run id to be replayed"))
+	}
+
+	spec := "original"
+	if c.Bool("current") {
+		spec = "current"
+	}
+	resp, err := cli.HTTP.Post(fmt.Sprintf("/v2/runs/%s/replay?spec=%s", c.Args().First(), spec), nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var replay models.RunReplay
+	return cli.renderAPIResponse(resp, &replay)
+}
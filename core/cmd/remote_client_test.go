@@ -60,6 +60,34 @@ func TestClient_IndexJobSpecs(t *testing.T) {
 	assert.Equal(t, j1.ID, jobs[0].ID)
 }
 
+func TestClient_IndexJobSpecs_Filters(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j1 := cltest.NewJob()
+	j1.Labels = []string{"eth-usd"}
+	require.NoError(t, app.Store.CreateJob(&j1))
+	j2 := cltest.NewJob()
+	require.NoError(t, app.Store.CreateJob(&j2))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	labels := cli.StringSlice{}
+	set.Var(&labels, "label", "")
+	set.String("cursor", "", "")
+	require.NoError(t, set.Parse([]string{"--label", "eth-usd", "--cursor", ""}))
+	c := cli.NewContext(nil, set, nil)
+
+	require.Nil(t, client.IndexJobSpecs(c))
+	jobs := *r.Renders[0].(*[]models.JobSpec)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, j1.ID, jobs[0].ID)
+}
+
 func TestClient_ShowJobRun_Exists(t *testing.T) {
 	t.Parallel()
 
@@ -98,6 +126,99 @@ func TestClient_ShowJobRun_NotFound(t *testing.T) {
 	assert.Empty(t, r.Renders)
 }
 
+func TestClient_WatchJobRuns(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRun(j)
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{jr.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+
+	done := make(chan error)
+	go func() { done <- client.WatchJobRuns(c) }()
+
+	// The run is saved until the watcher has subscribed and seen it finish
+	jr.Status = models.RunStatusCompleted
+	timeout := time.After(10 * time.Second)
+	for finished := false; !finished; {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			finished = true
+		case <-time.After(100 * time.Millisecond):
+			require.NoError(t, app.Store.SaveJobRun(&jr))
+		case <-timeout:
+			t.Fatal("timed out watching run")
+		}
+	}
+
+	require.NotEmpty(t, r.Renders)
+	watched := r.Renders[len(r.Renders)-1].(*presenters.JobRun)
+	assert.Equal(t, jr.ID, watched.ID)
+	assert.Equal(t, models.RunStatusCompleted, watched.Status)
+}
+
+func TestClient_WatchJobRuns_Finished(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.CreateJobRunWithStatus(t, app.Store, j, models.RunStatusErrored)
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{jr.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+
+	require.NoError(t, client.WatchJobRuns(c))
+	require.Len(t, r.Renders, 1)
+	watched := r.Renders[0].(*presenters.JobRun)
+	assert.Equal(t, jr.ID, watched.ID)
+	assert.Equal(t, models.RunStatusErrored, watched.Status)
+}
+
+func TestClient_ReplayJobRun(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRun(j)
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("current", true, "")
+	set.Parse([]string{jr.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ReplayJobRun(c))
+
+	require.Len(t, r.Renders, 1)
+	replay := r.Renders[0].(*models.RunReplay)
+	assert.Equal(t, jr.ID, replay.JobRunID)
+	assert.Equal(t, "current", replay.Spec)
+	assert.Len(t, replay.TaskRuns, 1)
+}
+
 func TestClient_IndexJobRuns(t *testing.T) {
 	t.Parallel()
 
@@ -162,6 +283,36 @@ func TestClient_ShowJobSpec_NotFound(t *testing.T) {
 	assert.Empty(t, r.Renders)
 }
 
+func TestClient_ShowJobFluxMonitorStatus(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+	require.NoError(t, app.Store.SaveFluxMonitorState(&models.FluxMonitorState{
+		InitiatorID: job.Initiators[0].ID,
+		JobSpecID:   job.ID,
+		Reason:      "not eligible to submit",
+	}))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("test", 0)
+	set.Parse([]string{job.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ShowJobFluxMonitorStatus(c))
+	require.Equal(t, 1, len(r.Renders))
+	states := *r.Renders[0].(*[]models.FluxMonitorState)
+	require.Len(t, states, 1)
+	assert.Equal(t, job.Initiators[0].ID, states[0].InitiatorID)
+	assert.Equal(t, "not eligible to submit", states[0].Reason)
+
+	assert.Error(t, client.ShowJobFluxMonitorStatus(cli.NewContext(nil, flag.NewFlagSet("test", 0), nil)))
+}
+
 var EndAt = time.Now().AddDate(0, 10, 0).Round(time.Second).UTC()
 
 func TestClient_CreateServiceAgreement(t *testing.T) {
@@ -287,6 +438,28 @@ func TestClient_CreateExternalInitiator_Errors(t *testing.T) {
 	}
 }
 
+func TestClient_IndexExternalInitiators(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	exi, err := models.NewExternalInitiator(auth.NewToken(),
+		&models.ExternalInitiatorRequest{Name: "name"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, app.Store.CreateExternalInitiator(exi))
+
+	client, r := app.NewClientAndRenderer()
+
+	require.NoError(t, client.IndexExternalInitiators(cltest.EmptyCLIContext()))
+	eis := *r.Renders[0].(*[]presenters.ExternalInitiator)
+	require.Len(t, eis, 1)
+	assert.Equal(t, exi.Name, eis[0].Name)
+	assert.Equal(t, models.ExternalInitiatorStatusUnknown, eis[0].Status)
+}
+
 func TestClient_DestroyExternalInitiator(t *testing.T) {
 	t.Parallel()
 
@@ -346,6 +519,8 @@ func TestClient_CreateJobSpec(t *testing.T) {
 		{"web", `{"initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"}]}`, 1, false},
 		{"runAt", `{"initiators":[{"type":"runAt","params":{"time":"2018-01-08T18:12:01.103Z"}}],"tasks":[{"type":"NoOp"}]}`, 2, false},
 		{"file", "../internal/fixtures/web/end_at_job.json", 3, false},
+		{"toml file", "../internal/fixtures/web/end_at_job.toml", 4, false},
+		{"yaml file", "../internal/fixtures/web/end_at_job.yaml", 5, false},
 	}
 
 	for _, test := range tests {
@@ -363,6 +538,155 @@ func TestClient_CreateJobSpec(t *testing.T) {
 	}
 }
 
+func TestClient_ShowJobSpec_Format(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(job))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("show", 0)
+	set.String("format", "", "")
+	set.Parse([]string{"--format", "toml", job.ID.String()})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.ShowJobSpec(c))
+
+	require.Len(t, r.Renders, 1)
+	doc := r.Renders[0].(*models.JobSpecDocument)
+	assert.Equal(t, models.JobSpecFormatTOML, doc.Format)
+
+	jsr, err := models.ParseJobSpecRequest([]byte(doc.Spec), models.JobSpecFormatTOML)
+	require.NoError(t, err)
+	require.Len(t, jsr.Initiators, 1)
+	assert.Equal(t, models.InitiatorWeb, jsr.Initiators[0].Type)
+	assert.Equal(t, len(job.Tasks), len(jsr.Tasks))
+}
+
+func TestClient_ShowJobSpec_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("show", 0)
+	set.String("format", "", "")
+	set.Parse([]string{"--format", "xml", "190AE4CE-40B6-4D60-A3DA-061C5ACD32D0"})
+	c := cli.NewContext(nil, set, nil)
+	assert.Error(t, client.ShowJobSpec(c))
+}
+
+func TestClient_CreateJobSpecsFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	template := models.NewSpecTemplate(models.SpecTemplateRequest{
+		Name:      "multiplier",
+		Variables: models.SpecTemplateVariables{{Name: "times", Type: models.SpecTemplateInteger}},
+		Spec:      cltest.JSONFromString(t, `{"initiators":[{"type":"web"}],"tasks":[{"type":"multiply","params":{"times":"{{times}}"}}]}`),
+	})
+	require.NoError(t, app.Store.CreateSpecTemplate(&template))
+
+	client, r := app.NewClientAndRenderer()
+
+	tests := []struct {
+		name, input string
+		nJobs       int
+		errored     bool
+	}{
+		{"list", `[{"times": 10}, {"times": 100}]`, 2, false},
+		{"instances", `{"instances": [{"times": 1000}]}`, 3, false},
+		{"single", `{"times": 10000}`, 4, false},
+		{"invalid", `[{"times": 1}, {"times": "many"}]`, 4, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			set := flag.NewFlagSet("from-template", 0)
+			set.Parse([]string{template.ID.String(), test.input})
+			c := cli.NewContext(nil, set, nil)
+
+			err := client.CreateJobSpecsFromTemplate(c)
+			cltest.AssertError(t, test.errored, err)
+
+			instances, err := app.Store.SpecTemplateInstances(template.ID)
+			require.NoError(t, err)
+			assert.Len(t, instances, test.nJobs)
+		})
+	}
+
+	jobs := *r.Renders[0].(*[]models.JobSpec)
+	assert.Len(t, jobs, 2)
+}
+
+func TestClient_UpdateJobSpec(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(job))
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("update", 0)
+	set.Parse([]string{job.ID.String(), `{"initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"},{"type":"NoOp"}]}`})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.UpdateJobSpec(c))
+
+	updated := *r.Renders[0].(*presenters.JobSpec)
+	assert.Equal(t, job.ID, updated.ID)
+	assert.Equal(t, uint32(2), updated.Version)
+	assert.Len(t, updated.Tasks, 2)
+
+	set = flag.NewFlagSet("versions", 0)
+	set.Parse([]string{job.ID.String()})
+	c = cli.NewContext(nil, set, nil)
+	require.NoError(t, client.IndexJobSpecVersions(c))
+	assert.Len(t, *r.Renders[1].(*[]models.JobSpecVersion), 2)
+
+	set = flag.NewFlagSet("diff", 0)
+	set.Int("from", 0, "")
+	set.Parse([]string{"--from", "1", job.ID.String()})
+	c = cli.NewContext(nil, set, nil)
+	require.NoError(t, client.DiffJobSpec(c))
+	diff := *r.Renders[2].(*models.JobSpecDiff)
+	assert.Equal(t, uint32(2), diff.To)
+	paths := []string{}
+	for _, change := range diff.Changes {
+		paths = append(paths, change.Path)
+	}
+	assert.Contains(t, paths, "tasks.1")
+}
+
+func TestClient_UpdateJobSpec_RequiresIDAndSpec(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("update", 0)
+	set.Parse([]string{`{"initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"}]}`})
+	c := cli.NewContext(nil, set, nil)
+	assert.Error(t, client.UpdateJobSpec(c))
+}
+
 func TestClient_ArchiveJobSpec(t *testing.T) {
 	t.Parallel()
 
@@ -403,6 +727,28 @@ func TestClient_CreateJobSpec_JSONAPIErrors(t *testing.T) {
 	assert.Contains(t, err.Error(), "must have a time")
 }
 
+func TestClient_SimulateJobSpec(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("simulate", 0)
+	set.String("input", "", "")
+	set.Parse([]string{"--input", `{"result":"hi"}`, `{"initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"}]}`})
+	c := cli.NewContext(nil, set, nil)
+
+	require.NoError(t, client.SimulateJobSpec(c))
+	require.Len(t, r.Renders, 1)
+	simulation := *r.Renders[0].(*models.RunSimulation)
+	assert.Equal(t, models.RunStatusCompleted, simulation.Status)
+	assert.Equal(t, "hi", simulation.Result.Data.Get("result").String())
+	assert.Len(t, cltest.AllJobs(t, app.Store), 0)
+}
+
 func TestClient_CreateJobRun(t *testing.T) {
 	t.Parallel()
 
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"chainlink/core/logger"
 	"chainlink/core/store/models"
@@ -14,6 +17,7 @@ import (
 	"chainlink/core/web"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
 )
 
 // Renderer implements the Render method.
@@ -55,6 +59,26 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderJobRuns(*typed)
 	case *presenters.JobRun:
 		return rt.renderJobRun(*typed)
+	case *models.RunSimulation:
+		return rt.renderRunSimulation(*typed)
+	case *models.RunReplay:
+		return rt.renderRunReplay(*typed)
+	case *[]models.JobSpecVersion:
+		return rt.renderJobSpecVersions(*typed)
+	case *models.JobSpecDiff:
+		return rt.renderJobSpecDiff(*typed)
+	case *models.JobApproval:
+		return rt.renderJobApproval(*typed)
+	case *[]models.JobApprover:
+		return rt.renderJobApprovers(*typed)
+	case *[]models.FluxMonitorState:
+		return rt.renderFluxMonitorStates(*typed)
+	case *models.JobSpecDocument:
+		return rt.renderJobSpecDocument(*typed)
+	case *[]models.SpecTemplate:
+		return rt.renderSpecTemplates(*typed)
+	case *presenters.SpecTemplate:
+		return rt.renderSpecTemplate(*typed)
 	case *models.BridgeType:
 		return rt.renderBridge(*typed)
 	case *models.BridgeTypeAuthentication:
@@ -73,6 +97,8 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderTx(*typed)
 	case *presenters.ExternalInitiatorAuthentication:
 		return rt.renderExternalInitiatorAuthentication(*typed)
+	case *[]presenters.ExternalInitiator:
+		return rt.renderExternalInitiators(*typed)
 	case *web.ConfigPatchResponse:
 		return rt.renderConfigPatchResponse(typed)
 	case *presenters.ConfigWhitelist:
@@ -83,7 +109,7 @@ func (rt RendererTable) Render(v interface{}) error {
 }
 
 func (rt RendererTable) renderJobs(jobs []models.JobSpec) error {
-	table := rt.newTable([]string{"ID", "Created At", "Initiators", "Tasks"})
+	table := rt.newTable([]string{"ID", "Name", "Labels", "Created At", "Initiators", "Tasks"})
 	for _, v := range jobs {
 		table.Append(jobRowToStrings(v))
 	}
@@ -149,6 +175,8 @@ func jobRowToStrings(job models.JobSpec) []string {
 	p := presenters.JobSpec{JobSpec: job}
 	return []string{
 		p.ID.String(),
+		p.Name,
+		strings.Join(p.Labels, ", "),
 		p.FriendlyCreatedAt(),
 		p.FriendlyInitiators(),
 		p.FriendlyTasks(),
@@ -217,25 +245,32 @@ func (rt RendererTable) renderJobRun(run presenters.JobRun) error {
 }
 
 func (rt RendererTable) renderJobSingles(j presenters.JobSpec) error {
-	table := rt.newTable([]string{"ID", "Created At", "Start At", "End At", "Min Payment"})
+	table := rt.newTable([]string{"ID", "Name", "Labels", "Version", "Created At", "Start At", "End At", "Min Payment", "Paused At", "Proposed At"})
 	table.Append([]string{
 		j.ID.String(),
+		j.Name,
+		strings.Join(j.Labels, ", "),
+		strconv.FormatUint(uint64(j.Version), 10),
 		j.FriendlyCreatedAt(),
 		j.FriendlyStartAt(),
 		j.FriendlyEndAt(),
 		j.FriendlyMinPayment(),
+		utils.NullISO8601UTC(j.PausedAt),
+		utils.NullISO8601UTC(j.ProposedAt),
 	})
 	render("Job", table)
 	return nil
 }
 
 func (rt RendererTable) renderJobInitiators(j presenters.JobSpec) error {
-	table := rt.newTable([]string{"Type", "Schedule", "Run At", "Address"})
+	table := rt.newTable([]string{"Type", "Schedule", "Next Runs", "Run At", "Address"})
+	now := time.Now()
 	for _, i := range j.Initiators {
 		p := presenters.Initiator{Initiator: i}
 		table.Append([]string{
 			p.Type,
 			p.Schedule.String(),
+			p.FriendlyNextRuns(now),
 			p.FriendlyRunAt(),
 			p.FriendlyAddress(),
 		})
@@ -275,6 +310,245 @@ func (rt RendererTable) renderJobRuns(runs []presenters.JobRun) error {
 	return nil
 }
 
+func (rt RendererTable) renderRunSimulation(simulation models.RunSimulation) error {
+	table := rt.newTable([]string{"Type", "Status", "Input", "Output", "Error"})
+	table.SetAutoWrapText(false)
+	for _, tr := range simulation.TaskRuns {
+		table.Append([]string{
+			tr.Type.String(),
+			string(tr.Status),
+			tr.Input.String(),
+			tr.Output.String(),
+			tr.Error.ValueOrZero(),
+		})
+	}
+
+	render(fmt.Sprintf("Simulation %s", simulation.Status), table)
+	return nil
+}
+
+func (rt RendererTable) renderRunReplay(replay models.RunReplay) error {
+	table := rt.newTable([]string{"Type", "Recorded", "Original", "Replayed", "Changed"})
+	table.SetAutoWrapText(false)
+	for _, tr := range replay.TaskRuns {
+		changed := ""
+		if tr.Changed {
+			changed = "yes"
+			if len(tr.ChangedKeys) > 0 {
+				changed = strings.Join(tr.ChangedKeys, ", ")
+			}
+		}
+		table.Append([]string{
+			tr.Type.String(),
+			strconv.FormatBool(tr.Recorded),
+			taskReplayResultString(tr.Original),
+			taskReplayResultString(tr.Replayed),
+			changed,
+		})
+	}
+
+	render(fmt.Sprintf("Replay of run %s (%s spec): %s, originally %s", replay.JobRunID, replay.Spec, replay.Status, replay.OriginalStatus), table)
+	return nil
+}
+
+func taskReplayResultString(result models.TaskReplayResult) string {
+	if result.Error.Valid {
+		return fmt.Sprintf("%s: %s", result.Status, result.Error.String)
+	}
+	return fmt.Sprintf("%s: %s", result.Status, result.Output.String())
+}
+
+func (rt RendererTable) renderJobSpecVersions(versions []models.JobSpecVersion) error {
+	table := rt.newTable([]string{"Version", "Created At", "Spec"})
+	table.SetAutoWrapText(false)
+	for _, v := range versions {
+		table.Append([]string{
+			strconv.FormatUint(uint64(v.Version), 10),
+			utils.ISO8601UTC(v.CreatedAt),
+			v.Spec.String(),
+		})
+	}
+
+	render("Versions", table)
+	return nil
+}
+
+func (rt RendererTable) renderJobSpecDocument(doc models.JobSpecDocument) error {
+	_, err := io.WriteString(rt, strings.TrimSuffix(doc.Spec, "\n")+"\n")
+	return err
+}
+
+func (rt RendererTable) renderJobSpecDiff(diff models.JobSpecDiff) error {
+	table := rt.newTable([]string{"Path", "From", "To"})
+	table.SetAutoWrapText(false)
+	for _, change := range diff.Changes {
+		table.Append([]string{
+			change.Path,
+			jobSpecChangeValueString(change.From),
+			jobSpecChangeValueString(change.To),
+		})
+	}
+
+	render(fmt.Sprintf("Changes from version %d to %d", diff.From, diff.To), table)
+	return nil
+}
+
+func (rt RendererTable) renderJobApproval(approval models.JobApproval) error {
+	table := rt.newTable([]string{"ID", "Version", "Approved Version", "Proposed At", "Proposed By", "Reasons"})
+	table.Append([]string{
+		approval.JobSpecID.String(),
+		strconv.FormatUint(uint64(approval.Version), 10),
+		strconv.FormatUint(uint64(approval.ApprovedVersion), 10),
+		utils.NullISO8601UTC(approval.ProposedAt),
+		approval.ProposedBy,
+		strings.Join(approval.Reasons, "\n"),
+	})
+	render("Approval", table)
+
+	if err := rt.renderJobSpecDiff(approval.Diff); err != nil {
+		return err
+	}
+
+	table = rt.newTable([]string{"Version", "Action", "Actor", "Detail", "Created At"})
+	for _, event := range approval.Events {
+		table.Append([]string{
+			strconv.FormatUint(uint64(event.Version), 10),
+			string(event.Action),
+			event.Actor,
+			event.Detail,
+			utils.ISO8601UTC(event.CreatedAt),
+		})
+	}
+	render("Audit Trail", table)
+	return nil
+}
+
+func (rt RendererTable) renderJobApprovers(approvers []models.JobApprover) error {
+	table := rt.newTable([]string{"Email", "Created At"})
+	for _, approver := range approvers {
+		table.Append([]string{approver.Email, utils.ISO8601UTC(approver.CreatedAt)})
+	}
+	render("Job Approvers", table)
+	return nil
+}
+
+func (rt RendererTable) renderFluxMonitorStates(states []models.FluxMonitorState) error {
+	for _, state := range states {
+		oracle := ""
+		if state.OracleAddress != nil {
+			oracle = state.OracleAddress.Hex()
+		}
+		table := rt.newTable([]string{"Initiator", "Oracle", "Oracle Status", "Polled At", "Polled Answer", "Latest Answer", "Deviation", "Round", "Eligible", "Reason"})
+		table.Append([]string{
+			strconv.FormatUint(uint64(state.InitiatorID), 10),
+			oracle,
+			state.OracleStatus,
+			utils.NullISO8601UTC(state.PolledAt),
+			nullDecimalString(state.PolledAnswer),
+			nullDecimalString(state.LatestAnswer),
+			fluxMonitorDeviationString(state),
+			strconv.FormatUint(uint64(state.ReportableRoundID), 10),
+			strconv.FormatBool(state.Eligible),
+			state.Reason,
+		})
+		render("Flux Monitor", table)
+
+		submissionRunID := ""
+		if state.SubmissionRunID != nil {
+			submissionRunID = state.SubmissionRunID.String()
+		}
+		table = rt.newTable([]string{"Round", "Submitted At", "Run ID", "Tx Hash"})
+		table.Append([]string{
+			strconv.FormatUint(state.SubmittedRoundID, 10),
+			utils.NullISO8601UTC(state.SubmittedAt),
+			submissionRunID,
+			state.SubmissionTxHash.ValueOrZero(),
+		})
+		render("Last Submission", table)
+
+		table = rt.newTable([]string{"Feed", "Status", "Value", "Error"})
+		for _, feed := range state.Feeds {
+			table.Append([]string{feed.Feed, feed.Status, nullDecimalString(feed.Value), feed.Error})
+		}
+		render("Feeds", table)
+	}
+	return nil
+}
+
+func fluxMonitorDeviationString(state models.FluxMonitorState) string {
+	switch {
+	case state.Deviation.Valid:
+		return state.Deviation.Decimal.StringFixed(2) + "% (" + state.AbsoluteDeviation.Decimal.String() + ")"
+	case state.AbsoluteDeviation.Valid:
+		return state.AbsoluteDeviation.Decimal.String()
+	default:
+		return ""
+	}
+}
+
+func nullDecimalString(d decimal.NullDecimal) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Decimal.String()
+}
+
+func jobSpecChangeValueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+func (rt RendererTable) renderSpecTemplates(templates []models.SpecTemplate) error {
+	table := rt.newTable([]string{"ID", "Name", "Version", "Variables", "Updated At"})
+	for _, t := range templates {
+		table.Append([]string{
+			t.ID.String(),
+			t.Name,
+			strconv.FormatUint(uint64(t.Version), 10),
+			strconv.Itoa(len(t.Variables)),
+			utils.ISO8601UTC(t.UpdatedAt),
+		})
+	}
+
+	render("Spec Templates", table)
+	return nil
+}
+
+func (rt RendererTable) renderSpecTemplate(t presenters.SpecTemplate) error {
+	table := rt.newTable([]string{"ID", "Name", "Version", "Updated At", "Outdated Jobs"})
+	table.Append([]string{
+		t.ID.String(),
+		t.Name,
+		strconv.FormatUint(uint64(t.Version), 10),
+		utils.ISO8601UTC(t.UpdatedAt),
+		strconv.Itoa(len(t.OutdatedJobIDs)),
+	})
+	render("Spec Template", table)
+
+	variables := rt.newTable([]string{"Name", "Type", "Default", "Description"})
+	for _, v := range t.Variables {
+		variables.Append([]string{
+			v.Name,
+			string(v.Type),
+			jobSpecChangeValueString(v.Default),
+			v.Description,
+		})
+	}
+	render("Variables", variables)
+
+	if len(t.OutdatedJobIDs) > 0 {
+		fmt.Printf("%d jobs were created from an earlier version, run `chainlink templates rollout %s` to update them\n",
+			len(t.OutdatedJobIDs), t.ID)
+	}
+	return nil
+}
+
 func (rt RendererTable) renderAccountBalances(balances []presenters.AccountBalance) error {
 	table := rt.newTable([]string{"Address", "ETH", "LINK"})
 	for _, ab := range balances {
@@ -317,6 +591,29 @@ func (rt RendererTable) renderExternalInitiatorAuthentication(eia presenters.Ext
 	return nil
 }
 
+func (rt RendererTable) renderExternalInitiators(eis []presenters.ExternalInitiator) error {
+	table := rt.newTable([]string{"Name", "URL", "Status", "Checked At", "Check Error", "Pending Notifications"})
+	for _, ei := range eis {
+		url, checkedAt := "", ""
+		if ei.URL != nil {
+			url = ei.URL.String()
+		}
+		if ei.CheckedAt.Valid {
+			checkedAt = utils.ISO8601UTC(ei.CheckedAt.Time)
+		}
+		table.Append([]string{
+			ei.Name,
+			url,
+			ei.Status,
+			checkedAt,
+			ei.CheckError,
+			strconv.Itoa(ei.PendingNotifications),
+		})
+	}
+	render("External Initiators", table)
+	return nil
+}
+
 func (rt RendererTable) newTable(headers []string) *tablewriter.Table {
 	table := tablewriter.NewWriter(rt)
 	table.SetHeader(headers)
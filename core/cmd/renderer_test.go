@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"testing"
 
+	"chainlink/core/adapters"
 	"chainlink/core/cmd"
 	"chainlink/core/internal/cltest"
 	"chainlink/core/store/models"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	null "gopkg.in/guregu/null.v3"
 )
 
 func TestRendererJSON_RenderJobs(t *testing.T) {
@@ -331,3 +333,43 @@ func TestRendererTable_RenderUnknown(t *testing.T) {
 	anon := struct{ Name string }{"Romeo"}
 	assert.Error(t, r.Render(&anon))
 }
+
+func TestRendererTable_RenderRunReplay(t *testing.T) {
+	t.Parallel()
+	r := cmd.RendererTable{Writer: ioutil.Discard}
+	replay := models.RunReplay{
+		JobRunID: models.NewID(),
+		Spec:     "original",
+		Status:   models.RunStatusErrored,
+		TaskRuns: []models.TaskReplay{{
+			Type:        adapters.TaskTypeHTTPGet,
+			Recorded:    true,
+			Original:    models.TaskReplayResult{Status: models.RunStatusCompleted, Output: cltest.JSONFromString(t, `{"result":"100"}`)},
+			Replayed:    models.TaskReplayResult{Status: models.RunStatusErrored, Error: null.StringFrom("no recorded response")},
+			Changed:     true,
+			ChangedKeys: []string{"result"},
+		}},
+	}
+	assert.NoError(t, r.Render(&replay))
+}
+
+func TestRendererTable_RenderJobSpecVersionsAndDiff(t *testing.T) {
+	t.Parallel()
+	r := cmd.RendererTable{Writer: ioutil.Discard}
+	versions := []models.JobSpecVersion{{
+		JobSpecID: models.NewID(),
+		Version:   1,
+		Spec:      cltest.JSONFromString(t, `{"tasks":[{"type":"noop"}]}`),
+	}}
+	assert.NoError(t, r.Render(&versions))
+
+	diff := models.JobSpecDiff{
+		From: 1,
+		To:   2,
+		Changes: []models.JobSpecChange{
+			{Path: "tasks.0.type", From: "noop", To: "httpget"},
+			{Path: "tasks.0.params", From: nil, To: map[string]interface{}{"get": "https://example.com"}},
+		},
+	}
+	assert.NoError(t, r.Render(&diff))
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/mrwonko/cron"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -611,13 +612,23 @@ func (*MockCron) Start() {}
 // Stop stops the mockcron
 func (*MockCron) Stop() {}
 
-// AddFunc appends a schedule to mockcron entries
-func (mc *MockCron) AddFunc(schd string, fn func()) error {
+// Schedule appends a schedule to mockcron entries
+func (mc *MockCron) Schedule(schd cron.Schedule, job cron.Job) {
 	mc.Entries = append(mc.Entries, MockCronEntry{
 		Schedule: schd,
-		Function: fn,
+		Function: job.Run,
 	})
-	return nil
+}
+
+// Remove removes the mockcron entries of the schedule
+func (mc *MockCron) Remove(schd cron.Schedule) {
+	remaining := []MockCronEntry{}
+	for _, entry := range mc.Entries {
+		if entry.Schedule != schd {
+			remaining = append(remaining, entry)
+		}
+	}
+	mc.Entries = remaining
 }
 
 // RunEntries run every function for each mockcron entry
@@ -629,7 +640,7 @@ func (mc *MockCron) RunEntries() {
 
 // MockCronEntry a cron schedule and function
 type MockCronEntry struct {
-	Schedule string
+	Schedule cron.Schedule
 	Function func()
 }
 
@@ -750,15 +750,17 @@ func TestIntegration_ExternalInitiator(t *testing.T) {
 	defer cleanup()
 	require.NoError(t, app.Start())
 
-	exInitr := struct {
+	type notification struct {
 		Header http.Header
-		Body   web.JobSpecNotice
-	}{}
+		Body   models.JobSpecNotice
+	}
+	notifications := make(chan notification, 1)
 	eiMockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "",
 		func(header http.Header, body string) {
-			exInitr.Header = header
+			exInitr := notification{Header: header}
 			err := json.Unmarshal([]byte(body), &exInitr.Body)
 			require.NoError(t, err)
+			notifications <- exInitr
 		},
 	)
 	defer assertCalled()
@@ -784,6 +786,12 @@ func TestIntegration_ExternalInitiator(t *testing.T) {
 	require.Equal(t, eip.OutgoingSecret, ei.OutgoingSecret)
 
 	jobSpec := cltest.FixtureCreateJobViaWeb(t, app, "./testdata/external_initiator_job.json")
+	var exInitr notification
+	select {
+	case exInitr = <-notifications:
+	case <-time.After(5 * time.Second):
+		t.Fatal("external initiator was not notified")
+	}
 	assert.Equal(t,
 		eip.OutgoingToken,
 		exInitr.Header.Get(web.ExternalInitiatorAccessKeyHeader),
@@ -792,9 +800,10 @@ func TestIntegration_ExternalInitiator(t *testing.T) {
 		eip.OutgoingSecret,
 		exInitr.Header.Get(web.ExternalInitiatorSecretHeader),
 	)
-	expected := web.JobSpecNotice{
+	expected := models.JobSpecNotice{
 		JobID:  jobSpec.ID,
 		Type:   models.InitiatorExternal,
+		Action: models.ExternalInitiatorNotificationCreate,
 		Params: cltest.JSONFromString(t, `{"foo":"bar"}`),
 	}
 	assert.Equal(t, expected, exInitr.Body)
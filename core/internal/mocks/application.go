@@ -10,6 +10,8 @@ import (
 
 	packr "github.com/gobuffalo/packr"
 
+	services "chainlink/core/services"
+
 	store "chainlink/core/store"
 
 	synchronization "chainlink/core/services/synchronization"
@@ -34,6 +36,20 @@ func (_m *Application) AddJob(job models.JobSpec) error {
 	return r0
 }
 
+// AddJobs provides a mock function with given fields: jobs
+func (_m *Application) AddJobs(jobs []models.JobSpec) error {
+	ret := _m.Called(jobs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.JobSpec) error); ok {
+		r0 = rf(jobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddServiceAgreement provides a mock function with given fields: _a0
 func (_m *Application) AddServiceAgreement(_a0 *models.ServiceAgreement) error {
 	ret := _m.Called(_a0)
@@ -48,6 +64,20 @@ func (_m *Application) AddServiceAgreement(_a0 *models.ServiceAgreement) error {
 	return r0
 }
 
+// ApproveJob provides a mock function with given fields: _a0, _a1
+func (_m *Application) ApproveJob(_a0 *models.ID, _a1 models.JobApprovalRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID, models.JobApprovalRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ArchiveJob provides a mock function with given fields: _a0
 func (_m *Application) ArchiveJob(_a0 *models.ID) error {
 	ret := _m.Called(_a0)
@@ -131,6 +161,22 @@ func (_m *Application) CreateErrored(jobSpecID *models.ID, initiator models.Init
 	return r0, r1
 }
 
+// GetRunBroadcaster provides a mock function with given fields:
+func (_m *Application) GetRunBroadcaster() services.RunBroadcaster {
+	ret := _m.Called()
+
+	var r0 services.RunBroadcaster
+	if rf, ok := ret.Get(0).(func() services.RunBroadcaster); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.RunBroadcaster)
+		}
+	}
+
+	return r0
+}
+
 // GetStatsPusher provides a mock function with given fields:
 func (_m *Application) GetStatsPusher() synchronization.StatsPusher {
 	ret := _m.Called()
@@ -177,6 +223,34 @@ func (_m *Application) NewBox() packr.Box {
 	return r0
 }
 
+// PauseJob provides a mock function with given fields: _a0
+func (_m *Application) PauseJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RejectJob provides a mock function with given fields: _a0, _a1
+func (_m *Application) RejectJob(_a0 *models.ID, _a1 models.JobApprovalRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID, models.JobApprovalRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumeAllConfirming provides a mock function with given fields: currentBlockHeight
 func (_m *Application) ResumeAllConfirming(currentBlockHeight *big.Int) error {
 	ret := _m.Called(currentBlockHeight)
@@ -219,6 +293,34 @@ func (_m *Application) ResumeAllInProgress() error {
 	return r0
 }
 
+// ResumeAllQueued provides a mock function with given fields: jobSpecID
+func (_m *Application) ResumeAllQueued(jobSpecID *models.ID) error {
+	ret := _m.Called(jobSpecID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(jobSpecID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeJob provides a mock function with given fields: _a0
+func (_m *Application) ResumeJob(_a0 *models.ID) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePending provides a mock function with given fields: runID, input
 func (_m *Application) ResumePending(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
@@ -233,6 +335,11 @@ func (_m *Application) ResumePending(runID *models.ID, input models.BridgeRunRes
 	return r0
 }
 
+// RunFinished provides a mock function with given fields: run
+func (_m *Application) RunFinished(run *models.JobRun) {
+	_m.Called(run)
+}
+
 // Start provides a mock function with given fields:
 func (_m *Application) Start() error {
 	ret := _m.Called()
@@ -261,6 +368,34 @@ func (_m *Application) Stop() error {
 	return r0
 }
 
+// UpdateJob provides a mock function with given fields: job
+func (_m *Application) UpdateJob(job models.JobSpec) error {
+	ret := _m.Called(job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.JobSpec) error); ok {
+		r0 = rf(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateJobs provides a mock function with given fields: jobs
+func (_m *Application) UpdateJobs(jobs []models.JobSpec) error {
+	ret := _m.Called(jobs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.JobSpec) error); ok {
+		r0 = rf(jobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
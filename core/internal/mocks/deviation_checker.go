@@ -2,13 +2,22 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	models "chainlink/core/store/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // DeviationChecker is an autogenerated mock type for the DeviationChecker type
 type DeviationChecker struct {
 	mock.Mock
 }
 
+// OnNewHead provides a mock function with given fields: _a0
+func (_m *DeviationChecker) OnNewHead(_a0 *models.Head) {
+	_m.Called(_a0)
+}
+
 // Start provides a mock function with given fields:
 func (_m *DeviationChecker) Start() {
 	_m.Called()
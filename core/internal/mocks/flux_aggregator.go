@@ -102,6 +102,27 @@ func (_m *FluxAggregator) GetMethodID(method string) ([]byte, error) {
 	return r0, r1
 }
 
+// OracleStatus provides a mock function with given fields: oracle
+func (_m *FluxAggregator) OracleStatus(oracle common.Address) (contracts.FluxAggregatorOracleStatus, error) {
+	ret := _m.Called(oracle)
+
+	var r0 contracts.FluxAggregatorOracleStatus
+	if rf, ok := ret.Get(0).(func(common.Address) contracts.FluxAggregatorOracleStatus); ok {
+		r0 = rf(oracle)
+	} else {
+		r0 = ret.Get(0).(contracts.FluxAggregatorOracleStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
+		r1 = rf(oracle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RoundState provides a mock function with given fields: oracle
 func (_m *FluxAggregator) RoundState(oracle common.Address) (contracts.FluxAggregatorRoundState, error) {
 	ret := _m.Called(oracle)
@@ -26,3 +26,8 @@ func (_m *RunExecutor) Execute(_a0 *models.ID) error {
 
 	return r0
 }
+
+// OnRunFinished provides a mock function with given fields: _a0
+func (_m *RunExecutor) OnRunFinished(_a0 func(*models.JobRun)) {
+	_m.Called(_a0)
+}
@@ -125,6 +125,20 @@ func (_m *RunManager) ResumeAllInProgress() error {
 	return r0
 }
 
+// ResumeAllQueued provides a mock function with given fields: jobSpecID
+func (_m *RunManager) ResumeAllQueued(jobSpecID *models.ID) error {
+	ret := _m.Called(jobSpecID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ID) error); ok {
+		r0 = rf(jobSpecID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResumePending provides a mock function with given fields: runID, input
 func (_m *RunManager) ResumePending(runID *models.ID, input models.BridgeRunResult) error {
 	ret := _m.Called(runID, input)
@@ -138,3 +152,8 @@ func (_m *RunManager) ResumePending(runID *models.ID, input models.BridgeRunResu
 
 	return r0
 }
+
+// RunFinished provides a mock function with given fields: run
+func (_m *RunManager) RunFinished(run *models.JobRun) {
+	_m.Called(run)
+}
@@ -203,6 +203,29 @@ func (_m *TxManager) CreateTxWithEth(from common.Address, to common.Address, val
 	return r0, r1
 }
 
+// CreateTxFromWithGas provides a mock function with given fields: surrogateID, from, to, data, gasPriceWei, gasLimit
+func (_m *TxManager) CreateTxFromWithGas(surrogateID null.String, from common.Address, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error) {
+	ret := _m.Called(surrogateID, from, to, data, gasPriceWei, gasLimit)
+
+	var r0 *models.Tx
+	if rf, ok := ret.Get(0).(func(null.String, common.Address, common.Address, []byte, *big.Int, uint64) *models.Tx); ok {
+		r0 = rf(surrogateID, from, to, data, gasPriceWei, gasLimit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(null.String, common.Address, common.Address, []byte, *big.Int, uint64) error); ok {
+		r1 = rf(surrogateID, from, to, data, gasPriceWei, gasLimit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTxWithGas provides a mock function with given fields: surrogateID, to, data, gasPriceWei, gasLimit
 func (_m *TxManager) CreateTxWithGas(surrogateID null.String, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error) {
 	ret := _m.Called(surrogateID, to, data, gasPriceWei, gasLimit)
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+	"chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// BalanceWatcher is a HeadTrackable checking the balances watched by balance
+// initiators on each new head, and creating a run of the job when one
+// crosses its threshold. Balances are checked in the background, so that
+// slow balance requests do not hold up other head trackables.
+type BalanceWatcher struct {
+	worker  *balanceWatcherWorker
+	checker SleeperTask
+}
+
+// NewBalanceWatcher returns a BalanceWatcher which creates runs through the
+// RunManager.
+func NewBalanceWatcher(store *store.Store, runManager RunManager) *BalanceWatcher {
+	worker := &balanceWatcherWorker{store: store, runManager: runManager}
+	return &BalanceWatcher{
+		worker:  worker,
+		checker: NewSleeperTask(worker),
+	}
+}
+
+// Connect checks the watched balances at the head connected at.
+func (bw *BalanceWatcher) Connect(head *models.Head) error {
+	if head != nil {
+		bw.OnNewHead(head)
+	}
+	return nil
+}
+
+// Disconnect is a no-op, balances being checked again on the next head.
+func (bw *BalanceWatcher) Disconnect() {}
+
+// OnNewHead checks the watched balances at the new head.
+func (bw *BalanceWatcher) OnNewHead(head *models.Head) {
+	bw.worker.setHead(*head)
+	bw.checker.WakeUp()
+}
+
+// Stop waits for any balances being checked.
+func (bw *BalanceWatcher) Stop() error {
+	return bw.checker.Stop()
+}
+
+type balanceWatcherWorker struct {
+	store      *store.Store
+	runManager RunManager
+	head       models.Head
+	headMutex  sync.RWMutex
+}
+
+func (bw *balanceWatcherWorker) setHead(head models.Head) {
+	bw.headMutex.Lock()
+	defer bw.headMutex.Unlock()
+	bw.head = head
+}
+
+func (bw *balanceWatcherWorker) getHead() models.Head {
+	bw.headMutex.RLock()
+	defer bw.headMutex.RUnlock()
+	return bw.head
+}
+
+func (bw *balanceWatcherWorker) Work() {
+	head := bw.getHead()
+	var initrs []models.Initiator
+	err := bw.store.Jobs(func(j *models.JobSpec) bool {
+		if j.Proposed() || j.Paused() {
+			return true
+		}
+		initrs = append(initrs, j.InitiatorsFor(models.InitiatorBalance)...)
+		return true
+	}, models.InitiatorBalance)
+	if err != nil {
+		logger.Errorw("Error finding balance watching jobs", "head", head.Number, "error", err)
+		return
+	}
+
+	for _, initr := range initrs {
+		for _, address := range initr.Addresses {
+			if err := bw.check(initr, address, head); err != nil {
+				logger.Errorw("Error checking watched balance", "job", initr.JobSpecID, "address", address.Hex(), "head", head.Number, "error", err)
+			}
+		}
+	}
+}
+
+// check compares the address's balance to the one last seen, creating a run
+// if it crossed the initiator's threshold. The first balance seen is only
+// recorded. A crossing is recorded only once its run is created, or refused
+// as the job cannot run, so that one whose run could not be saved is tried
+// again on the next head.
+func (bw *balanceWatcherWorker) check(initr models.Initiator, address common.Address, head models.Head) error {
+	balance, err := bw.balance(initr, address)
+	if err != nil {
+		return err
+	}
+
+	watch, err := bw.store.FindBalanceWatch(initr.ID, address)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		watch = models.BalanceWatch{
+			InitiatorID: initr.ID,
+			Address:     address,
+			Balance:     utils.NewBig(balance),
+			Below:       models.BalanceBelowThreshold(initr, balance, false),
+		}
+		return bw.store.SaveBalanceWatch(&watch)
+	} else if err != nil {
+		return err
+	}
+
+	oldBalance := watch.Balance
+	below := models.BalanceBelowThreshold(initr, balance, watch.Below)
+	crossed := below != watch.Below
+	watch.Balance = utils.NewBig(balance)
+	watch.Below = below
+	if !crossed {
+		return bw.store.SaveBalanceWatch(&watch)
+	}
+
+	kv := models.KV{
+		"address":    address.Hex(),
+		"oldBalance": oldBalance.String(),
+		"newBalance": balance.String(),
+		"below":      below,
+	}
+	if initr.Token != nil {
+		kv["token"] = initr.Token.Hex()
+	}
+	requestParams, err := models.JSON{}.MultiAdd(kv)
+	if err != nil {
+		return err
+	}
+
+	logger.Infow(fmt.Sprintf("Balance of %s crossed threshold %s", address.Hex(), initr.BalanceThreshold.String()),
+		"job", initr.JobSpecID, "balance", balance.String(), "below", below)
+	_, err = bw.runManager.Create(initr.JobSpecID, &initr, head.ToInt(), models.NewRunRequest(requestParams))
+	if err != nil && ExpectedRecurringScheduleJobError(err) {
+		logger.Infow(err.Error(), "job", initr.JobSpecID, "address", address.Hex())
+	} else if err != nil {
+		return err
+	}
+	return bw.store.SaveBalanceWatch(&watch)
+}
+
+// balance returns the address's balance of the initiator's token, or of ETH
+// in wei if it has none.
+func (bw *balanceWatcherWorker) balance(initr models.Initiator, address common.Address) (*big.Int, error) {
+	if initr.Token != nil {
+		return bw.store.TxManager.GetERC20Balance(address, *initr.Token)
+	}
+	balance, err := bw.store.TxManager.GetEthBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	return balance.ToInt(), nil
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"math/big"
+	"testing"
+
+	"chainlink/core/assets"
+	"chainlink/core/internal/cltest"
+	"chainlink/core/internal/mocks"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+	"chainlink/core/utils"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceWatcher_OnNewHead(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	store.TxManager = txManager
+	runManager := new(mocks.RunManager)
+
+	address := cltest.NewAddress()
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type: models.InitiatorBalance,
+		InitiatorParams: models.InitiatorParams{
+			Addresses:        models.AddressCollection{address},
+			BalanceThreshold: utils.NewBig(big.NewInt(50)),
+			Hysteresis:       utils.NewBig(big.NewInt(20)),
+		},
+	}}
+	require.NoError(t, store.CreateJob(&job))
+	initr := job.Initiators[0]
+
+	watcher := services.NewBalanceWatcher(store, runManager)
+	defer watcher.Stop()
+
+	seeBalance := func(head int, balance int64) {
+		txManager.On("GetEthBalance", address).Return(assets.NewEth(balance), nil).Once()
+		watcher.OnNewHead(cltest.Head(head))
+		g.Eventually(func() string {
+			watch, err := store.FindBalanceWatch(initr.ID, address)
+			if err != nil {
+				return ""
+			}
+			return watch.Balance.String()
+		}).Should(gomega.Equal(big.NewInt(balance).String()))
+	}
+	runOn := func(head int, oldBalance, newBalance string, below bool) {
+		runManager.On("Create", job.ID, mock.Anything, big.NewInt(int64(head)), mock.MatchedBy(func(rr *models.RunRequest) bool {
+			return rr.RequestParams.Get("address").String() == address.Hex() &&
+				rr.RequestParams.Get("oldBalance").String() == oldBalance &&
+				rr.RequestParams.Get("newBalance").String() == newBalance &&
+				rr.RequestParams.Get("below").Bool() == below
+		})).Return(nil, nil).Once()
+	}
+
+	// The first balance seen is only recorded
+	seeBalance(1, 100)
+
+	runOn(2, "100", "40", true)
+	seeBalance(2, 40)
+
+	// Within the hysteresis the balance is still below
+	seeBalance(3, 60)
+
+	runOn(4, "60", "75", false)
+	seeBalance(4, 75)
+
+	seeBalance(5, 60)
+
+	// A crossing whose run could not be created is tried again on the next head
+	failed := make(chan struct{})
+	runManager.On("Create", job.ID, mock.Anything, big.NewInt(6), mock.Anything).
+		Return(nil, errors.New("unable to save run")).
+		Once().
+		Run(func(mock.Arguments) { close(failed) })
+	txManager.On("GetEthBalance", address).Return(assets.NewEth(30), nil).Once()
+	watcher.OnNewHead(cltest.Head(6))
+	cltest.CallbackOrTimeout(t, "Create", func() { <-failed })
+	watch, err := store.FindBalanceWatch(initr.ID, address)
+	require.NoError(t, err)
+	assert.Equal(t, "60", watch.Balance.String())
+	assert.False(t, watch.Below)
+
+	runOn(7, "60", "30", true)
+	seeBalance(7, 30)
+
+	runManager.AssertExpectations(t)
+	txManager.AssertExpectations(t)
+	assert.Len(t, runManager.Calls, 4)
+}
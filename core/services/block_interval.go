@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// MaxBlockIntervalCatchUp is the most heads missed while disconnected that
+// the BlockIntervalTracker catches up on, as many as the HeadTracker keeps.
+const MaxBlockIntervalCatchUp = 100
+
+// BlockIntervalTracker is a HeadTrackable creating runs of jobs with
+// blockinterval initiators, at every head whose number is the initiator's
+// offset past a multiple of its interval.
+type BlockIntervalTracker struct {
+	store      *store.Store
+	runManager RunManager
+	lastNumber *int64
+	mutex      sync.Mutex
+}
+
+// NewBlockIntervalTracker returns a BlockIntervalTracker which creates runs
+// through the RunManager.
+func NewBlockIntervalTracker(store *store.Store, runManager RunManager) *BlockIntervalTracker {
+	return &BlockIntervalTracker{store: store, runManager: runManager}
+}
+
+// Connect resumes after the last head handled. On the first connection that
+// is the last head the node saw, so heads missed while the node was down are
+// caught up on with the next new head.
+func (bt *BlockIntervalTracker) Connect(head *models.Head) error {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	if bt.lastNumber == nil && head != nil {
+		number := head.Number
+		bt.lastNumber = &number
+	}
+	return nil
+}
+
+// Disconnect keeps the last head handled to resume after on reconnection.
+func (bt *BlockIntervalTracker) Disconnect() {}
+
+// OnNewHead creates the runs triggered at the new head and at any heads
+// missed since the last head handled. Heads at or behind the last head
+// handled, such as those of a reorg, trigger nothing again.
+func (bt *BlockIntervalTracker) OnNewHead(head *models.Head) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+
+	from := head.Number
+	if bt.lastNumber != nil {
+		if head.Number <= *bt.lastNumber {
+			return
+		}
+		from = *bt.lastNumber + 1
+	}
+	if head.Number-from >= MaxBlockIntervalCatchUp {
+		logger.Warnw(fmt.Sprintf("Skipping blockinterval runs of %d missed heads", head.Number-from-MaxBlockIntervalCatchUp+1),
+			"from", from, "head", head.Number)
+		from = head.Number - MaxBlockIntervalCatchUp + 1
+	}
+
+	initrs, err := bt.initiators()
+	if err != nil {
+		logger.Errorw("Error finding blockinterval jobs", "head", head.Number, "error", err)
+		return
+	}
+
+	for number := from; number <= head.Number; number++ {
+		if err := bt.trigger(initrs, number, head); err != nil {
+			logger.Errorw("Error triggering blockinterval jobs", "head", number, "error", err)
+			return
+		}
+		handled := number
+		bt.lastNumber = &handled
+	}
+}
+
+// initiators returns the blockinterval initiators of jobs that can be run.
+func (bt *BlockIntervalTracker) initiators() ([]models.Initiator, error) {
+	var initrs []models.Initiator
+	err := bt.store.Jobs(func(j *models.JobSpec) bool {
+		if j.Proposed() || j.Paused() {
+			return true
+		}
+		initrs = append(initrs, j.InitiatorsFor(models.InitiatorBlockInterval)...)
+		return true
+	}, models.InitiatorBlockInterval)
+	return initrs, err
+}
+
+func (bt *BlockIntervalTracker) trigger(initrs []models.Initiator, number int64, head *models.Head) error {
+	var hash *common.Hash
+	for i := range initrs {
+		initr := initrs[i]
+		if !triggeredAtHead(initr, number) {
+			continue
+		}
+
+		creationHeight := big.NewInt(number)
+		exists, err := bt.store.JobRunExistsAt(initr, creationHeight)
+		if err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+
+		if hash == nil {
+			if hash, err = bt.hashAt(number, head); err != nil {
+				return err
+			}
+		}
+
+		requestParams := models.JSON{}
+		requestParams, err = requestParams.MultiAdd(models.KV{
+			"blockNumber": number,
+			"blockHash":   hash.Hex(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = bt.runManager.Create(initr.JobSpecID, &initr, creationHeight, models.NewRunRequest(requestParams))
+		if err != nil && ExpectedRecurringScheduleJobError(err) {
+			logger.Infow(err.Error(), "job", initr.JobSpecID, "head", number)
+		} else if err != nil {
+			logger.Errorw(err.Error(), "job", initr.JobSpecID, "head", number)
+		}
+	}
+	return nil
+}
+
+// hashAt returns the hash of the head with the given number, looking up the
+// block of a head that was missed.
+func (bt *BlockIntervalTracker) hashAt(number int64, head *models.Head) (*common.Hash, error) {
+	if number == head.Number {
+		return &head.Hash, nil
+	}
+	block, err := bt.store.TxManager.GetBlockByNumber(hexutil.EncodeUint64(uint64(number)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get block %d", number)
+	}
+	hash := block.Hash()
+	return &hash, nil
+}
+
+func triggeredAtHead(initr models.Initiator, number int64) bool {
+	return initr.Every > 0 && number%int64(initr.Every) == int64(initr.Offset)
+}
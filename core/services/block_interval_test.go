@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"chainlink/core/eth"
+	"chainlink/core/internal/cltest"
+	"chainlink/core/internal/mocks"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+	"chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+)
+
+func TestBlockIntervalTracker_OnNewHead(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	store.TxManager = txManager
+	runManager := new(mocks.RunManager)
+
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type:            models.InitiatorBlockInterval,
+		InitiatorParams: models.InitiatorParams{Every: 3, Offset: 1},
+	}}
+	require.NoError(t, store.CreateJob(&job))
+
+	runAt := func(number int64, hash string) {
+		runManager.On("Create", job.ID, mock.Anything, big.NewInt(number), mock.MatchedBy(func(rr *models.RunRequest) bool {
+			return rr.RequestParams.Get("blockNumber").Int() == number &&
+				rr.RequestParams.Get("blockHash").String() == hash
+		})).Return(nil, nil).Once()
+	}
+
+	tracker := services.NewBlockIntervalTracker(store, runManager)
+	require.NoError(t, tracker.Connect(cltest.Head(9)))
+
+	// Head 10 was missed, so its hash is looked up
+	missedHash := cltest.NewHash()
+	txManager.On("GetBlockByNumber", "0xa").Return(eth.BlockHeader{GethHash: missedHash}, nil).Once()
+	runAt(10, missedHash.Hex())
+	tracker.OnNewHead(cltest.Head(12))
+	runManager.AssertExpectations(t)
+
+	// Heads already handled trigger nothing
+	tracker.OnNewHead(cltest.Head(10))
+	tracker.OnNewHead(cltest.Head(12))
+
+	tracker.Disconnect()
+	require.NoError(t, tracker.Connect(cltest.Head(12)))
+	head := cltest.Head(13)
+	runAt(13, head.Hash.Hex())
+	tracker.OnNewHead(head)
+	runManager.AssertExpectations(t)
+
+	// Runs already created at a head are not created again
+	run := cltest.NewJobRun(job)
+	run.CreationHeight = utils.NewBig(big.NewInt(16))
+	require.NoError(t, store.CreateJobRun(&run))
+	tracker.OnNewHead(cltest.Head(16))
+
+	runManager.AssertExpectations(t)
+	txManager.AssertExpectations(t)
+	runManager.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestBlockIntervalTracker_OnNewHead_SkipsProposedJobs(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runManager := new(mocks.RunManager)
+
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type:            models.InitiatorBlockInterval,
+		InitiatorParams: models.InitiatorParams{Every: 1},
+	}}
+	job.ProposedAt = null.TimeFrom(time.Now())
+	require.NoError(t, store.CreateJob(&job))
+
+	tracker := services.NewBlockIntervalTracker(store, runManager)
+	require.NoError(t, tracker.Connect(nil))
+	tracker.OnNewHead(cltest.Head(1))
+
+	assert.Empty(t, runManager.Calls)
+}
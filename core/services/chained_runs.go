@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"chainlink/core/logger"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+)
+
+// ChainedRunStatuses are the statuses of an upstream run that a jobrun
+// initiator can be triggered by, and that it is triggered by if it lists none.
+var ChainedRunStatuses = models.RunStatusCollection{models.RunStatusCompleted, models.RunStatusErrored}
+
+// TriggerChainedRuns creates a run of each job with a jobrun initiator
+// watching the finished run's job for its status. The downstream runs are
+// requested with the upstream run's result data, and the upstream run itself
+// under "upstream".
+func TriggerChainedRuns(runManager RunManager, orm *orm.ORM, run *models.JobRun) {
+	if !run.Status.Completed() && !run.Status.Errored() {
+		return
+	}
+
+	initrs, err := orm.JobRunInitiatorsFor(run.JobSpecID)
+	if err != nil {
+		logger.Errorw("Error finding jobs chained to run", run.ForLogger("error", err)...)
+		return
+	}
+	if len(initrs) == 0 {
+		return
+	}
+
+	requestParams, err := chainedRunRequestParams(run)
+	if err != nil {
+		logger.Errorw("Error building request of chained runs", run.ForLogger("error", err)...)
+		return
+	}
+
+	for i := range initrs {
+		initr := initrs[i]
+		if !triggeredByStatus(initr, run.Status) {
+			continue
+		}
+
+		logger.Debugw(fmt.Sprintf("Triggering run of chained job %s", initr.JobSpecID), run.ForLogger()...)
+		_, err := runManager.Create(initr.JobSpecID, &initr, nil, models.NewRunRequest(requestParams))
+		if err != nil && ExpectedRecurringScheduleJobError(err) {
+			logger.Infow(err.Error(), run.ForLogger("chained_job", initr.JobSpecID)...)
+		} else if err != nil {
+			logger.Errorw(err.Error(), run.ForLogger("chained_job", initr.JobSpecID)...)
+		}
+	}
+}
+
+func triggeredByStatus(initr models.Initiator, status models.RunStatus) bool {
+	statuses := initr.Statuses
+	if len(statuses) == 0 {
+		statuses = ChainedRunStatuses
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func chainedRunRequestParams(run *models.JobRun) (models.JSON, error) {
+	upstream := map[string]interface{}{
+		"upstream": map[string]interface{}{
+			"jobId":  run.JobSpecID.String(),
+			"runId":  run.ID.String(),
+			"status": run.Status,
+			"error":  run.Result.ErrorMessage.ValueOrZero(),
+		},
+	}
+	b, err := json.Marshal(upstream)
+	if err != nil {
+		return models.JSON{}, err
+	}
+	upstreamJSON, err := models.ParseJSON(b)
+	if err != nil {
+		return models.JSON{}, err
+	}
+	return models.Merge(run.Result.Data, upstreamJSON)
+}
@@ -10,6 +10,7 @@ import (
 	"chainlink/core/logger"
 	"chainlink/core/services"
 	"chainlink/core/services/fluxmonitor"
+	"chainlink/core/services/messagequeue"
 	"chainlink/core/services/synchronization"
 	"chainlink/core/store"
 	strpkg "chainlink/core/store"
@@ -17,6 +18,7 @@ import (
 	"chainlink/core/store/orm"
 
 	"github.com/gobuffalo/packr"
+	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 )
 
@@ -41,9 +43,17 @@ type Application interface {
 	Stop() error
 	GetStore() *store.Store
 	GetStatsPusher() synchronization.StatsPusher
+	GetRunBroadcaster() services.RunBroadcaster
 	WakeSessionReaper()
 	AddJob(job models.JobSpec) error
+	AddJobs(jobs []models.JobSpec) error
+	UpdateJob(job models.JobSpec) error
+	UpdateJobs(jobs []models.JobSpec) error
 	ArchiveJob(*models.ID) error
+	PauseJob(*models.ID) error
+	ResumeJob(*models.ID) error
+	ApproveJob(*models.ID, models.JobApprovalRequest) error
+	RejectJob(*models.ID, models.JobApprovalRequest) error
 	AddServiceAgreement(*models.ServiceAgreement) error
 	NewBox() packr.Box
 	services.RunManager
@@ -57,9 +67,13 @@ type ChainlinkApplication struct {
 	HeadTracker *services.HeadTracker
 	StatsPusher synchronization.StatsPusher
 	services.RunManager
+	RunBroadcaster           services.RunBroadcaster
 	RunQueue                 services.RunQueue
 	JobSubscriber            services.JobSubscriber
 	FluxMonitor              fluxmonitor.Service
+	BalanceWatcher           *services.BalanceWatcher
+	MessageQueue             messagequeue.Service
+	EINotifier               *services.ExternalInitiatorNotifier
 	Scheduler                *services.Scheduler
 	Store                    *store.Store
 	SessionReaper            services.SleeperTask
@@ -81,6 +95,7 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 	runExecutor := services.NewRunExecutor(store, statsPusher)
 	runQueue := services.NewRunQueue(runExecutor)
 	runManager := services.NewRunManager(runQueue, config, store.ORM, statsPusher, store.TxManager, store.Clock)
+	runExecutor.OnRunFinished(runManager.RunFinished)
 	jobSubscriber := services.NewJobSubscriber(store, runManager)
 	fluxMonitor := fluxmonitor.New(store, runManager)
 
@@ -89,8 +104,12 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 	app := &ChainlinkApplication{
 		JobSubscriber:            jobSubscriber,
 		FluxMonitor:              fluxMonitor,
+		BalanceWatcher:           services.NewBalanceWatcher(store, runManager),
+		MessageQueue:             messagequeue.New(store, runManager),
+		EINotifier:               services.NewExternalInitiatorNotifier(store),
 		StatsPusher:              statsPusher,
 		RunManager:               runManager,
+		RunBroadcaster:           services.NewRunBroadcaster(store.ORM),
 		RunQueue:                 runQueue,
 		Scheduler:                services.NewScheduler(store, runManager),
 		Store:                    store,
@@ -103,6 +122,9 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 		store.TxManager,
 		jobSubscriber,
 		pendingConnectionResumer,
+		services.NewBlockIntervalTracker(store, runManager),
+		app.BalanceWatcher,
+		fluxMonitor,
 	}
 	for _, onConnectCallback := range onConnectCallbacks {
 		headTrackable := &headTrackableCallback{func() {
@@ -136,9 +158,12 @@ func (app *ChainlinkApplication) Start() error {
 	return multierr.Combine(
 		app.Store.Start(),
 		app.StatsPusher.Start(),
+		app.RunBroadcaster.Start(),
 		app.RunQueue.Start(),
 		app.RunManager.ResumeAllInProgress(),
 		app.FluxMonitor.Start(),
+		app.MessageQueue.Start(),
+		app.EINotifier.Start(),
 
 		// HeadTracker deliberately started after
 		// RunManager.ResumeAllInProgress since it Connects JobSubscriber
@@ -162,8 +187,12 @@ func (app *ChainlinkApplication) Stop() error {
 		merr = multierr.Append(merr, app.HeadTracker.Stop())
 		app.JobSubscriber.Stop()
 		app.FluxMonitor.Stop()
+		app.MessageQueue.Stop()
+		merr = multierr.Append(merr, app.BalanceWatcher.Stop())
+		merr = multierr.Append(merr, app.EINotifier.Stop())
 		app.RunQueue.Stop()
 		app.StatsPusher.Close()
+		merr = multierr.Append(merr, app.RunBroadcaster.Stop())
 		merr = multierr.Append(merr, app.SessionReaper.Stop())
 		merr = multierr.Append(merr, app.Store.Close())
 	})
@@ -179,6 +208,11 @@ func (app *ChainlinkApplication) GetStatsPusher() synchronization.StatsPusher {
 	return app.StatsPusher
 }
 
+// GetRunBroadcaster returns the broadcaster of job run updates.
+func (app *ChainlinkApplication) GetRunBroadcaster() services.RunBroadcaster {
+	return app.RunBroadcaster
+}
+
 // WakeSessionReaper wakes up the reaper to do its reaping.
 func (app *ChainlinkApplication) WakeSessionReaper() {
 	app.SessionReaper.WakeUp()
@@ -186,37 +220,266 @@ func (app *ChainlinkApplication) WakeSessionReaper() {
 
 // AddJob adds a job to the store and the scheduler. If there was
 // an error from adding the job to the store, the job will not be
-// added to the scheduler.
+// added to the scheduler. Jobs the approval policy holds for approval are
+// saved as proposed, and only added to the scheduler once approved.
 func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
-	err := app.Store.CreateJob(&job)
+	return app.AddJobs([]models.JobSpec{job})
+}
+
+// AddJobs saves the jobs in a single database transaction, so that either
+// every one of them is added or none are, then starts those the approval
+// policy does not hold for approval.
+func (app *ChainlinkApplication) AddJobs(jobs []models.JobSpec) error {
+	err := app.Store.Transaction(func(tx *orm.ORM) error {
+		for i := range jobs {
+			reasons := services.ProposeJobIfRequired(&jobs[i], app.Store.Config)
+			if err := tx.CreateJob(&jobs[i]); err != nil {
+				return errors.Wrapf(err, "job %s", jobs[i].ID)
+			}
+			if jobs[i].Proposed() {
+				if err := services.RecordJobProposal(tx, jobs[i], reasons); err != nil {
+					return err
+				}
+			} else if err := services.RecordEINotification(tx, jobs[i], models.ExternalInitiatorNotificationCreate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	app.EINotifier.WakeUp()
+
+	for _, job := range jobs {
+		if job.Proposed() {
+			continue
+		}
+		app.Scheduler.AddJob(job)
+
+		// XXX: Add mechanism to asynchronously communicate when a job spec has
+		// an ethereum interaction error.
+		// https://www.pivotaltracker.com/story/show/170349568
+		logger.ErrorIf(app.FluxMonitor.AddJob(job))
+		logger.ErrorIf(app.MessageQueue.AddJob(job))
+		logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	}
+	return nil
+}
+
+// UpdateJob saves the job as the next version of the existing job with its ID,
+// then swaps the subscriptions of the existing job's initiators for the new
+// version's, starting each of the new ones before stopping the old. The old
+// initiators keep running the job until then, so that nothing triggering it
+// in between is lost, and are retired once swapped. Versions the approval
+// policy holds for approval have no subscriptions until approved, and a
+// paused job stays paused.
+func (app *ChainlinkApplication) UpdateJob(job models.JobSpec) error {
+	return app.UpdateJobs([]models.JobSpec{job})
+}
+
+// UpdateJobs updates the jobs as UpdateJob does, saving them in a single
+// database transaction so that either every one of them is updated or none
+// are.
+func (app *ChainlinkApplication) UpdateJobs(jobs []models.JobSpec) error {
+	err := app.Store.Transaction(func(tx *orm.ORM) error {
+		for i := range jobs {
+			reasons := services.ProposeJobIfRequired(&jobs[i], app.Store.Config)
+			if err := tx.UpdateJob(&jobs[i]); err != nil {
+				return errors.Wrapf(err, "job %s", jobs[i].ID)
+			}
+			if jobs[i].Proposed() {
+				if err := services.RecordJobProposal(tx, jobs[i], reasons); err != nil {
+					return err
+				}
+			} else if err := services.RecordEINotification(tx, jobs[i], models.ExternalInitiatorNotificationUpdate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	app.EINotifier.WakeUp()
+
+	for _, job := range jobs {
+		if job.Proposed() {
+			app.Scheduler.RemoveJob(job.ID)
+			_ = app.JobSubscriber.RemoveJob(job.ID)
+			app.FluxMonitor.RemoveJob(job.ID)
+			app.MessageQueue.RemoveJob(job.ID)
+		} else {
+			app.Scheduler.AddJob(job)
+			app.subscribe(job)
+		}
+		logger.ErrorIf(app.Store.RetireInitiators(job.ID))
+	}
+	return nil
+}
+
+// subscribe starts the job's flux monitor checkers, message queue consumers
+// and log subscriptions in place of any it had, leaving those a paused job
+// does not have stopped.
+func (app *ChainlinkApplication) subscribe(job models.JobSpec) {
+	if !job.Paused() {
+		logger.ErrorIf(app.FluxMonitor.AddJob(job))
+		logger.ErrorIf(app.MessageQueue.AddJob(job))
+	}
+	if !job.Paused() || app.Store.Config.PausedJobRunLogPolicy() != orm.PausedRunPolicyDrop {
+		logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	}
+}
+
+// ApproveJob approves the job's proposed version on behalf of an approver,
+// who may not be who proposed it, then starts its initiators unless the job
+// is paused.
+func (app *ChainlinkApplication) ApproveJob(ID *models.ID, request models.JobApprovalRequest) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if err = services.AuthenticateJobApprover(job, request, app.Store); err != nil {
+		return err
+	}
+	action := models.ExternalInitiatorNotificationUpdate
+	if job.ApprovedVersion == 0 {
+		action = models.ExternalInitiatorNotificationCreate
+	}
+	err = app.Store.Transaction(func(tx *orm.ORM) error {
+		if err := tx.ApproveJob(ID, request.Version, request.Email, request.Reason); err != nil {
+			return err
+		}
+		approved, err := tx.FindJob(ID)
+		if err != nil {
+			return err
+		}
+		job = approved
+		return services.RecordEINotification(tx, job, action)
+	})
+	if err != nil {
+		return err
+	}
+	logger.Infow("Job approved", "job", ID, "version", request.Version, "actor", request.Email)
+	app.EINotifier.WakeUp()
 
 	app.Scheduler.AddJob(job)
+	app.subscribe(job)
+	return nil
+}
 
-	// XXX: Add mechanism to asynchronously communicate when a job spec has
-	// an ethereum interaction error.
-	// https://www.pivotaltracker.com/story/show/170349568
-	logger.ErrorIf(app.FluxMonitor.AddJob(job))
-	logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+// RejectJob rejects the job's proposed version on behalf of an approver, who
+// may not be who proposed it. The job stays stopped until it is updated or
+// archived.
+func (app *ChainlinkApplication) RejectJob(ID *models.ID, request models.JobApprovalRequest) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if err = services.AuthenticateJobApprover(job, request, app.Store); err != nil {
+		return err
+	}
+	if err = app.Store.RejectJob(ID, request.Version, request.Email, request.Reason); err != nil {
+		return err
+	}
+	logger.Infow("Job rejected", "job", ID, "version", request.Version, "actor", request.Email)
 	return nil
 }
 
 // ArchiveJob silences the job from the system, preventing future job runs.
+// Its external initiator is told to stop initiating it, unless it was never
+// approved and so never told of it.
 func (app *ChainlinkApplication) ArchiveJob(ID *models.ID) error {
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+
+	app.Scheduler.RemoveJob(ID)
 	_ = app.JobSubscriber.RemoveJob(ID)
 	app.FluxMonitor.RemoveJob(ID)
-	return app.Store.ArchiveJob(ID)
+	app.MessageQueue.RemoveJob(ID)
+	err = app.Store.Transaction(func(tx *orm.ORM) error {
+		if err := tx.ArchiveJob(ID); err != nil {
+			return err
+		}
+		if job.ApprovedVersion == 0 {
+			return nil
+		}
+		return services.RecordEINotification(tx, job, models.ExternalInitiatorNotificationArchive)
+	})
+	if err != nil {
+		return err
+	}
+	app.EINotifier.WakeUp()
+	return nil
+}
+
+// PauseJob stops the job from starting runs until it is resumed. Its flux
+// monitor checkers and message queue consumers are stopped, leaving its
+// messages queued, and its log subscriptions too unless requests
+// made while paused are to be queued or errored rather than dropped. Cron,
+// runat, web and external initiators are rejected when they trigger a run.
+func (app *ChainlinkApplication) PauseJob(ID *models.ID) error {
+	err := app.Store.PauseJob(ID)
+	if err != nil {
+		return err
+	}
+
+	app.FluxMonitor.RemoveJob(ID)
+	app.MessageQueue.RemoveJob(ID)
+	if app.Store.Config.PausedJobRunLogPolicy() == orm.PausedRunPolicyDrop {
+		_ = app.JobSubscriber.RemoveJob(ID)
+	}
+	return nil
+}
+
+// ResumeJob restarts the initiators PauseJob stopped, runs those of its runat
+// initiators that came due while it was paused, and starts the runs queued
+// while it was paused. Jobs that are not paused are left alone.
+func (app *ChainlinkApplication) ResumeJob(ID *models.ID) error {
+	err := app.Store.ResumeJob(ID)
+	if err != nil {
+		return err
+	}
+
+	job, err := app.Store.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if job.Proposed() {
+		return nil
+	}
+
+	app.Scheduler.ResumeJob(job)
+	logger.ErrorIf(app.FluxMonitor.AddJob(job))
+	logger.ErrorIf(app.MessageQueue.AddJob(job))
+	if app.Store.Config.PausedJobRunLogPolicy() == orm.PausedRunPolicyDrop {
+		logger.ErrorIf(app.JobSubscriber.AddJob(job, nil))
+	}
+	return app.RunManager.ResumeAllQueued(ID)
 }
 
 // AddServiceAgreement adds a Service Agreement which includes a job that needs
-// to be scheduled.
+// to be scheduled. A job the approval policy holds for approval is saved as
+// proposed, and only scheduled once approved.
 func (app *ChainlinkApplication) AddServiceAgreement(sa *models.ServiceAgreement) error {
-	err := app.Store.CreateServiceAgreement(sa)
+	err := app.Store.Transaction(func(tx *orm.ORM) error {
+		reasons := services.ProposeJobIfRequired(&sa.JobSpec, app.Store.Config)
+		if err := tx.CreateServiceAgreement(sa); err != nil {
+			return err
+		}
+		if sa.JobSpec.Proposed() {
+			return services.RecordJobProposal(tx, sa.JobSpec, reasons)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	if sa.JobSpec.Proposed() {
+		return nil
+	}
 
 	app.Scheduler.AddJob(sa.JobSpec)
 
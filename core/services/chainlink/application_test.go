@@ -7,9 +7,13 @@ import (
 	"testing"
 
 	"chainlink/core/internal/cltest"
+	"chainlink/core/internal/mocks"
 	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
 
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tevino/abool"
 )
@@ -68,3 +72,79 @@ func TestChainlinkApplication_resumesPendingConnection_Archived(t *testing.T) {
 	require.NoError(t, app.StartAndConnect())
 	_ = cltest.WaitForJobRunToComplete(t, store, jr)
 }
+
+func TestChainlinkApplication_PauseUpdateResumeJob(t *testing.T) {
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+
+	fluxMonitor := new(mocks.Service)
+	messageQueue := new(mocks.Service)
+	for _, service := range []*mocks.Service{fluxMonitor, messageQueue} {
+		service.On("Start").Return(nil)
+		service.On("Stop").Return()
+	}
+	app.FluxMonitor = fluxMonitor
+	app.MessageQueue = messageQueue
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+
+	fluxMonitor.On("RemoveJob", job.ID).Return()
+	messageQueue.On("RemoveJob", job.ID).Return()
+	require.NoError(t, app.PauseJob(job.ID))
+
+	// A paused job stays stopped when it is updated
+	update := cltest.NewJobWithFluxMonitorInitiator()
+	update.ID = job.ID
+	require.NoError(t, app.UpdateJob(update))
+	fluxMonitor.AssertNotCalled(t, "AddJob", mock.Anything)
+	messageQueue.AssertNotCalled(t, "AddJob", mock.Anything)
+
+	fluxMonitor.On("AddJob", mock.Anything).Return(nil).Once()
+	messageQueue.On("AddJob", mock.Anything).Return(nil).Once()
+	require.NoError(t, app.ResumeJob(job.ID))
+
+	// Resuming a job that is not paused starts nothing twice
+	assert.Equal(t, orm.ErrorJobNotPaused, app.ResumeJob(job.ID))
+
+	fluxMonitor.AssertExpectations(t)
+	messageQueue.AssertExpectations(t)
+}
+
+func TestChainlinkApplication_UpdateJob_RunsDuringSwap(t *testing.T) {
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+
+	fluxMonitor := new(mocks.Service)
+	messageQueue := new(mocks.Service)
+	for _, service := range []*mocks.Service{fluxMonitor, messageQueue} {
+		service.On("Start").Return(nil)
+		service.On("Stop").Return()
+	}
+	app.FluxMonitor = fluxMonitor
+	app.MessageQueue = messageQueue
+	require.NoError(t, app.Start())
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, app.Store.CreateJob(&job))
+	initiator := job.Initiators[0]
+
+	// The old checker triggers a run while the new version's starts
+	update := cltest.NewJobWithFluxMonitorInitiator()
+	update.ID = job.ID
+	fluxMonitor.On("AddJob", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) {
+		_, err := app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+		assert.NoError(t, err)
+	})
+	messageQueue.On("AddJob", mock.Anything).Return(nil).Once()
+	require.NoError(t, app.UpdateJob(update))
+
+	fluxMonitor.AssertExpectations(t)
+	fluxMonitor.AssertNotCalled(t, "RemoveJob", mock.Anything)
+	messageQueue.AssertExpectations(t)
+
+	// Once swapped, the old initiator no longer runs the job
+	_, err := app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+	assert.Error(t, err)
+}
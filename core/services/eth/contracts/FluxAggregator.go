@@ -15,6 +15,7 @@ import (
 type FluxAggregator interface {
 	ethsvc.ConnectedContract
 	RoundState(oracle common.Address) (FluxAggregatorRoundState, error)
+	OracleStatus(oracle common.Address) (FluxAggregatorOracleStatus, error)
 }
 
 const (
@@ -86,3 +87,32 @@ func (fa *fluxAggregator) RoundState(oracle common.Address) (FluxAggregatorRound
 	}
 	return result, nil
 }
+
+// FluxAggregatorOracleStatus is whether an oracle may submit to the
+// aggregator: whether it is among the aggregator's oracles, and whether the
+// aggregator has stopped taking answers, which its owner does by setting its
+// maximum answer count to 0.
+type FluxAggregatorOracleStatus struct {
+	Permitted bool
+	Paused    bool
+}
+
+func (fa *fluxAggregator) OracleStatus(oracle common.Address) (FluxAggregatorOracleStatus, error) {
+	var oracles []common.Address
+	if err := fa.Call(&oracles, "getOracles"); err != nil {
+		return FluxAggregatorOracleStatus{}, errors.Wrap(err, "unable to fetch oracles")
+	}
+	var maxAnswerCount uint32
+	if err := fa.Call(&maxAnswerCount, "maxAnswerCount"); err != nil {
+		return FluxAggregatorOracleStatus{}, errors.Wrap(err, "unable to fetch maxAnswerCount")
+	}
+
+	status := FluxAggregatorOracleStatus{Paused: maxAnswerCount == 0}
+	for _, o := range oracles {
+		if o == oracle {
+			status.Permitted = true
+			break
+		}
+	}
+	return status, nil
+}
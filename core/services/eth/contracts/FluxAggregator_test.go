@@ -84,6 +84,59 @@ func TestFluxAggregatorClient_RoundState(t *testing.T) {
 	}
 }
 
+func TestFluxAggregatorClient_OracleStatus(t *testing.T) {
+	aggregatorAddress := cltest.NewAddress()
+	nodeAddr := cltest.NewAddress()
+	otherAddr := cltest.NewAddress()
+
+	callArgs := func(signature string) eth.CallArgs {
+		return eth.CallArgs{To: aggregatorAddress, Data: utils.MustHash(signature).Bytes()[:4]}
+	}
+	makeOracles := func(oracles ...common.Address) string {
+		data := utils.EVMWordUint64(32)
+		data = append(data, utils.EVMWordUint64(uint64(len(oracles)))...)
+		for _, oracle := range oracles {
+			data = append(data, common.LeftPadBytes(oracle.Bytes(), 32)...)
+		}
+		return "0x" + hex.EncodeToString(data)
+	}
+
+	tests := []struct {
+		name           string
+		oracles        string
+		maxAnswerCount uint64
+		expected       contracts.FluxAggregatorOracleStatus
+	}{
+		{"permitted", makeOracles(otherAddr, nodeAddr), 3, contracts.FluxAggregatorOracleStatus{Permitted: true}},
+		{"removed", makeOracles(otherAddr), 3, contracts.FluxAggregatorOracleStatus{}},
+		{"paused", makeOracles(nodeAddr), 0, contracts.FluxAggregatorOracleStatus{Permitted: true, Paused: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ethClient := new(mocks.Client)
+			respond := func(response string) func(mock.Arguments) {
+				return func(args mock.Arguments) {
+					err := args.Get(0).(encoding.TextUnmarshaler).UnmarshalText([]byte(response))
+					require.NoError(t, err)
+				}
+			}
+			ethClient.On("Call", mock.Anything, "eth_call", callArgs("getOracles()"), "latest").Return(nil).
+				Run(respond(test.oracles))
+			ethClient.On("Call", mock.Anything, "eth_call", callArgs("maxAnswerCount()"), "latest").Return(nil).
+				Run(respond("0x" + hex.EncodeToString(utils.EVMWordUint64(test.maxAnswerCount))))
+
+			fa, err := contracts.NewFluxAggregator(aggregatorAddress, ethClient, nil)
+			require.NoError(t, err)
+
+			status, err := fa.OracleStatus(nodeAddr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, status)
+			ethClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestFluxAggregatorClient_DecodesLogs(t *testing.T) {
 	fa, err := contracts.NewFluxAggregator(common.Address{}, nil, nil)
 	require.NoError(t, err)
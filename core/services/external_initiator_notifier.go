@@ -0,0 +1,311 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+
+	"github.com/jpillora/backoff"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	// EINotificationMinBackoff is how long a notification an external
+	// initiator did not accept waits before it is first sent again.
+	EINotificationMinBackoff = time.Second
+	// EINotificationMaxBackoff is the longest a notification waits between
+	// attempts, however many times it was not accepted.
+	EINotificationMaxBackoff = time.Hour
+	// eiRequestTimeout is how long an external initiator may take to answer a
+	// notification or health check.
+	eiRequestTimeout = 10 * time.Second
+)
+
+// ExternalInitiatorNotifier sends external initiators the notifications in
+// their outbox, telling them of the jobs they initiate being created,
+// updated and archived, and periodically checks their health.
+type ExternalInitiatorNotifier struct {
+	store  *store.Store
+	client *http.Client
+	chWake chan struct{}
+	chStop chan struct{}
+	chDone chan struct{}
+}
+
+// NewExternalInitiatorNotifier returns a notifier that sends the
+// notifications in the store's outbox once started.
+func NewExternalInitiatorNotifier(store *store.Store) *ExternalInitiatorNotifier {
+	return &ExternalInitiatorNotifier{
+		store:  store,
+		client: &http.Client{Timeout: eiRequestTimeout},
+		chWake: make(chan struct{}, 1),
+		chStop: make(chan struct{}),
+		chDone: make(chan struct{}),
+	}
+}
+
+// Start checks the health of the external initiators and sends the
+// notifications left in the outbox, then keeps doing so in the background.
+func (n *ExternalInitiatorNotifier) Start() error {
+	go n.run()
+	return nil
+}
+
+// Stop stops sending notifications and checking health. Notifications not
+// sent yet stay in the outbox.
+func (n *ExternalInitiatorNotifier) Stop() error {
+	close(n.chStop)
+	<-n.chDone
+	return nil
+}
+
+func (n *ExternalInitiatorNotifier) run() {
+	defer close(n.chDone)
+
+	var chHealthCheck <-chan time.Time
+	if interval := n.store.Config.EIHealthCheckInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		chHealthCheck = ticker.C
+		n.CheckHealth(time.Now())
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-n.chStop:
+			return
+		case <-chHealthCheck:
+			n.CheckHealth(time.Now())
+			continue
+		case <-n.chWake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+		if next := n.Deliver(time.Now()); !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// WakeUp has the notifier send the notifications in the outbox, such as those
+// just recorded.
+func (n *ExternalInitiatorNotifier) WakeUp() {
+	select {
+	case n.chWake <- struct{}{}:
+	default:
+	}
+}
+
+// RecordEINotification adds a notice of the action taken on the job to the
+// outbox of the external initiator responsible for initiating it, if it has a
+// URL to send it to. It is recorded through the transaction taking the
+// action, so that the notice is sent if and only if the action is saved, once
+// the notifier is woken up after the transaction commits.
+func RecordEINotification(tx *orm.ORM, job models.JobSpec, action string) error {
+	initrs := job.InitiatorsFor(models.InitiatorExternal)
+	if len(initrs) > 1 {
+		return errors.New("must have one or less External Initiators")
+	}
+	if len(initrs) == 0 {
+		return nil
+	}
+	initr := initrs[0]
+
+	ei, err := tx.FindExternalInitiatorByName(initr.Name)
+	if err != nil {
+		return errors.Wrap(err, "external initiator")
+	}
+	if ei.URL == nil {
+		return nil
+	}
+	notice, err := models.NewJobSpecNotice(initr, job, action)
+	if err != nil {
+		return errors.Wrap(err, "new Job Spec notification")
+	}
+	b, err := json.Marshal(notice)
+	if err != nil {
+		return errors.Wrap(err, "new Job Spec notification")
+	}
+	body, err := models.ParseJSON(b)
+	if err != nil {
+		return errors.Wrap(err, "new Job Spec notification")
+	}
+
+	err = tx.CreateExternalInitiatorNotification(&models.ExternalInitiatorNotification{
+		ExternalInitiatorName: ei.Name,
+		JobSpecID:             job.ID,
+		Action:                action,
+		Body:                  body,
+		NextAttemptAt:         time.Now(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "saving Job Spec notification")
+	}
+	return nil
+}
+
+// Deliver sends the notifications that are due, returning when the next one
+// is due, or the zero time if none are pending. Each external initiator is
+// sent its notifications in the order they were made, so that it learns of a
+// job's creation, updates and archival in turn: one that is waiting to be
+// sent again holds back those after it.
+func (n *ExternalInitiatorNotifier) Deliver(now time.Time) time.Time {
+	pending, err := n.store.PendingExternalInitiatorNotifications()
+	if err != nil {
+		logger.Errorw("Unable to load external initiator notifications", "error", err)
+		return now.Add(EINotificationMinBackoff)
+	}
+
+	var next time.Time
+	heldBack := map[string]bool{}
+	for _, notification := range pending {
+		name := notification.ExternalInitiatorName
+		if heldBack[name] {
+			continue
+		}
+		if notification.NextAttemptAt.After(now) || !n.deliver(&notification, now) {
+			heldBack[name] = true
+			if next.IsZero() || notification.NextAttemptAt.Before(next) {
+				next = notification.NextAttemptAt
+			}
+		}
+	}
+	return next
+}
+
+// deliver sends the notification, returning whether it is settled, either
+// sent or out of attempts.
+func (n *ExternalInitiatorNotifier) deliver(notification *models.ExternalInitiatorNotification, now time.Time) bool {
+	logFields := []interface{}{
+		"externalInitiator", notification.ExternalInitiatorName,
+		"job", notification.JobSpecID,
+		"action", notification.Action,
+	}
+
+	err := n.send(notification)
+	notification.Attempts++
+	if err == nil {
+		notification.SentAt = null.TimeFrom(now)
+		notification.LastError = ""
+	} else if notification.Attempts >= n.store.Config.EINotificationMaxAttempts() {
+		notification.FailedAt = null.TimeFrom(now)
+		notification.LastError = err.Error()
+		logger.Errorw("Giving up notifying external initiator", append(logFields, "attempts", notification.Attempts, "error", err)...)
+	} else {
+		notification.NextAttemptAt = now.Add(eiNotificationBackoff(notification.Attempts))
+		notification.LastError = err.Error()
+		logger.Warnw("Unable to notify external initiator, retrying", append(logFields, "retryAt", notification.NextAttemptAt, "error", err)...)
+	}
+
+	if err := n.store.SaveExternalInitiatorNotification(notification); err != nil {
+		logger.Errorw("Unable to save external initiator notification", append(logFields, "error", err)...)
+		return false
+	}
+	return notification.SentAt.Valid || notification.FailedAt.Valid
+}
+
+func (n *ExternalInitiatorNotifier) send(notification *models.ExternalInitiatorNotification) error {
+	ei, err := n.store.FindExternalInitiatorByName(notification.ExternalInitiatorName)
+	if err != nil {
+		return errors.Wrap(err, "external initiator")
+	}
+	if ei.URL == nil {
+		return errors.New("external initiator has no URL")
+	}
+
+	b, err := json.Marshal(notification.Body)
+	if err != nil {
+		return err
+	}
+	req, err := newEIRequest(http.MethodPost, ei.URL.String(), ei, b)
+	if err != nil {
+		return err
+	}
+	return n.do(req, ei)
+}
+
+// eiNotificationBackoff returns how long a notification that was not
+// accepted on its last attempt waits before the next one.
+func eiNotificationBackoff(attempts uint32) time.Duration {
+	b := backoff.Backoff{
+		Min:    EINotificationMinBackoff,
+		Max:    EINotificationMaxBackoff,
+		Factor: 2,
+	}
+	return b.ForAttempt(float64(attempts - 1))
+}
+
+// CheckHealth asks each external initiator with a URL whether it is healthy,
+// by a GET of health resolved relative to its URL, so that one notified at
+// https://example.com/ei/jobs is checked at https://example.com/ei/health, and
+// records the answer as its status.
+func (n *ExternalInitiatorNotifier) CheckHealth(now time.Time) {
+	eis, err := n.store.ExternalInitiators()
+	if err != nil {
+		logger.Errorw("Unable to load external initiators", "error", err)
+		return
+	}
+
+	for _, ei := range eis {
+		if ei.URL == nil {
+			continue
+		}
+		status, checkError := models.ExternalInitiatorStatusHealthy, ""
+		if err := n.checkHealth(ei); err != nil {
+			status, checkError = models.ExternalInitiatorStatusUnhealthy, err.Error()
+			if ei.Status != models.ExternalInitiatorStatusUnhealthy {
+				logger.Warnw("External initiator is unhealthy", "externalInitiator", ei.Name, "error", err)
+			}
+		}
+		if err := n.store.UpdateExternalInitiatorHealth(ei.Name, status, checkError, now); err != nil {
+			logger.Errorw("Unable to save external initiator health", "externalInitiator", ei.Name, "error", err)
+		}
+	}
+}
+
+func (n *ExternalInitiatorNotifier) checkHealth(ei models.ExternalInitiator) error {
+	eiURL := url.URL(*ei.URL)
+	healthURL := eiURL.ResolveReference(&url.URL{Path: "health"})
+	req, err := newEIRequest(http.MethodGet, healthURL.String(), ei, nil)
+	if err != nil {
+		return err
+	}
+	return n.do(req, ei)
+}
+
+func (n *ExternalInitiatorNotifier) do(req *http.Request, ei models.ExternalInitiator) error {
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not reach '%s' (%s)", ei.Name, req.URL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("'%s' (%s) received bad response '%s'", ei.Name, req.URL, resp.Status)
+	}
+	return nil
+}
+
+func newEIRequest(method, rawURL string, ei models.ExternalInitiator, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(models.ExternalInitiatorAccessKeyHeader, ei.OutgoingToken)
+	req.Header.Set(models.ExternalInitiatorSecretHeader, ei.OutgoingSecret)
+	return req, nil
+}
@@ -0,0 +1,234 @@
+package services_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"chainlink/core/auth"
+	"chainlink/core/internal/cltest"
+	"chainlink/core/services"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eiServer stands in for an external initiator, answering notifications and
+// health checks with the statuses it is given, in turn.
+type eiServer struct {
+	*httptest.Server
+	notices  []models.JobSpecNotice
+	headers  []http.Header
+	paths    []string
+	statuses []int
+}
+
+func newEIServer(t *testing.T, statuses ...int) *eiServer {
+	s := &eiServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			b, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			var notice models.JobSpecNotice
+			require.NoError(t, json.Unmarshal(b, &notice))
+			s.notices = append(s.notices, notice)
+		}
+		s.headers = append(s.headers, r.Header)
+		s.paths = append(s.paths, r.URL.Path)
+
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	return s
+}
+
+func createEI(t *testing.T, store *store.Store, name, rawURL string) *models.ExternalInitiator {
+	eir := &models.ExternalInitiatorRequest{Name: name}
+	if rawURL != "" {
+		url := cltest.WebURL(t, rawURL)
+		eir.URL = &url
+	}
+	ei, err := models.NewExternalInitiator(auth.NewToken(), eir)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateExternalInitiator(ei))
+	return ei
+}
+
+func newEIJob(t *testing.T, store *store.Store, ei *models.ExternalInitiator) models.JobSpec {
+	job := cltest.NewJobWithExternalInitiator(ei)
+	body := cltest.JSONFromString(t, `{"foo":"bar"}`)
+	job.Initiators[0].Body = &body
+	require.NoError(t, store.CreateJob(&job))
+	return job
+}
+
+func TestExternalInitiatorNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	server := newEIServer(t)
+	defer server.Close()
+	ei := createEI(t, store, "somecoin", server.URL)
+	job := newEIJob(t, store, ei)
+
+	notifier := services.NewExternalInitiatorNotifier(store)
+	require.NoError(t, services.RecordEINotification(store.ORM, job, models.ExternalInitiatorNotificationCreate))
+	require.NoError(t, services.RecordEINotification(store.ORM, job, models.ExternalInitiatorNotificationArchive))
+	next := notifier.Deliver(time.Now())
+	assert.True(t, next.IsZero())
+
+	require.Len(t, server.notices, 2)
+	assert.Equal(t, models.JobSpecNotice{
+		JobID:  job.ID,
+		Type:   models.InitiatorExternal,
+		Action: models.ExternalInitiatorNotificationCreate,
+		Params: cltest.JSONFromString(t, `{"foo":"bar"}`),
+	}, server.notices[0])
+	assert.Equal(t, models.ExternalInitiatorNotificationArchive, server.notices[1].Action)
+	assert.Equal(t, ei.OutgoingToken, server.headers[0].Get(models.ExternalInitiatorAccessKeyHeader))
+	assert.Equal(t, ei.OutgoingSecret, server.headers[0].Get(models.ExternalInitiatorSecretHeader))
+
+	pending, err := store.CountPendingExternalInitiatorNotifications(ei.Name)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+}
+
+func TestExternalInitiatorNotifier_Notify_NotNotified(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	withoutURL := createEI(t, store, "withouturl", "")
+
+	require.NoError(t, services.RecordEINotification(store.ORM, cltest.NewJobWithWebInitiator(), models.ExternalInitiatorNotificationCreate))
+	require.NoError(t, services.RecordEINotification(store.ORM, newEIJob(t, store, withoutURL), models.ExternalInitiatorNotificationCreate))
+
+	pending, err := store.PendingExternalInitiatorNotifications()
+	require.NoError(t, err)
+	assert.Len(t, pending, 0)
+}
+
+func TestExternalInitiatorNotifier_Notify_RolledBack(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ei := createEI(t, store, "somecoin", "http://example.com")
+	job := newEIJob(t, store, ei)
+
+	// A notice recorded by an action that is rolled back is never sent
+	err := store.Transaction(func(tx *orm.ORM) error {
+		require.NoError(t, services.RecordEINotification(tx, job, models.ExternalInitiatorNotificationArchive))
+		return errors.New("unable to archive job")
+	})
+	require.Error(t, err)
+
+	pending, err := store.CountPendingExternalInitiatorNotifications(ei.Name)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+}
+
+func TestExternalInitiatorNotifier_Deliver_RetriesInOrder(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	server := newEIServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+	ei := createEI(t, store, "somecoin", server.URL)
+	job := newEIJob(t, store, ei)
+
+	notifier := services.NewExternalInitiatorNotifier(store)
+	require.NoError(t, services.RecordEINotification(store.ORM, job, models.ExternalInitiatorNotificationCreate))
+	require.NoError(t, services.RecordEINotification(store.ORM, job, models.ExternalInitiatorNotificationUpdate))
+
+	now := time.Now()
+	next := notifier.Deliver(now)
+	assert.Equal(t, now.Add(services.EINotificationMinBackoff), next)
+	assert.Len(t, server.notices, 1)
+
+	// The update waits for the create to be sent
+	assert.WithinDuration(t, next, notifier.Deliver(now), time.Millisecond)
+	assert.Len(t, server.notices, 1)
+
+	now = next
+	next = notifier.Deliver(now)
+	assert.WithinDuration(t, now.Add(2*services.EINotificationMinBackoff), next, time.Millisecond)
+	assert.Len(t, server.notices, 2)
+
+	assert.True(t, notifier.Deliver(next).IsZero())
+	require.Len(t, server.notices, 4)
+	assert.Equal(t, models.ExternalInitiatorNotificationCreate, server.notices[2].Action)
+	assert.Equal(t, models.ExternalInitiatorNotificationUpdate, server.notices[3].Action)
+}
+
+func TestExternalInitiatorNotifier_Deliver_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("EXTERNAL_INITIATOR_NOTIFICATION_MAX_ATTEMPTS", 2)
+
+	server := newEIServer(t, http.StatusInternalServerError, http.StatusInternalServerError)
+	defer server.Close()
+	ei := createEI(t, store, "somecoin", server.URL)
+	job := newEIJob(t, store, ei)
+
+	notifier := services.NewExternalInitiatorNotifier(store)
+	require.NoError(t, services.RecordEINotification(store.ORM, job, models.ExternalInitiatorNotificationCreate))
+
+	next := notifier.Deliver(time.Now())
+	require.False(t, next.IsZero())
+	assert.True(t, notifier.Deliver(next).IsZero())
+	assert.Len(t, server.notices, 2)
+
+	pending, err := store.CountPendingExternalInitiatorNotifications(ei.Name)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+}
+
+func TestExternalInitiatorNotifier_CheckHealth(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	healthy := newEIServer(t, http.StatusOK)
+	defer healthy.Close()
+	unhealthy := newEIServer(t, http.StatusServiceUnavailable)
+	defer unhealthy.Close()
+	createEI(t, store, "healthy", healthy.URL+"/ei/jobs?token=abc")
+	createEI(t, store, "unhealthy", unhealthy.URL+"/jobs")
+	createEI(t, store, "withouturl", "")
+
+	now := time.Now()
+	services.NewExternalInitiatorNotifier(store).CheckHealth(now)
+
+	status := func(name string) models.ExternalInitiator {
+		ei, err := store.FindExternalInitiatorByName(name)
+		require.NoError(t, err)
+		return ei
+	}
+	assert.Equal(t, models.ExternalInitiatorStatusHealthy, status("healthy").Status)
+	assert.True(t, status("healthy").CheckedAt.Valid)
+	assert.Equal(t, []string{"/ei/health"}, healthy.paths)
+	assert.Equal(t, models.ExternalInitiatorStatusUnhealthy, status("unhealthy").Status)
+	assert.Contains(t, status("unhealthy").CheckError, "503")
+	assert.Equal(t, models.ExternalInitiatorStatusUnknown, status("withouturl").Status)
+	assert.False(t, status("withouturl").CheckedAt.Valid)
+}
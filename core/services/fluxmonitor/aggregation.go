@@ -0,0 +1,449 @@
+package fluxmonitor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"chainlink/core/logger"
+	"chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+)
+
+const (
+	// AggregationMedian answers with the median of the feeds' values, or the
+	// average of the two middle values for an even number of feeds. It is
+	// used when an initiator does not choose an aggregation.
+	AggregationMedian = "median"
+	// AggregationTrimmedMean answers with the mean of the feeds' values, once
+	// trimPercent of them are dropped from each end.
+	AggregationTrimmedMean = "trimmedMean"
+	// AggregationVolumeWeighted answers with the mean of the feeds' values,
+	// weighted by the weight each feed returns alongside its result.
+	AggregationVolumeWeighted = "volumeWeighted"
+	// AggregationMode answers with the value most feeds agree on, for feeds
+	// reporting discrete values.
+	AggregationMode = "mode"
+)
+
+// Feed statuses, as logged and exported as the status label of
+// flux_monitor_feed_inclusions_total and flux_monitor_request_duration_seconds.
+const (
+	feedAnswered   = "answered"
+	feedIncluded   = "included"
+	feedErrored    = "errored"
+	feedTimedOut   = "timedOut"
+	feedOutlier    = "outlier"
+	feedUnweighted = "unweighted"
+)
+
+// IsValidAggregation returns whether the aggregation is one an initiator may
+// choose. The empty aggregation is the median.
+func IsValidAggregation(aggregation string) bool {
+	switch aggregation {
+	case "", AggregationMedian, AggregationTrimmedMean, AggregationVolumeWeighted, AggregationMode:
+		return true
+	default:
+		return false
+	}
+}
+
+// aggregateOptions choose how an aggregateFetcher combines its feeds' values,
+// and how long it waits for them. Without a feedTimeout or deadline,
+// defaultHTTPTimeout is used.
+type aggregateOptions struct {
+	aggregation      string
+	trimPercent      float64
+	minFeeds         int
+	outlierThreshold float64
+	feedTimeout      time.Duration
+	deadline         time.Duration
+	jobSpecID        string
+}
+
+func aggregateOptionsFromInitiator(initr models.Initiator) aggregateOptions {
+	opts := aggregateOptions{
+		aggregation:      initr.InitiatorParams.Aggregation,
+		trimPercent:      float64(initr.InitiatorParams.TrimPercent),
+		minFeeds:         int(initr.InitiatorParams.MinFeeds),
+		outlierThreshold: float64(initr.InitiatorParams.OutlierThreshold),
+		feedTimeout:      initr.InitiatorParams.FeedTimeout.Duration(),
+	}
+	if initr.JobSpecID != nil {
+		opts.jobSpecID = initr.JobSpecID.String()
+	}
+	return opts
+}
+
+// observation is a feed's answer, with the weight it is given when
+// aggregating by volume, if it returned one.
+type observation struct {
+	value  decimal.Decimal
+	weight *decimal.Decimal
+}
+
+// observer is implemented by fetchers whose answers can carry a weight, and
+// which stop fetching once the context is done.
+type observer interface {
+	observe(ctx context.Context) (observation, error)
+}
+
+// feed is one of an aggregateFetcher's fetchers. Its name labels it in
+// metrics, logs and the feed results reported, and so never holds the URL it
+// is fetched from, which may carry credentials; feeds are named after their
+// bridge, or else by their index and the host they are fetched from.
+type feed struct {
+	name    string
+	fetcher Fetcher
+}
+
+// aggregateFetcher fetches from all its feeds at once and combines their
+// values with the chosen aggregation, once the feeds in error or too slow and
+// those too far from the median are left out. Each feed has feedTimeout to
+// answer, and all of them share the deadline; answers arriving later are
+// discarded.
+type aggregateFetcher struct {
+	feeds   []feed
+	options aggregateOptions
+
+	lastResults      models.FluxMonitorFeedResults
+	lastResultsMutex sync.Mutex
+}
+
+// newAggregateFetcherFromURLs creates an aggregate fetcher that retrieves a
+// price from all passed URLs using httpFetcher. Feeds given a bridge name are
+// named after their bridge.
+func newAggregateFetcherFromURLs(
+	timeout time.Duration,
+	requestData string,
+	priceURLs []*url.URL,
+	bridgeNames []string,
+	options aggregateOptions,
+) (Fetcher, error) {
+	fetchers := []Fetcher{}
+	for i, url := range priceURLs {
+		fetcher := newHTTPFetcher(timeout, requestData, url).(*httpFetcher)
+		if i < len(bridgeNames) && bridgeNames[i] != "" {
+			fetcher.bridgeName = bridgeNames[i]
+		}
+		fetchers = append(fetchers, fetcher)
+	}
+	return newAggregateFetcher(options, fetchers...)
+}
+
+func newAggregateFetcher(options aggregateOptions, fetchers ...Fetcher) (Fetcher, error) {
+	if len(fetchers) == 0 {
+		return nil, errors.New("must pass in at least one price fetcher to newAggregateFetcher")
+	}
+	if !IsValidAggregation(options.aggregation) {
+		return nil, fmt.Errorf("unknown aggregation %q", options.aggregation)
+	}
+	if options.minFeeds > len(fetchers) {
+		return nil, fmt.Errorf("minFeeds %d exceeds the %d feeds", options.minFeeds, len(fetchers))
+	}
+
+	feeds := make([]feed, len(fetchers))
+	for i, fetcher := range fetchers {
+		name := fmt.Sprintf("feed %d", i)
+		if hf, ok := fetcher.(*httpFetcher); ok {
+			if hf.bridgeName != "" {
+				name = fmt.Sprintf("bridge %s", hf.bridgeName)
+			} else {
+				name = fmt.Sprintf("feed %d (%s)", i, hf.url.Host)
+			}
+		}
+		feeds[i] = feed{name: name, fetcher: fetcher}
+	}
+	if options.deadline == 0 {
+		options.deadline = defaultHTTPTimeout
+	}
+	if options.feedTimeout == 0 || options.feedTimeout > options.deadline {
+		options.feedTimeout = options.deadline
+	}
+	return &aggregateFetcher{feeds: feeds, options: options}, nil
+}
+
+// newMedianFetcher creates an aggregate fetcher answering with the median of
+// the fetchers' values.
+func newMedianFetcher(fetchers ...Fetcher) (Fetcher, error) {
+	return newAggregateFetcher(aggregateOptions{}, fetchers...)
+}
+
+type feedResult struct {
+	observation
+	err    error
+	status string
+}
+
+func (a *aggregateFetcher) Fetch() (decimal.Decimal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.options.deadline)
+	defer cancel()
+
+	results := make([]feedResult, len(a.feeds))
+	var wg sync.WaitGroup
+	wg.Add(len(a.feeds))
+	for i := range a.feeds {
+		go func(i int) {
+			defer wg.Done()
+			results[i] = a.fetchFeed(ctx, a.feeds[i])
+		}(i)
+	}
+	wg.Wait()
+
+	reported := make(models.FluxMonitorFeedResults, len(a.feeds))
+	defer a.setLastResults(reported)
+
+	observations := []observation{}
+	indexes := []int{}
+	fetchErrors := []error{}
+	for i, result := range results {
+		reported[i] = models.FluxMonitorFeedResult{Feed: a.feeds[i].name, Status: result.status}
+		if result.err != nil {
+			reported[i].Error = result.err.Error()
+			a.exclude(a.feeds[i].name, result.status, "error", result.err)
+			fetchErrors = append(fetchErrors, result.err)
+			continue
+		}
+		reported[i].Value = decimal.NullDecimal{Decimal: result.value, Valid: true}
+		observations = append(observations, result.observation)
+		indexes = append(indexes, i)
+	}
+
+	if a.options.minFeeds == 0 {
+		errorRate := float64(len(fetchErrors)) / float64(len(a.feeds))
+		if errorRate >= 0.5 {
+			return decimal.Decimal{}, errors.Wrap(multierr.Combine(fetchErrors...), "majority of fetchers failed")
+		}
+	}
+
+	included := []observation{}
+	if len(observations) > 0 {
+		values := make([]decimal.Decimal, len(observations))
+		for i, obs := range observations {
+			values[i] = obs.value
+		}
+		median := medianOf(values)
+
+		for i, obs := range observations {
+			entry := &reported[indexes[i]]
+			if a.isOutlier(obs.value, median) {
+				entry.Status = feedOutlier
+				a.exclude(entry.Feed, feedOutlier, "value", obs.value, "median", median)
+				continue
+			}
+			if a.options.aggregation == AggregationVolumeWeighted && obs.weight == nil {
+				entry.Status = feedUnweighted
+				a.exclude(entry.Feed, feedUnweighted, "value", obs.value)
+				continue
+			}
+			entry.Status = feedIncluded
+			a.include(entry.Feed, obs.value)
+			included = append(included, obs)
+		}
+	}
+
+	if len(included) == 0 || len(included) < a.options.minFeeds {
+		err := fmt.Errorf("only %d of %d feeds can be aggregated, need %d", len(included), len(a.feeds), a.options.minFeeds)
+		return decimal.Decimal{}, multierr.Combine(append([]error{err}, fetchErrors...)...)
+	}
+
+	return a.aggregate(included)
+}
+
+// FeedResults returns how each feed answered the last fetch, and whether its
+// value was included in the aggregate.
+func (a *aggregateFetcher) FeedResults() models.FluxMonitorFeedResults {
+	a.lastResultsMutex.Lock()
+	defer a.lastResultsMutex.Unlock()
+	return append(models.FluxMonitorFeedResults{}, a.lastResults...)
+}
+
+func (a *aggregateFetcher) setLastResults(results models.FluxMonitorFeedResults) {
+	a.lastResultsMutex.Lock()
+	defer a.lastResultsMutex.Unlock()
+	a.lastResults = results
+}
+
+// fetchFeed fetches the feed's answer, giving up once its feedTimeout has
+// passed or the context is done. The fetch is left to finish on its own,
+// and its answer discarded, if the feed cannot be stopped.
+func (a *aggregateFetcher) fetchFeed(ctx context.Context, feed feed) feedResult {
+	ctx, cancel := context.WithTimeout(ctx, a.options.feedTimeout)
+	defer cancel()
+
+	start := time.Now()
+	chResult := make(chan feedResult, 1)
+	go func() {
+		obs, err := fetchObservation(ctx, feed.fetcher)
+		chResult <- feedResult{observation: obs, err: err, status: feedErrored}
+	}()
+
+	var result feedResult
+	select {
+	case result = <-chResult:
+		if result.err == nil {
+			result.status = feedAnswered
+		} else if ctx.Err() != nil {
+			result.status = feedTimedOut
+		}
+	case <-ctx.Done():
+		result = feedResult{
+			err:    errors.Wrapf(ctx.Err(), "%s did not answer within %s", feed.name, a.options.feedTimeout),
+			status: feedTimedOut,
+		}
+	}
+	promFMResponseTime.WithLabelValues(feed.name, result.status).Observe(time.Since(start).Seconds())
+	return result
+}
+
+func fetchObservation(ctx context.Context, fetcher Fetcher) (observation, error) {
+	if o, ok := fetcher.(observer); ok {
+		return o.observe(ctx)
+	}
+	value, err := fetcher.Fetch()
+	return observation{value: value}, err
+}
+
+// isOutlier returns whether the value is more than outlierThreshold percent
+// away from the median. Nothing is an outlier when there is no threshold, or
+// when the median is zero and no percentage of it can be taken.
+func (a *aggregateFetcher) isOutlier(value, median decimal.Decimal) bool {
+	if a.options.outlierThreshold <= 0 || median.IsZero() {
+		return false
+	}
+	deviation, _ := value.Sub(median).Div(median).Abs().Mul(decimal.NewFromInt(100)).Float64()
+	return deviation > a.options.outlierThreshold
+}
+
+func (a *aggregateFetcher) include(name string, value decimal.Decimal) {
+	promFMFeedInclusions.WithLabelValues(a.options.jobSpecID, name, feedIncluded).Inc()
+	logger.Debugw("Including feed in aggregate",
+		"job", a.options.jobSpecID,
+		"feed", name,
+		"value", value,
+	)
+}
+
+func (a *aggregateFetcher) exclude(name, status string, keysAndValues ...interface{}) {
+	promFMFeedInclusions.WithLabelValues(a.options.jobSpecID, name, status).Inc()
+	logger.Warnw("Excluding feed from aggregate", append([]interface{}{
+		"job", a.options.jobSpecID,
+		"feed", name,
+		"reason", status,
+	}, keysAndValues...)...)
+}
+
+func (a *aggregateFetcher) aggregate(observations []observation) (decimal.Decimal, error) {
+	values := make([]decimal.Decimal, len(observations))
+	for i, obs := range observations {
+		values[i] = obs.value
+	}
+
+	switch a.options.aggregation {
+	case AggregationTrimmedMean:
+		return trimmedMeanOf(values, a.options.trimPercent), nil
+	case AggregationVolumeWeighted:
+		return volumeWeightedMeanOf(observations)
+	case AggregationMode:
+		return modeOf(values)
+	default:
+		return medianOf(values), nil
+	}
+}
+
+func (a *aggregateFetcher) String() string {
+	feedDescriptions := make([]string, len(a.feeds))
+	for i, feed := range a.feeds {
+		feedDescriptions[i] = fmt.Sprintf("%s", feed.fetcher)
+	}
+	aggregation := a.options.aggregation
+	if aggregation == "" {
+		aggregation = AggregationMedian
+	}
+	return fmt.Sprintf("%s fetcher: %s", aggregation, strings.Join(feedDescriptions, ","))
+}
+
+func sortedDecimals(values []decimal.Decimal) []decimal.Decimal {
+	sorted := append([]decimal.Decimal{}, values...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	return sorted
+}
+
+// medianOf returns the median of the values, or the average of the two
+// middle values for an even number of them.
+func medianOf(values []decimal.Decimal) decimal.Decimal {
+	sorted := sortedDecimals(values)
+	k := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[k]
+	}
+	return sorted[k].Add(sorted[k-1]).Div(decimal.NewFromInt(2))
+}
+
+// trimmedMeanOf returns the mean of the values once trimPercent of them,
+// rounded down, are dropped from each end. At least one value is kept.
+func trimmedMeanOf(values []decimal.Decimal, trimPercent float64) decimal.Decimal {
+	sorted := sortedDecimals(values)
+	trim := int(math.Floor(float64(len(sorted)) * trimPercent / 100))
+	if len(sorted)-2*trim < 1 {
+		trim = (len(sorted) - 1) / 2
+	}
+	kept := sorted[trim : len(sorted)-trim]
+
+	sum := decimal.Zero
+	for _, value := range kept {
+		sum = sum.Add(value)
+	}
+	return sum.Div(decimal.NewFromInt(int64(len(kept))))
+}
+
+// volumeWeightedMeanOf returns the mean of the observations' values weighted
+// by their weights.
+func volumeWeightedMeanOf(observations []observation) (decimal.Decimal, error) {
+	sum, totalWeight := decimal.Zero, decimal.Zero
+	for _, obs := range observations {
+		if obs.weight.IsNegative() {
+			return decimal.Decimal{}, fmt.Errorf("feed returned negative weight %s", obs.weight)
+		}
+		sum = sum.Add(obs.value.Mul(*obs.weight))
+		totalWeight = totalWeight.Add(*obs.weight)
+	}
+	if totalWeight.IsZero() {
+		return decimal.Decimal{}, errors.New("feeds' weights sum to zero")
+	}
+	return sum.Div(totalWeight), nil
+}
+
+// modeOf returns the value occurring most often, erroring if several values
+// occur equally often.
+func modeOf(values []decimal.Decimal) (decimal.Decimal, error) {
+	sorted := sortedDecimals(values)
+	var mode decimal.Decimal
+	best, tied := 0, false
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Equal(sorted[i]) {
+			j++
+		}
+		if count := j - i; count > best {
+			mode, best, tied = sorted[i], count, false
+		} else if count == best {
+			tied = true
+		}
+		i = j
+	}
+	if tied {
+		return decimal.Decimal{}, fmt.Errorf("no single mode, several values occur %d times", best)
+	}
+	return mode, nil
+}
@@ -2,19 +2,17 @@ package fluxmonitor
 
 import (
 	"chainlink/core/logger"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/guregu/null"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/shopspring/decimal"
-	"go.uber.org/multierr"
 )
 
 //go:generate mockery -name Fetcher -output ../../internal/mocks/ -case=underscore
@@ -30,6 +28,7 @@ type httpFetcher struct {
 	client      *http.Client
 	url         *url.URL
 	requestData string
+	bridgeName  string
 }
 
 func newHTTPFetcher(
@@ -38,7 +37,6 @@ func newHTTPFetcher(
 	url *url.URL,
 ) Fetcher {
 	client := &http.Client{Timeout: timeout, Transport: http.DefaultTransport}
-	client.Transport = promhttp.InstrumentRoundTripperDuration(promFMResponseTime, client.Transport)
 	client.Transport = instrumentRoundTripperReponseSize(promFMResponseSize, client.Transport)
 
 	return &httpFetcher{
@@ -49,44 +47,59 @@ func newHTTPFetcher(
 }
 
 func (p *httpFetcher) Fetch() (decimal.Decimal, error) {
-	r, err := p.client.Post(p.url.String(), "application/json", strings.NewReader(p.requestData))
+	obs, err := p.observe(context.Background())
+	return obs.value, err
+}
+
+// observe fetches the price, along with the weight the adapter gives it, if
+// any, giving up once the context is done. Errors name the feed only by its
+// host, as they are reported with the feed's results.
+func (p *httpFetcher) observe(ctx context.Context) (observation, error) {
+	req, err := http.NewRequest(http.MethodPost, p.url.String(), strings.NewReader(p.requestData))
+	if err != nil {
+		return observation{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s", p.url.Host))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	r, err := p.client.Do(req.WithContext(ctx))
 	if err != nil {
-		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s with payload '%s'", p.url.String(), p.requestData))
+		return observation{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s with payload '%s'", p.url.Host, p.requestData))
 	}
 
 	defer r.Body.Close()
 	target := adapterResponse{}
 	if err = json.NewDecoder(r.Body).Decode(&target); err != nil {
-		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to decode price from %s", p.url.String()))
+		return observation{}, errors.Wrap(err, fmt.Sprintf("unable to decode price from %s", p.url.Host))
 	}
 	if target.ErrorMessage.Valid {
-		return decimal.Decimal{}, errors.Wrap(errors.New(target.ErrorMessage.String), fmt.Sprintf("price fetcher %s returned error", p.url.String()))
+		return observation{}, errors.Wrap(errors.New(target.ErrorMessage.String), fmt.Sprintf("price fetcher %s returned error", p.url.Host))
 	}
 	if r.StatusCode >= 400 {
-		return decimal.Decimal{}, fmt.Errorf("status code: %d, no error message; unable to retrieve price from %s", r.StatusCode, p.url.String())
+		return observation{}, fmt.Errorf("status code: %d, no error message; unable to retrieve price from %s", r.StatusCode, p.url.Host)
 	}
 
 	result := target.Result()
 	if result == nil {
-		return decimal.Decimal{}, errors.Wrap(errors.New("no result returned"), fmt.Sprintf("unable to fetch price from %s", p.url.String()))
+		return observation{}, errors.Wrap(errors.New("no result returned"), fmt.Sprintf("unable to fetch price from %s", p.url.Host))
 	}
 
 	resultFloat, _ := result.Float64()
 	promFMIndividualReportedValue.WithLabelValues(p.url.String()).Set(resultFloat)
 	logger.Debugw(
-		fmt.Sprintf("fetched price %v from %s", *result, p.url.String()),
+		fmt.Sprintf("fetched price %v from %s", *result, p.url.Host),
 		"price", result,
-		"url", p.url.String(),
+		"host", p.url.Host,
 	)
-	return *result, nil
+	return observation{value: *result, weight: target.Data.Weight}, nil
 }
 
 func (p *httpFetcher) String() string {
-	return fmt.Sprintf("http price fetcher: %s", p.url.String())
+	return fmt.Sprintf("http price fetcher: %s", p.url.Host)
 }
 
 type adapterResponseData struct {
 	Result *decimal.Decimal `json:"result"`
+	Weight *decimal.Decimal `json:"weight"`
 }
 
 // adapterResponse is the HTTP response as defined by the external adapter:
@@ -99,75 +112,3 @@ type adapterResponse struct {
 func (pr adapterResponse) Result() *decimal.Decimal {
 	return pr.Data.Result
 }
-
-// medianFetcher fetches from all fetchers, and returns the median value, or
-// average if even number of results.
-type medianFetcher struct {
-	fetchers []Fetcher
-}
-
-// newMedianFetcherFromURLs creates a median fetcher that retrieves a price
-// from all passed URLs using httpFetcher, and returns the median.
-func newMedianFetcherFromURLs(
-	timeout time.Duration,
-	requestData string,
-	priceURLs []*url.URL,
-) (Fetcher, error) {
-	fetchers := []Fetcher{}
-	for _, url := range priceURLs {
-		ps := newHTTPFetcher(timeout, requestData, url)
-		fetchers = append(fetchers, ps)
-	}
-
-	medianFetcher, err := newMedianFetcher(fetchers...)
-	if err != nil {
-		return nil, err
-	}
-
-	return medianFetcher, nil
-}
-
-func newMedianFetcher(fetchers ...Fetcher) (Fetcher, error) {
-	if len(fetchers) == 0 {
-		return nil, errors.New("must pass in at least one price fetcher to newMedianFetcher")
-	}
-	return &medianFetcher{
-		fetchers: fetchers,
-	}, nil
-}
-
-func (m *medianFetcher) Fetch() (decimal.Decimal, error) {
-	prices := []decimal.Decimal{}
-	fetchErrors := []error{}
-	for _, fetcher := range m.fetchers {
-		price, err := fetcher.Fetch()
-		if err != nil {
-			logger.Error(err)
-			fetchErrors = append(fetchErrors, err)
-		} else {
-			prices = append(prices, price)
-		}
-	}
-
-	errorRate := float64(len(fetchErrors)) / float64(len(m.fetchers))
-	if errorRate >= 0.5 {
-		return decimal.Decimal{}, errors.Wrap(multierr.Combine(fetchErrors...), "majority of fetchers in median failed")
-	}
-
-	sort.Slice(prices, func(i, j int) bool {
-		return prices[i].LessThan(prices[j])
-	})
-	k := len(prices) / 2
-	if len(prices)%2 == 1 {
-		return prices[k], nil
-	}
-	return prices[k].Add(prices[k-1]).Div(decimal.NewFromInt(2)), nil
-}
-
-func (m *medianFetcher) String() string {
-	fetcherDescriptions := make([]string, len(m.fetchers))
-	for i, fetcher := range m.fetchers {
-		fetcherDescriptions[i] = fmt.Sprintf("%s", fetcher)
-	}
-	return fmt.Sprintf("median fetcher: %s", strings.Join(fetcherDescriptions, ","))
-}
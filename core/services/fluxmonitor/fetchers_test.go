@@ -18,7 +18,7 @@ import (
 // https://github.com/smartcontractkit/price-adapters
 const ethUSDPairing = `{"data":{"coin":"ETH","market":"USD"}}`
 
-func TestNewMedianFetcherFromURLs_Happy(t *testing.T) {
+func TestNewAggregateFetcherFromURLs_Happy(t *testing.T) {
 	tests := []struct {
 		name   string
 		prices []decimal.Decimal
@@ -63,7 +63,7 @@ func TestNewMedianFetcherFromURLs_Happy(t *testing.T) {
 				urls = append(urls, newURL)
 			}
 
-			medianFetcher, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls)
+			medianFetcher, err := newAggregateFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, nil, aggregateOptions{})
 			require.NoError(t, err)
 
 			medianPrice, err := medianFetcher.Fetch()
@@ -73,12 +73,12 @@ func TestNewMedianFetcherFromURLs_Happy(t *testing.T) {
 	}
 }
 
-func TestNewMedianFetcherFromURLs_EmptyError(t *testing.T) {
+func TestNewAggregateFetcherFromURLs_EmptyError(t *testing.T) {
 	s1 := httptest.NewServer(fakePriceResponder(t, ethUSDPairing, decimal.NewFromInt(101)))
 	defer s1.Close()
 	var urls []*url.URL
 
-	_, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls)
+	_, err := newAggregateFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, nil, aggregateOptions{})
 	require.Error(t, err)
 }
 
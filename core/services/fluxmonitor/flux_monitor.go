@@ -16,17 +16,20 @@ import (
 	"chainlink/core/store/orm"
 	"chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	null "gopkg.in/guregu/null.v3"
 )
 
 //go:generate mockery -name Service -output ../../internal/mocks/ -case=underscore
 //go:generate mockery -name DeviationCheckerFactory -output ../../internal/mocks/ -case=underscore
 //go:generate mockery -name DeviationChecker -output ../../internal/mocks/ -case=underscore
 
-// defaultHTTPTimeout is the timeout used by the price adapter fetcher for outgoing HTTP requests.
+// defaultHTTPTimeout is the timeout used by the price adapter fetcher for outgoing HTTP requests,
+// and the deadline all of a flux monitor's feeds share to answer a poll.
 const defaultHTTPTimeout = 5 * time.Second
 
 // MinimumPollingInterval is the smallest possible polling interval the Flux
@@ -44,11 +47,15 @@ type RunManager interface {
 
 // Service is the interface encapsulating all functionality
 // needed to listen to price deviations and new round requests.
+// It is a HeadTrackable, passing new heads on to its DeviationCheckers.
 type Service interface {
 	AddJob(models.JobSpec) error
 	RemoveJob(*models.ID)
 	Start() error
 	Stop()
+	Connect(*models.Head) error
+	Disconnect()
+	OnNewHead(*models.Head)
 }
 
 type concreteFluxMonitor struct {
@@ -60,6 +67,7 @@ type concreteFluxMonitor struct {
 	chRemove       chan models.ID
 	chConnect      chan *models.Head
 	chDisconnect   chan struct{}
+	chHead         chan models.Head
 	chStop         chan struct{}
 	chDone         chan struct{}
 }
@@ -88,6 +96,7 @@ func New(
 		chRemove:     make(chan models.ID),
 		chConnect:    make(chan *models.Head),
 		chDisconnect: make(chan struct{}),
+		chHead:       make(chan models.Head, 1),
 		chStop:       make(chan struct{}),
 		chDone:       make(chan struct{}),
 	}
@@ -106,6 +115,9 @@ func (fm *concreteFluxMonitor) Start() error {
 			return true
 		}
 		job := *j
+		if job.Paused() || job.Proposed() {
+			return true
+		}
 
 		wg.Add(1)
 		go func() {
@@ -140,14 +152,17 @@ func (fm *concreteFluxMonitor) processAddRemoveJobRequests() {
 	for {
 		select {
 		case entry := <-fm.chAdd:
-			if _, ok := jobMap[entry.jobID]; ok {
-				logger.Errorf("job %s has already been added to flux monitor", entry.jobID)
-				return
-			}
 			for _, checker := range entry.checkers {
 				checker.Start()
 			}
-			jobMap[entry.jobID] = entry.checkers
+			for _, checker := range jobMap[entry.jobID] {
+				checker.Stop()
+			}
+			if len(entry.checkers) > 0 {
+				jobMap[entry.jobID] = entry.checkers
+			} else {
+				delete(jobMap, entry.jobID)
+			}
 
 		case jobID := <-fm.chRemove:
 			for _, checker := range jobMap[jobID] {
@@ -155,6 +170,13 @@ func (fm *concreteFluxMonitor) processAddRemoveJobRequests() {
 			}
 			delete(jobMap, jobID)
 
+		case head := <-fm.chHead:
+			for _, checkers := range jobMap {
+				for _, checker := range checkers {
+					checker.OnNewHead(&head)
+				}
+			}
+
 		case <-fm.chStop:
 			for _, checkers := range jobMap {
 				for _, checker := range checkers {
@@ -167,7 +189,8 @@ func (fm *concreteFluxMonitor) processAddRemoveJobRequests() {
 }
 
 // AddJob created a DeviationChecker for any job initiators of type
-// InitiatorFluxMonitor.
+// InitiatorFluxMonitor. The checkers of a version the job was updated from are
+// stopped once the new version's have started.
 func (fm *concreteFluxMonitor) AddJob(job models.JobSpec) error {
 	if job.ID == nil {
 		err := errors.New("received job with nil ID")
@@ -187,9 +210,6 @@ func (fm *concreteFluxMonitor) AddJob(job models.JobSpec) error {
 		}
 		validCheckers = append(validCheckers, checker)
 	}
-	if len(validCheckers) == 0 {
-		return nil
-	}
 
 	fm.chAdd <- addEntry{*job.ID, validCheckers}
 	return nil
@@ -205,6 +225,32 @@ func (fm *concreteFluxMonitor) RemoveJob(id *models.ID) {
 	fm.chRemove <- *id
 }
 
+// Connect does nothing, as the checkers connect through the log broadcaster.
+func (fm *concreteFluxMonitor) Connect(*models.Head) error {
+	return nil
+}
+
+// Disconnect does nothing, as the checkers connect through the log
+// broadcaster.
+func (fm *concreteFluxMonitor) Disconnect() {}
+
+// OnNewHead passes the head on to every checker, for those with an idle
+// threshold in blocks. It never blocks the head tracker: a head not yet passed
+// on is replaced by the newer one.
+func (fm *concreteFluxMonitor) OnNewHead(head *models.Head) {
+	for {
+		select {
+		case fm.chHead <- *head:
+			return
+		default:
+		}
+		select {
+		case <-fm.chHead:
+		default:
+		}
+	}
+}
+
 // DeviationCheckerFactory holds the New method needed to create a new instance
 // of a DeviationChecker.
 type DeviationCheckerFactory interface {
@@ -225,11 +271,17 @@ func (f pollingDeviationCheckerFactory) New(initr models.Initiator, runManager R
 	if err != nil {
 		return nil, err
 	}
+	bridgeNames, err := extractFeedBridgeNames(initr.InitiatorParams.Feeds)
+	if err != nil {
+		return nil, err
+	}
 
-	fetcher, err := newMedianFetcherFromURLs(
+	fetcher, err := newAggregateFetcherFromURLs(
 		defaultHTTPTimeout,
 		initr.InitiatorParams.RequestData.String(),
-		urls)
+		urls,
+		bridgeNames,
+		aggregateOptionsFromInitiator(initr))
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +334,22 @@ func ExtractFeedURLs(feeds models.Feeds, orm *orm.ORM) ([]*url.URL, error) {
 	return urls, nil
 }
 
+// extractFeedBridgeNames returns the name of the bridge of each of the feeds,
+// or an empty name for those given by URL.
+func extractFeedBridgeNames(feeds models.Feeds) ([]string, error) {
+	var feedsData []interface{}
+	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(feedsData))
+	for i, entry := range feedsData {
+		if feed, ok := entry.(map[string]interface{}); ok {
+			names[i], _ = feed["bridge"].(string)
+		}
+	}
+	return names, nil
+}
+
 // GetBridgeURLFromName looks up a bridge in the DB by name, then extracts the url
 func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 	task := models.TaskType(name)
@@ -293,11 +361,18 @@ func GetBridgeURLFromName(name string, orm *orm.ORM) (*url.URL, error) {
 	return &bridgeURL, nil
 }
 
+// feedResultsReporter is implemented by fetchers that can tell how each of
+// their feeds answered the last fetch.
+type feedResultsReporter interface {
+	FeedResults() models.FluxMonitorFeedResults
+}
+
 // DeviationChecker encapsulate methods needed to initialize and check prices
 // for price deviations.
 type DeviationChecker interface {
 	Start()
 	Stop()
+	OnNewHead(*models.Head)
 }
 
 // PollingDeviationChecker polls external price adapters via HTTP to check for price swings.
@@ -307,11 +382,13 @@ type PollingDeviationChecker struct {
 	runManager     RunManager
 	fetcher        Fetcher
 
-	initr         models.Initiator
-	requestData   models.JSON
-	threshold     float64
-	precision     int32
-	idleThreshold time.Duration
+	initr               models.Initiator
+	requestData         models.JSON
+	thresholds          DeviationThresholds
+	gasPolicy           gasPolicy
+	precision           int32
+	idleThreshold       time.Duration
+	idleThresholdBlocks int64
 
 	connected                  utils.AtomicBool
 	chMaybeLogs                chan maybeLog
@@ -319,6 +396,15 @@ type PollingDeviationChecker struct {
 	mostRecentSubmittedRoundID uint64
 	pollTicker                 *ResettableTicker
 	idleTicker                 <-chan time.Time
+	idleBlocksFrom             *int64
+
+	latestHead      *models.Head
+	latestHeadMutex sync.Mutex
+	chNewHead       chan struct{}
+
+	// state is what the checker last did, saved after each poll so that it
+	// is kept across restarts and can be shown to operators.
+	state models.FluxMonitorState
 
 	chStop     chan struct{}
 	waitOnStop chan struct{}
@@ -347,15 +433,22 @@ func NewPollingDeviationChecker(
 		initr:          initr,
 		requestData:    initr.InitiatorParams.RequestData,
 		idleThreshold:  initr.InitiatorParams.IdleThreshold.Duration(),
-		threshold:      float64(initr.InitiatorParams.Threshold),
-		precision:      initr.InitiatorParams.Precision,
-		runManager:     runManager,
-		fetcher:        fetcher,
-		pollTicker:     NewResettableTicker(pollDelay),
-		idleTicker:     nil,
-		chMaybeLogs:    make(chan maybeLog, 100),
-		chStop:         make(chan struct{}),
-		waitOnStop:     make(chan struct{}),
+		thresholds: DeviationThresholds{
+			Rel:  float64(initr.InitiatorParams.Threshold),
+			Abs:  float64(initr.InitiatorParams.AbsoluteThreshold),
+			Both: initr.InitiatorParams.ThresholdMode == ThresholdModeBoth,
+		},
+		gasPolicy:           gasPolicyFromInitiator(initr),
+		idleThresholdBlocks: int64(initr.InitiatorParams.IdleThresholdBlocks),
+		precision:           initr.InitiatorParams.Precision,
+		runManager:          runManager,
+		fetcher:             fetcher,
+		pollTicker:          NewResettableTicker(pollDelay),
+		idleTicker:          nil,
+		chMaybeLogs:         make(chan maybeLog, 100),
+		chNewHead:           make(chan struct{}, 1),
+		chStop:              make(chan struct{}),
+		waitOnStop:          make(chan struct{}),
 	}, nil
 }
 
@@ -417,6 +510,24 @@ func (t *ResettableTicker) Reset() {
 	t.Ticker = time.NewTicker(t.d)
 }
 
+// OnNewHead records the head as the latest, to be counted towards the idle
+// threshold in blocks. It never blocks: a head arriving while the last is
+// still unhandled replaces it.
+func (p *PollingDeviationChecker) OnNewHead(head *models.Head) {
+	if p.idleThresholdBlocks == 0 {
+		return
+	}
+
+	p.latestHeadMutex.Lock()
+	p.latestHead = head
+	p.latestHeadMutex.Unlock()
+
+	select {
+	case p.chNewHead <- struct{}{}:
+	default:
+	}
+}
+
 func (p *PollingDeviationChecker) HandleLog(log interface{}, err error) {
 	select {
 	case p.chMaybeLogs <- maybeLog{log, err}:
@@ -427,6 +538,7 @@ func (p *PollingDeviationChecker) HandleLog(log interface{}, err error) {
 func (p *PollingDeviationChecker) consume() {
 	defer close(p.waitOnStop)
 
+	p.loadState()
 	p.determineMostRecentSubmittedRoundID()
 
 	connected, unsubscribeLogs := p.fluxAggregator.SubscribeToLogs(p)
@@ -435,7 +547,7 @@ func (p *PollingDeviationChecker) consume() {
 	p.connected.Set(connected)
 
 	// Try to do an initial poll
-	p.pollIfEligible(p.threshold)
+	p.pollIfEligible(p.thresholds)
 	p.pollTicker.Reset()
 	defer p.pollTicker.Stop()
 
@@ -457,16 +569,43 @@ Loop:
 			p.respondToLog(maybeLog.Log)
 
 		case <-p.pollTicker.Tick():
-			p.pollIfEligible(p.threshold)
+			p.pollIfEligible(p.thresholds)
 
 		case <-p.idleTicker:
-			p.pollIfEligible(0)
+			p.pollIfEligible(DeviationThresholds{})
+
+		case <-p.chNewHead:
+			p.latestHeadMutex.Lock()
+			head := p.latestHead
+			p.latestHeadMutex.Unlock()
+			p.respondToNewHead(head)
 		}
 	}
 }
 
+// loadState picks up what the checker last did before it was stopped. The
+// last round it submitted to is not taken from it, as its job run may have
+// failed before sending a transaction; that is determined from the
+// transactions sent instead.
+func (p *PollingDeviationChecker) loadState() {
+	state, err := p.store.ORM.FindFluxMonitorState(p.initr.ID)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		logger.Errorw(fmt.Sprintf("error loading flux monitor state: %v", err), "jobID", p.initr.JobSpecID)
+	}
+	state.InitiatorID = p.initr.ID
+	state.JobSpecID = p.initr.JobSpecID
+	p.state = state
+}
+
+// saveState saves what the checker last did.
+func (p *PollingDeviationChecker) saveState() {
+	if err := p.store.ORM.SaveFluxMonitorState(&p.state); err != nil {
+		logger.Errorw(fmt.Sprintf("error saving flux monitor state: %v", err), "jobID", p.initr.JobSpecID)
+	}
+}
+
 func (p *PollingDeviationChecker) determineMostRecentSubmittedRoundID() {
-	myAccount, err := p.store.KeyStore.GetFirstAccount()
+	oracle, err := p.oracleAddress()
 	if err != nil {
 		logger.Error("error determining most recent submitted round ID: ", err)
 		return
@@ -474,9 +613,9 @@ func (p *PollingDeviationChecker) determineMostRecentSubmittedRoundID() {
 
 	// Just to be particularly defensive against issues with the DB or TxManager, we
 	// fetch the most recent 5 transactions we've submitted to this aggregator from our
-	// Chainlink node address.  Take the highest round ID among them and store it so
+	// oracle address.  Take the highest round ID among them and store it so
 	// that we avoid re-polling for a given round when our tx takes a while to confirm.
-	txs, err := p.store.ORM.FindTxsBySenderAndRecipient(myAccount.Address, p.initr.InitiatorParams.Address, 0, 5)
+	txs, err := p.store.ORM.FindTxsBySenderAndRecipient(oracle, p.initr.InitiatorParams.Address, 0, 5)
 	if err != nil && !gorm.IsRecordNotFoundError(err) {
 		logger.Error("error determining most recent submitted round ID: ", err)
 		return
@@ -496,6 +635,14 @@ func (p *PollingDeviationChecker) determineMostRecentSubmittedRoundID() {
 		if roundID > p.mostRecentSubmittedRoundID {
 			p.mostRecentSubmittedRoundID = roundID
 		}
+		if roundID > p.state.SubmittedRoundID {
+			p.state.SubmittedRoundID = roundID
+			p.state.SubmissionRunID = nil
+			if runID, err := models.NewIDFromString(tx.SurrogateID.ValueOrZero()); err == nil {
+				p.state.SubmissionRunID = runID
+			}
+			p.state.SubmissionTxHash = null.StringFrom(tx.Hash.Hex())
+		}
 	}
 	logger.Infow(fmt.Sprintf("roundID of most recent submission is %v", p.mostRecentSubmittedRoundID),
 		"jobID", p.initr.JobSpecID,
@@ -545,13 +692,14 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log *contracts.LogNewRoun
 	if p.idleThreshold > 0 {
 		p.idleTicker = time.After(p.idleThreshold)
 	}
+	p.idleBlocksFrom = nil
 
 	// Ignore rounds we started
-	acct, err := p.store.KeyStore.GetFirstAccount()
+	oracle, err := p.oracleAddress()
 	if err != nil {
 		logger.Errorw(fmt.Sprintf("error fetching account from keystore: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
-	} else if log.StartedBy == acct.Address {
+	} else if log.StartedBy == oracle {
 		return
 	}
 
@@ -569,56 +717,111 @@ func (p *PollingDeviationChecker) respondToNewRoundLog(log *contracts.LogNewRoun
 	p.reportableRoundID = big.NewInt(int64(roundState.ReportableRoundID))
 
 	if !roundState.EligibleToSubmit {
-		logger.Infow("Ignoring new round request: not eligible to submit", p.loggerFieldsForNewRound(log)...)
+		if p.checkOracleStatus() {
+			logger.Infow("Ignoring new round request: not eligible to submit", p.loggerFieldsForNewRound(log)...)
+		} else {
+			p.saveState()
+		}
 		return
 	}
+	p.restoreOracleStatus()
 
 	logger.Infow("Responding to new round request: new > current", p.loggerFieldsForNewRound(log)...)
 
-	polledAnswer, err := p.fetcher.Fetch()
+	p.startPoll()
+	defer p.saveState()
+	p.recordRoundState(roundState)
+	p.state.Eligible = true
+
+	polledAnswer, err := p.fetch()
 	if err != nil {
+		p.state.Reason = fmt.Sprintf("unable to fetch answer: %v", err)
 		logger.Errorw(fmt.Sprintf("unable to fetch median price: %v", err), p.loggerFieldsForNewRound(log)...)
 		return
 	}
+	p.recordDeviation(polledAnswer)
+
+	if err := p.createJobRun(polledAnswer, p.reportableRoundID); err != nil {
+		p.state.Reason = fmt.Sprintf("unable to create job run: %v", err)
+		return
+	}
+	p.state.Reason = "responded to new round request"
+}
+
+// respondToNewHead polls regardless of the deviation once idleThresholdBlocks
+// heads have passed since the head the current round started at, or since
+// the last such poll. The count starts over from a head behind the one it
+// is counted from, as after a reorg.
+//
+// Only invoked by the CSP consumer on the single goroutine for thread safety.
+func (p *PollingDeviationChecker) respondToNewHead(head *models.Head) {
+	if p.idleBlocksFrom == nil || head.Number < *p.idleBlocksFrom {
+		from := head.Number
+		p.idleBlocksFrom = &from
+		return
+	}
+	if head.Number-*p.idleBlocksFrom < p.idleThresholdBlocks {
+		return
+	}
 
-	p.createJobRun(polledAnswer, p.reportableRoundID)
+	logger.Infow("idleThresholdBlocks reached, polling regardless of deviation",
+		"jobID", p.initr.JobSpecID,
+		"head", head.Number,
+		"idleThresholdBlocks", p.idleThresholdBlocks,
+	)
+	from := head.Number
+	p.idleBlocksFrom = &from
+	p.pollIfEligible(DeviationThresholds{})
 }
 
 // poll walks through the steps to check for a deviation, early exiting if deviation
 // is not met, or triggering a new job run if deviation is met.
 // Only invoked by the CSP consumer on the single goroutine for thread safety.
-func (p *PollingDeviationChecker) pollIfEligible(threshold float64) (createdJobRun bool) {
+func (p *PollingDeviationChecker) pollIfEligible(thresholds DeviationThresholds) (createdJobRun bool) {
+	p.startPoll()
+	defer p.saveState()
+
 	if p.connected.Get() == false {
+		p.state.Reason = "not connected to Ethereum node"
 		logger.Warn("not connected to Ethereum node, skipping poll")
 		return false
 	}
 
 	roundState, err := p.roundState()
 	if err != nil {
+		p.state.Reason = fmt.Sprintf("unable to determine eligibility to submit: %v", err)
 		logger.Errorf("unable to determine eligibility to submit from FluxAggregator contract: %v", err)
 		return false
 	}
+	p.recordRoundState(roundState)
 
 	// It's pointless to listen to logs from before the current reporting round
 	p.reportableRoundID = big.NewInt(int64(roundState.ReportableRoundID))
 
 	// If we've already submitted an answer for this round, but the tx is still pending, don't resubmit
 	if p.mostRecentSubmittedRoundID >= uint64(roundState.ReportableRoundID) {
-		logger.Infow(fmt.Sprintf("already submitted for round %v, tx is still pending", roundState.ReportableRoundID),
+		p.state.Reason = fmt.Sprintf("already submitted for round %v, tx is still pending", roundState.ReportableRoundID)
+		logger.Infow(p.state.Reason,
 			"jobID", p.initr.JobSpecID,
 		)
 		return false
 	}
 
 	if !roundState.EligibleToSubmit {
-		logger.Infow("not eligible to submit, skipping poll",
-			"jobID", p.initr.JobSpecID,
-		)
+		if p.checkOracleStatus() {
+			p.state.Reason = "not eligible to submit"
+			logger.Infow("not eligible to submit, skipping poll",
+				"jobID", p.initr.JobSpecID,
+			)
+		}
 		return false
 	}
+	p.restoreOracleStatus()
+	p.state.Eligible = true
 
-	polledAnswer, err := p.fetcher.Fetch()
+	polledAnswer, err := p.fetch()
 	if err != nil {
+		p.state.Reason = fmt.Sprintf("unable to fetch answer: %v", err)
 		logger.Errorf("can't fetch answer: %v", err)
 		return false
 	}
@@ -627,15 +830,32 @@ func (p *PollingDeviationChecker) pollIfEligible(threshold float64) (createdJobR
 	promSetDecimal(promFMSeenValue.WithLabelValues(jobSpecID), polledAnswer)
 
 	latestAnswer := decimal.NewFromBigInt(roundState.LatestAnswer, -p.precision)
-	if !OutsideDeviation(latestAnswer, polledAnswer, threshold) {
+	p.recordDeviation(polledAnswer)
+	if !OutsideDeviation(latestAnswer, polledAnswer, thresholds) {
+		p.state.Reason = "deviation below threshold"
 		logger.Debugw("deviation < threshold, not submitting",
 			"latestAnswer", latestAnswer,
 			"polledAnswer", polledAnswer,
-			"threshold", threshold,
+			"threshold", thresholds.Rel,
+			"absoluteThreshold", thresholds.Abs,
 		)
 		return false
 	}
 
+	// Heartbeats are polled without thresholds, and submitted whatever the gas price
+	if thresholds != (DeviationThresholds{}) {
+		gasPrice := p.store.Config.EthGasPriceDefault()
+		if ok, reason := p.gasPolicy.allows(latestAnswer, polledAnswer, gasPrice); !ok {
+			p.state.Reason = reason
+			logger.Infow("not submitting at current gas price",
+				"jobID", p.initr.JobSpecID,
+				"gasPrice", gasPrice,
+				"reason", reason,
+			)
+			return false
+		}
+	}
+
 	logger.Infow("deviation > threshold, starting new round",
 		"reportableRound", roundState.ReportableRoundID,
 		"address", p.initr.Address.Hex(),
@@ -643,21 +863,162 @@ func (p *PollingDeviationChecker) pollIfEligible(threshold float64) (createdJobR
 	)
 	err = p.createJobRun(polledAnswer, p.reportableRoundID)
 	if err != nil {
+		p.state.Reason = fmt.Sprintf("unable to create job run: %v", err)
 		logger.Errorf("can't create job run: %v", err)
 		return false
 	}
+	p.state.Reason = "deviation met threshold, submitted"
 
 	promSetDecimal(promFMReportedValue.WithLabelValues(jobSpecID), polledAnswer)
 	promSetBigInt(promFMReportedRound.WithLabelValues(jobSpecID), p.reportableRoundID)
 	return true
 }
 
+// startPoll clears what the state says of the last poll, keeping what it says
+// of the last submission.
+func (p *PollingDeviationChecker) startPoll() {
+	p.state.PolledAt = null.TimeFrom(time.Now())
+	p.state.ReportableRoundID = 0
+	p.state.Eligible = false
+	p.state.Reason = ""
+	p.state.PolledAnswer = decimal.NullDecimal{}
+	p.state.LatestAnswer = decimal.NullDecimal{}
+	p.state.Deviation = decimal.NullDecimal{}
+	p.state.AbsoluteDeviation = decimal.NullDecimal{}
+	p.state.Feeds = nil
+	p.refreshSubmissionTxHash()
+}
+
+// recordRoundState records the round the poll is for and the answer on chain.
+func (p *PollingDeviationChecker) recordRoundState(roundState contracts.FluxAggregatorRoundState) {
+	p.state.ReportableRoundID = roundState.ReportableRoundID
+	if roundState.LatestAnswer != nil {
+		latestAnswer := decimal.NewFromBigInt(roundState.LatestAnswer, -p.precision)
+		p.state.LatestAnswer = decimal.NullDecimal{Decimal: latestAnswer, Valid: true}
+	}
+}
+
+// fetch fetches the polled answer, recording how each feed answered.
+func (p *PollingDeviationChecker) fetch() (decimal.Decimal, error) {
+	polledAnswer, err := p.fetcher.Fetch()
+	if reporter, ok := p.fetcher.(feedResultsReporter); ok {
+		p.state.Feeds = reporter.FeedResults()
+	}
+	return polledAnswer, err
+}
+
+// recordDeviation records the polled answer, and how far it is from the
+// answer on chain, in percent and absolutely.
+func (p *PollingDeviationChecker) recordDeviation(polledAnswer decimal.Decimal) {
+	p.state.PolledAnswer = decimal.NullDecimal{Decimal: polledAnswer, Valid: true}
+	if !p.state.LatestAnswer.Valid {
+		return
+	}
+	latestAnswer := p.state.LatestAnswer.Decimal
+	diff := latestAnswer.Sub(polledAnswer).Abs()
+	p.state.AbsoluteDeviation = decimal.NullDecimal{Decimal: diff, Valid: true}
+	if !latestAnswer.IsZero() {
+		deviation := diff.Div(latestAnswer).Mul(decimal.NewFromInt(100))
+		p.state.Deviation = decimal.NullDecimal{Decimal: deviation, Valid: true}
+	}
+}
+
+// refreshSubmissionTxHash records the hash of the transaction sent by the job
+// run of the last submission, as job runs send their transactions after they
+// are created.
+func (p *PollingDeviationChecker) refreshSubmissionTxHash() {
+	if p.state.SubmissionRunID == nil {
+		return
+	}
+	tx, err := p.store.ORM.FindTxBySurrogateID(p.state.SubmissionRunID.String())
+	if err == nil {
+		p.state.SubmissionTxHash = null.StringFrom(tx.Hash.Hex())
+	}
+}
+
 func (p *PollingDeviationChecker) roundState() (contracts.FluxAggregatorRoundState, error) {
-	acct, err := p.store.KeyStore.GetFirstAccount()
+	oracle, err := p.oracleAddress()
 	if err != nil {
 		return contracts.FluxAggregatorRoundState{}, err
 	}
-	return p.fluxAggregator.RoundState(acct.Address)
+	return p.fluxAggregator.RoundState(oracle)
+}
+
+// oracleAddress returns the key the checker submits to the aggregator with:
+// the initiator's oracleAddress if given, and otherwise the node's first key.
+// The run executor sends the transactions of the checker's runs from the same
+// key, found from the run's initiator.
+func (p *PollingDeviationChecker) oracleAddress() (common.Address, error) {
+	if p.initr.InitiatorParams.OracleAddress != nil {
+		return *p.initr.InitiatorParams.OracleAddress, nil
+	}
+	acct, err := p.store.KeyStore.GetFirstAccount()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return acct.Address, nil
+}
+
+// checkOracleStatus asks the aggregator why the checker is not eligible to
+// submit, and returns whether it may still submit to it. If its key has been
+// removed from the aggregator's oracles, or the aggregator is paused, the
+// checker stops polling its feeds until it is eligible again.
+func (p *PollingDeviationChecker) checkOracleStatus() bool {
+	oracle, err := p.oracleAddress()
+	if err != nil {
+		return true
+	}
+	p.state.OracleAddress = &oracle
+
+	status, err := p.fluxAggregator.OracleStatus(oracle)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("unable to determine oracle status: %v", err), "jobID", p.initr.JobSpecID)
+		return true
+	}
+
+	var reason string
+	switch {
+	case !status.Permitted:
+		p.setOracleStatus(OracleStatusRemoved)
+		reason = fmt.Sprintf("oracle key %s was removed from the aggregator's oracles", oracle.Hex())
+	case status.Paused:
+		p.setOracleStatus(OracleStatusPaused)
+		reason = "aggregator is paused"
+	default:
+		p.setOracleStatus(OracleStatusPermitted)
+		return true
+	}
+	p.state.Reason = reason + ", not polling"
+	return false
+}
+
+// restoreOracleStatus records that the checker is eligible to submit again.
+func (p *PollingDeviationChecker) restoreOracleStatus() {
+	if oracle, err := p.oracleAddress(); err == nil {
+		p.state.OracleAddress = &oracle
+	}
+	p.setOracleStatus(OracleStatusPermitted)
+}
+
+// setOracleStatus records whether the checker may submit to the aggregator,
+// logging when that changes.
+func (p *PollingDeviationChecker) setOracleStatus(status string) {
+	previous := p.state.OracleStatus
+	p.state.OracleStatus = status
+	if previous == status {
+		return
+	}
+	fields := []interface{}{
+		"jobID", p.initr.JobSpecID,
+		"aggregator", p.initr.InitiatorParams.Address.Hex(),
+		"oracle", p.state.OracleAddress,
+		"oracleStatus", status,
+	}
+	if status != OracleStatusPermitted {
+		logger.Warnw("oracle can no longer submit to aggregator, stopped polling", fields...)
+	} else if previous != "" {
+		logger.Infow("oracle can submit to aggregator again, resumed polling", fields...)
+	}
 }
 
 // jobRunRequest is the request used to trigger a Job Run by the Flux Monitor.
@@ -694,12 +1055,18 @@ func (p *PollingDeviationChecker) createJobRun(polledAnswer decimal.Decimal, nex
 	}
 	runRequest := models.NewRunRequest(runData)
 
-	_, err = p.runManager.Create(p.initr.JobSpecID, &p.initr, nil, runRequest)
+	run, err := p.runManager.Create(p.initr.JobSpecID, &p.initr, nil, runRequest)
 	if err != nil {
 		return err
 	}
 
 	p.mostRecentSubmittedRoundID = nextRound.Uint64()
+	p.state.SubmittedRoundID = p.mostRecentSubmittedRoundID
+	p.state.SubmittedAt = null.TimeFrom(time.Now())
+	p.state.SubmissionTxHash = null.String{}
+	if run != nil {
+		p.state.SubmissionRunID = run.ID
+	}
 
 	return nil
 }
@@ -725,30 +1092,78 @@ func (p *PollingDeviationChecker) loggerFieldsForAnswerUpdated(log *contracts.Lo
 	}
 }
 
-// OutsideDeviation checks whether the next price is outside the threshold.
-func OutsideDeviation(curAnswer, nextAnswer decimal.Decimal, threshold float64) bool {
-	if curAnswer.IsZero() {
-		logger.Infow("Current price is 0, deviation automatically met", "answer", decimal.Zero)
-		return true
-	}
+const (
+	// ThresholdModeEither submits an answer deviating by either the relative
+	// threshold or the absolute threshold. It is used when an initiator does
+	// not choose a mode.
+	ThresholdModeEither = "either"
+	// ThresholdModeBoth submits an answer only once it deviates by both the
+	// relative threshold and the absolute threshold.
+	ThresholdModeBoth = "both"
+)
+
+const (
+	// OracleStatusPermitted is the status of an oracle key that may submit
+	// to its aggregator.
+	OracleStatusPermitted = "permitted"
+	// OracleStatusRemoved is the status of an oracle key that was removed
+	// from its aggregator's oracles.
+	OracleStatusRemoved = "removed"
+	// OracleStatusPaused is the status of an oracle key whose aggregator is
+	// paused, taking no answers from any oracle.
+	OracleStatusPaused = "paused"
+)
+
+// DeviationThresholds are how far a polled answer must deviate from the
+// current answer to be submitted. A threshold of 0 is not used, and an
+// answer always deviates from the current one when neither is used.
+type DeviationThresholds struct {
+	Rel  float64 // Relative deviation, in percent of the current answer
+	Abs  float64 // Absolute deviation
+	Both bool    // Whether both thresholds must be met, rather than either
+}
 
+// OutsideDeviation checks whether the next price is outside the thresholds.
+// The relative deviation from a current price of 0 cannot be measured, so it
+// is left to the absolute threshold, or met automatically without one.
+func OutsideDeviation(curAnswer, nextAnswer decimal.Decimal, thresholds DeviationThresholds) bool {
 	diff := curAnswer.Sub(nextAnswer).Abs()
-	percentage := diff.Div(curAnswer).Mul(decimal.NewFromInt(100))
-	if percentage.LessThan(decimal.NewFromFloat(threshold)) {
-		logger.Debugw(
-			"Deviation threshold not met",
-			"difference", percentage,
-			"threshold", threshold,
-			"currentAnswer", curAnswer,
-			"nextAnswer", nextAnswer)
-		return false
-	}
-	logger.Infow(
-		"Deviation threshold met",
-		"difference", percentage,
-		"threshold", threshold,
+	fields := []interface{}{
+		"threshold", thresholds.Rel,
+		"absoluteThreshold", thresholds.Abs,
 		"currentAnswer", curAnswer,
 		"nextAnswer", nextAnswer,
-	)
+		"absoluteDifference", diff,
+	}
+
+	var met []bool
+	if thresholds.Abs > 0 {
+		met = append(met, !diff.LessThan(decimal.NewFromFloat(thresholds.Abs)))
+	}
+	if thresholds.Rel > 0 {
+		if !curAnswer.IsZero() {
+			percentage := diff.Div(curAnswer).Mul(decimal.NewFromInt(100))
+			fields = append(fields, "difference", percentage)
+			met = append(met, !percentage.LessThan(decimal.NewFromFloat(thresholds.Rel)))
+		} else if thresholds.Abs <= 0 {
+			logger.Infow("Current price is 0, deviation automatically met", "answer", decimal.Zero)
+			return true
+		}
+	}
+
+	outside := len(met) == 0 || thresholds.Both
+	for _, m := range met {
+		if thresholds.Both {
+			outside = outside && m
+		} else {
+			outside = outside || m
+		}
+	}
+
+	if !outside {
+		logger.Debugw("Deviation threshold not met", fields...)
+		return false
+	}
+	logger.Infow("Deviation threshold met", fields...)
 	return true
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
 )
 
 var (
@@ -108,6 +109,20 @@ func TestConcreteFluxMonitor_AddJobRemoveJob(t *testing.T) {
 	})
 }
 
+func TestConcreteFluxMonitor_OnNewHeadDoesNotBlock(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	fm := fluxmonitor.New(store, new(mocks.RunManager))
+
+	// Not yet started, so nothing takes the heads: all but the last are replaced
+	cltest.CallbackOrTimeout(t, "heads passed on", func() {
+		for i := int64(1); i <= 3; i++ {
+			fm.OnNewHead(cltest.Head(i))
+		}
+	})
+}
+
 func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 	tests := []struct {
 		name                      string
@@ -167,6 +182,10 @@ func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 				}
 				fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil).
 					Once()
+				if !test.eligible {
+					fluxAggregator.On("OracleStatus", nodeAddr).Return(contracts.FluxAggregatorOracleStatus{Permitted: true}, nil).
+						Once()
+				}
 			}
 
 			if test.expectedToPoll {
@@ -197,7 +216,7 @@ func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 				checker.OnConnect()
 			}
 
-			checker.ExportedPollIfEligible(test.threshold)
+			checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: test.threshold})
 
 			fluxAggregator.AssertExpectations(t)
 			fetcher.AssertExpectations(t)
@@ -206,6 +225,241 @@ func TestPollingDeviationChecker_PollIfEligible(t *testing.T) {
 	}
 }
 
+func TestPollingDeviationChecker_PersistsState(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	initr := job.Initiators[0]
+
+	const reportableRoundID = 2
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: reportableRoundID,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(100 * int64(math.Pow10(int(initr.InitiatorParams.Precision)))),
+	}
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+	fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(110), nil).Twice()
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once()
+	retriedRun := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&retriedRun, nil).Once()
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.ExportedLoadState()
+	checker.OnConnect()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err := store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, state.JobSpecID)
+	assert.True(t, state.PolledAt.Valid)
+	assert.Equal(t, "110", state.PolledAnswer.Decimal.String())
+	assert.Equal(t, "100", state.LatestAnswer.Decimal.String())
+	assert.Equal(t, "10", state.Deviation.Decimal.String())
+	assert.Equal(t, "10", state.AbsoluteDeviation.Decimal.String())
+	assert.True(t, state.Eligible)
+	assert.Equal(t, uint64(reportableRoundID), state.SubmittedRoundID)
+	assert.True(t, state.SubmittedAt.Valid)
+	assert.Equal(t, run.ID, state.SubmissionRunID)
+
+	// The run sent no transaction, so a restarted checker submits to the
+	// round again
+	checker, err = fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.ExportedLoadState()
+	checker.OnConnect()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err = store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, retriedRun.ID, state.SubmissionRunID)
+	assert.False(t, state.SubmissionTxHash.Valid)
+
+	tx := cltest.NewTx(nodeAddr, 1)
+	tx.To = initr.InitiatorParams.Address
+	tx.Data = utils.ConcatBytes(updateAnswerSelector, utils.EVMWordUint64(reportableRoundID), utils.EVMWordUint64(110))
+	tx.SurrogateID = null.StringFrom(retriedRun.ID.String())
+	tx, err = store.CreateTx(tx)
+	require.NoError(t, err)
+	otherTx := cltest.NewTx(nodeAddr, 2)
+	otherTx.To = initr.InitiatorParams.Address
+	otherTx.Nonce = 1
+	_, err = store.CreateTx(otherTx)
+	require.NoError(t, err)
+
+	// Once its run sent a transaction, a restarted checker knows it already
+	// submitted to the round, and reports the run's transaction
+	checker, err = fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.ExportedLoadState()
+	checker.OnConnect()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err = store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Contains(t, state.Reason, "already submitted for round 2")
+	assert.False(t, state.PolledAnswer.Valid)
+	assert.Equal(t, retriedRun.ID, state.SubmissionRunID)
+	assert.Equal(t, tx.Hash.Hex(), state.SubmissionTxHash.ValueOrZero())
+
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_GasPriceCeiling(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("ETH_GAS_PRICE_DEFAULT", "300000000000")
+
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.GasPriceCeiling = utils.NewBig(big.NewInt(200000000000))
+	initr.EmergencyThreshold = 20
+
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(100 * int64(math.Pow10(int(initr.InitiatorParams.Precision)))),
+	}
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+	fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(110), nil)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.OnConnect()
+
+	// A 10% deviation is past the threshold, but not the emergency threshold
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 1})
+	rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Heartbeats are submitted whatever the gas price
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{})
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_OracleStatus(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	initr := job.Initiators[0]
+
+	latestAnswer := big.NewInt(100 * int64(math.Pow10(int(initr.InitiatorParams.Precision))))
+	ineligible := contracts.FluxAggregatorRoundState{ReportableRoundID: 2, LatestAnswer: latestAnswer}
+	eligible := contracts.FluxAggregatorRoundState{ReportableRoundID: 2, EligibleToSubmit: true, LatestAnswer: latestAnswer}
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.ExportedLoadState()
+	checker.OnConnect()
+
+	// The oracle was removed: feeds are not polled
+	fluxAggregator.On("RoundState", nodeAddr).Return(ineligible, nil).Once()
+	fluxAggregator.On("OracleStatus", nodeAddr).Return(contracts.FluxAggregatorOracleStatus{}, nil).Once()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err := store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fluxmonitor.OracleStatusRemoved, state.OracleStatus)
+	assert.Equal(t, &nodeAddr, state.OracleAddress)
+	assert.Contains(t, state.Reason, "removed from the aggregator's oracles")
+
+	// The aggregator was paused
+	fluxAggregator.On("RoundState", nodeAddr).Return(ineligible, nil).Once()
+	fluxAggregator.On("OracleStatus", nodeAddr).Return(contracts.FluxAggregatorOracleStatus{Permitted: true, Paused: true}, nil).Once()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err = store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fluxmonitor.OracleStatusPaused, state.OracleStatus)
+	assert.Contains(t, state.Reason, "aggregator is paused")
+	fetcher.AssertNotCalled(t, "Fetch")
+
+	// Once the oracle is eligible again, polling resumes
+	fluxAggregator.On("RoundState", nodeAddr).Return(eligible, nil).Once()
+	fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(110), nil).Once()
+	run := cltest.NewJobRun(job)
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.Anything).Return(&run, nil).Once()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	state, err = store.FindFluxMonitorState(initr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fluxmonitor.OracleStatusPermitted, state.OracleStatus)
+	assert.True(t, state.Eligible)
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
+func TestPollingDeviationChecker_OracleAddress(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	ensureAccount(t, store)
+	oracleAddr := cltest.NewAddress()
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	initr := job.Initiators[0]
+	initr.ID = 1
+	initr.OracleAddress = &oracleAddr
+
+	roundState := contracts.FluxAggregatorRoundState{
+		ReportableRoundID: 2,
+		EligibleToSubmit:  true,
+		LatestAnswer:      big.NewInt(100 * int64(math.Pow10(int(initr.InitiatorParams.Precision)))),
+	}
+
+	rm := new(mocks.RunManager)
+	fetcher := new(mocks.Fetcher)
+	fluxAggregator := new(mocks.FluxAggregator)
+	fluxAggregator.On("RoundState", oracleAddr).Return(roundState, nil).Once()
+	fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil)
+	fetcher.On("Fetch").Return(decimal.NewFromInt(110), nil).Once()
+	run := cltest.NewJobRun(job)
+	// The run is sent from the key the initiator gives, not one it requests
+	rm.On("Create", job.ID, &initr, mock.Anything, mock.MatchedBy(func(runRequest *models.RunRequest) bool {
+		return !runRequest.RequestParams.Get("fromAddress").Exists()
+	})).Return(&run, nil).Once()
+
+	checker, err := fluxmonitor.NewPollingDeviationChecker(store, fluxAggregator, initr, rm, fetcher, time.Second)
+	require.NoError(t, err)
+	checker.OnConnect()
+	checker.ExportedPollIfEligible(fluxmonitor.DeviationThresholds{Rel: 5})
+
+	fluxAggregator.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	rm.AssertExpectations(t)
+}
+
 func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -300,6 +554,100 @@ func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 	}
 }
 
+func TestPollingDeviationChecker_TriggerIdleBlockThreshold(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	nodeAddr := ensureAccount(t, store)
+
+	tests := []struct {
+		name                string
+		idleThresholdBlocks uint32
+		expectedToSubmit    bool
+	}{
+		{"no idleThresholdBlocks", 0, false},
+		{"idleThresholdBlocks > 0", 3, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fetcher := new(mocks.Fetcher)
+			runManager := new(mocks.RunManager)
+			fluxAggregator := new(mocks.FluxAggregator)
+
+			job := cltest.NewJobWithFluxMonitorInitiator()
+			initr := job.Initiators[0]
+			initr.ID = 1
+			initr.PollingInterval = models.Duration(math.MaxInt64)
+			initr.IdleThresholdBlocks = test.idleThresholdBlocks
+			jobRun := cltest.NewJobRun(job)
+
+			const fetchedAnswer = 100
+			answerBigInt := big.NewInt(fetchedAnswer * int64(math.Pow10(int(initr.InitiatorParams.Precision))))
+
+			didSubscribe := make(chan struct{})
+			fluxAggregator.On("SubscribeToLogs", mock.Anything).Return(false, eth.UnsubscribeFunc(func() {}), nil).Run(func(mock.Arguments) {
+				close(didSubscribe)
+			})
+
+			jobRunCreated := make(chan struct{}, 1)
+			if test.expectedToSubmit {
+				roundState := contracts.FluxAggregatorRoundState{ReportableRoundID: 1, EligibleToSubmit: true, LatestAnswer: answerBigInt}
+				fetcher.On("Fetch").Return(decimal.NewFromInt(fetchedAnswer), nil).Once()
+				fluxAggregator.On("GetMethodID", "updateAnswer").Return(updateAnswerSelector, nil).Once()
+				// Heads passing the threshold again find the round submitted to
+				fluxAggregator.On("RoundState", nodeAddr).Return(roundState, nil)
+				runManager.On("Create", job.ID, mock.Anything, mock.Anything, mock.Anything).
+					Return(&jobRun, nil).
+					Run(func(args mock.Arguments) {
+						jobRunCreated <- struct{}{}
+					}).
+					Once()
+			}
+
+			deviationChecker, err := fluxmonitor.NewPollingDeviationChecker(
+				store,
+				fluxAggregator,
+				initr,
+				runManager,
+				fetcher,
+				time.Duration(math.MaxInt64),
+			)
+			require.NoError(t, err)
+
+			deviationChecker.Start()
+			<-didSubscribe
+			deviationChecker.OnConnect()
+
+			// The threshold is counted from the first head seen, so heads
+			// are sent until it is passed
+			var number int64
+			newHead := func() bool {
+				number++
+				deviationChecker.OnNewHead(cltest.Head(number))
+				return len(jobRunCreated) == 1
+			}
+			if test.expectedToSubmit {
+				require.Eventually(t, newHead, 3*time.Second, 10*time.Millisecond)
+			} else {
+				for i := 0; i < 10; i++ {
+					newHead()
+				}
+			}
+
+			deviationChecker.Stop()
+
+			if !test.expectedToSubmit {
+				require.Len(t, jobRunCreated, 0)
+			}
+
+			fetcher.AssertExpectations(t)
+			runManager.AssertExpectations(t)
+			fluxAggregator.AssertExpectations(t)
+		})
+	}
+}
+
 func TestPollingDeviationChecker_RespondToNewRound(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -501,6 +849,9 @@ func TestPollingDeviationChecker_RespondToNewRound(t *testing.T) {
 					LatestAnswer:      big.NewInt(test.latestAnswer * int64(math.Pow10(int(initr.InitiatorParams.Precision)))),
 					EligibleToSubmit:  test.eligible,
 				}, nil)
+				if !test.eligible {
+					fluxAggregator.On("OracleStatus", nodeAddr).Return(contracts.FluxAggregatorOracleStatus{Permitted: true}, nil)
+				}
 			}
 
 			if expectedToPoll {
@@ -558,11 +909,40 @@ func TestOutsideDeviation(t *testing.T) {
 		{"equal to deviation", decimal.NewFromInt(100), decimal.NewFromInt(102), 2, true},
 		{"outside deviation", decimal.NewFromInt(100), decimal.NewFromInt(103), 2, true},
 		{"outside deviation zero", decimal.NewFromInt(100), decimal.NewFromInt(0), 2, true},
+		{"no threshold", decimal.NewFromInt(100), decimal.NewFromInt(100), 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := fluxmonitor.OutsideDeviation(test.curPrice, test.nextPrice, fluxmonitor.DeviationThresholds{Rel: test.threshold})
+			assert.Equal(t, test.expectation, actual)
+		})
+	}
+}
+
+func TestOutsideDeviation_AbsoluteThreshold(t *testing.T) {
+	d := decimal.RequireFromString
+
+	tests := []struct {
+		name                string
+		curPrice, nextPrice decimal.Decimal
+		thresholds          fluxmonitor.DeviationThresholds
+		expectation         bool
+	}{
+		{"inside absolute", d("0.0010"), d("0.0015"), fluxmonitor.DeviationThresholds{Abs: 0.001}, false},
+		{"outside absolute", d("0.0010"), d("0.0021"), fluxmonitor.DeviationThresholds{Abs: 0.001}, true},
+		{"0 current price, inside absolute", d("0"), d("0.0005"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 0.001}, false},
+		{"0 current price, outside absolute", d("0"), d("0.0015"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 0.001}, true},
+		{"either, only relative met", d("0.0010"), d("0.0015"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 0.001}, true},
+		{"either, neither met", d("100"), d("101"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 5}, false},
+		{"both, only relative met", d("0.0010"), d("0.0015"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 0.001, Both: true}, false},
+		{"both, only absolute met", d("1000"), d("1010"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 5, Both: true}, false},
+		{"both, both met", d("100"), d("110"), fluxmonitor.DeviationThresholds{Rel: 2, Abs: 5, Both: true}, true},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actual := fluxmonitor.OutsideDeviation(test.curPrice, test.nextPrice, test.threshold)
+			actual := fluxmonitor.OutsideDeviation(test.curPrice, test.nextPrice, test.thresholds)
 			assert.Equal(t, test.expectation, actual)
 		})
 	}
@@ -0,0 +1,91 @@
+package fluxmonitor
+
+import (
+	"fmt"
+	"math/big"
+
+	"chainlink/core/store/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// gasPolicy holds back submissions that are not worth the gas price the node
+// submits at. On top of the initiator's thresholds, a polled answer must
+// deviate from the current answer by the minimum its gas price curve gives
+// for the gas price, and above the gas price ceiling by the emergency
+// threshold. Heartbeats, sent when the idle threshold is reached, and
+// answers to new rounds are submitted whatever the gas price.
+type gasPolicy struct {
+	curve              models.GasPriceThresholds
+	ceiling            *big.Int
+	emergencyThreshold float64
+}
+
+func gasPolicyFromInitiator(initr models.Initiator) gasPolicy {
+	policy := gasPolicy{
+		curve:              initr.InitiatorParams.GasThresholds,
+		emergencyThreshold: float64(initr.InitiatorParams.EmergencyThreshold),
+	}
+	if initr.InitiatorParams.GasPriceCeiling != nil {
+		policy.ceiling = initr.InitiatorParams.GasPriceCeiling.ToInt()
+	}
+	return policy
+}
+
+// allows returns whether the polled answer deviates from the current answer
+// by enough to be submitted at the gas price, and if not, why. The relative
+// deviation from a current answer of 0 cannot be measured, and is always
+// enough.
+func (g gasPolicy) allows(curAnswer, nextAnswer decimal.Decimal, gasPrice *big.Int) (bool, string) {
+	if len(g.curve) == 0 && g.ceiling == nil {
+		return true, ""
+	}
+	if curAnswer.IsZero() {
+		return true, ""
+	}
+	deviation, _ := curAnswer.Sub(nextAnswer).Abs().Div(curAnswer).Mul(decimal.NewFromInt(100)).Float64()
+
+	if g.ceiling != nil && gasPrice.Cmp(g.ceiling) > 0 {
+		if g.emergencyThreshold <= 0 {
+			return false, fmt.Sprintf("gas price %s is above ceiling %s", gasPrice, g.ceiling)
+		}
+		if deviation < g.emergencyThreshold {
+			return false, fmt.Sprintf("gas price %s is above ceiling %s and deviation is below emergency threshold %v%%",
+				gasPrice, g.ceiling, g.emergencyThreshold)
+		}
+		return true, ""
+	}
+
+	if min := g.thresholdAt(gasPrice); deviation < min {
+		return false, fmt.Sprintf("deviation is below %v%% required at gas price %s", min, gasPrice)
+	}
+	return true, ""
+}
+
+// thresholdAt returns the minimum deviation the curve gives for the gas
+// price, interpolating linearly between its points. Below its first point
+// and above its last, the curve is flat.
+func (g gasPolicy) thresholdAt(gasPrice *big.Int) float64 {
+	if len(g.curve) == 0 {
+		return 0
+	}
+	first, last := g.curve[0], g.curve[len(g.curve)-1]
+	if gasPrice.Cmp(first.GasPrice.ToInt()) <= 0 {
+		return float64(first.Threshold)
+	}
+	if gasPrice.Cmp(last.GasPrice.ToInt()) >= 0 {
+		return float64(last.Threshold)
+	}
+
+	for i := 1; i < len(g.curve); i++ {
+		lo, hi := g.curve[i-1], g.curve[i]
+		if gasPrice.Cmp(hi.GasPrice.ToInt()) > 0 {
+			continue
+		}
+		span := new(big.Float).SetInt(new(big.Int).Sub(hi.GasPrice.ToInt(), lo.GasPrice.ToInt()))
+		offset := new(big.Float).SetInt(new(big.Int).Sub(gasPrice, lo.GasPrice.ToInt()))
+		fraction, _ := new(big.Float).Quo(offset, span).Float64()
+		return float64(lo.Threshold) + fraction*float64(hi.Threshold-lo.Threshold)
+	}
+	return float64(last.Threshold)
+}
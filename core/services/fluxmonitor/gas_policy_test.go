@@ -0,0 +1,84 @@
+package fluxmonitor
+
+import (
+	"math/big"
+	"testing"
+
+	"chainlink/core/store/models"
+	"chainlink/core/utils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1000000000))
+}
+
+func TestGasPolicy_ThresholdAt(t *testing.T) {
+	policy := gasPolicy{curve: models.GasPriceThresholds{
+		{GasPrice: utils.NewBig(gwei(20)), Threshold: 0.5},
+		{GasPrice: utils.NewBig(gwei(100)), Threshold: 2.5},
+		{GasPrice: utils.NewBig(gwei(200)), Threshold: 5},
+	}}
+
+	tests := []struct {
+		name      string
+		gasPrice  *big.Int
+		threshold float64
+	}{
+		{"below the curve", gwei(1), 0.5},
+		{"at the first point", gwei(20), 0.5},
+		{"between points", gwei(60), 1.5},
+		{"at a middle point", gwei(100), 2.5},
+		{"between later points", gwei(150), 3.75},
+		{"above the curve", gwei(1000), 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.threshold, policy.thresholdAt(test.gasPrice), 1e-9)
+		})
+	}
+
+	assert.Equal(t, float64(0), gasPolicy{}.thresholdAt(gwei(1000)))
+}
+
+func TestGasPolicy_Allows(t *testing.T) {
+	curve := models.GasPriceThresholds{
+		{GasPrice: utils.NewBig(gwei(20)), Threshold: 1},
+		{GasPrice: utils.NewBig(gwei(100)), Threshold: 3},
+	}
+	ceiling := gwei(150)
+
+	tests := []struct {
+		name     string
+		policy   gasPolicy
+		next     int64
+		gasPrice *big.Int
+		allowed  bool
+	}{
+		{"no policy", gasPolicy{}, 1001, gwei(1000), true},
+		{"curve met at low gas", gasPolicy{curve: curve}, 1015, gwei(20), true},
+		{"curve not met at high gas", gasPolicy{curve: curve}, 1015, gwei(100), false},
+		{"curve met at high gas", gasPolicy{curve: curve}, 970, gwei(100), true},
+		{"below ceiling", gasPolicy{ceiling: ceiling}, 1001, gwei(150), true},
+		{"above ceiling without emergency", gasPolicy{ceiling: ceiling}, 2000, gwei(151), false},
+		{"above ceiling, below emergency", gasPolicy{ceiling: ceiling, emergencyThreshold: 10}, 1050, gwei(151), false},
+		{"above ceiling, past emergency", gasPolicy{ceiling: ceiling, emergencyThreshold: 10}, 1100, gwei(151), true},
+		{"above ceiling, past emergency, ignores curve", gasPolicy{curve: curve, ceiling: ceiling, emergencyThreshold: 2}, 1025, gwei(200), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, reason := test.policy.allows(decimal.NewFromInt(1000), decimal.NewFromInt(test.next), test.gasPrice)
+			assert.Equal(t, test.allowed, allowed)
+			if test.allowed {
+				assert.Empty(t, reason)
+			} else {
+				assert.NotEmpty(t, reason)
+			}
+		})
+	}
+
+	allowed, _ := gasPolicy{ceiling: ceiling}.allows(decimal.Zero, decimal.NewFromInt(1), gwei(1000))
+	assert.True(t, allowed, "deviation from 0 cannot be measured")
+}
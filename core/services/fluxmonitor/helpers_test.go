@@ -1,12 +1,14 @@
 package fluxmonitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -19,8 +21,13 @@ func ExportedSetCheckerFactory(fm Service, fac DeviationCheckerFactory) {
 	impl.checkerFactory = fac
 }
 
-func (p *PollingDeviationChecker) ExportedPollIfEligible(threshold float64) {
-	p.pollIfEligible(threshold)
+func (p *PollingDeviationChecker) ExportedPollIfEligible(thresholds DeviationThresholds) {
+	p.pollIfEligible(thresholds)
+}
+
+func (p *PollingDeviationChecker) ExportedLoadState() {
+	p.loadState()
+	p.determineMostRecentSubmittedRoundID()
 }
 
 func (p *PollingDeviationChecker) ExportedSetStoredReportableRoundID(roundID *big.Int) {
@@ -79,3 +86,33 @@ func dataWithResult(t *testing.T, result decimal.Decimal) adapterResponseData {
 	require.NoError(t, json.Unmarshal(body, &data))
 	return data
 }
+
+type weightedFetcher struct {
+	price, weight decimal.Decimal
+}
+
+func newWeightedFetcher(price, weight int64) *weightedFetcher {
+	return &weightedFetcher{price: decimal.NewFromInt(price), weight: decimal.NewFromInt(weight)}
+}
+
+func (wf *weightedFetcher) Fetch() (decimal.Decimal, error) {
+	return wf.price, nil
+}
+
+func (wf *weightedFetcher) observe(context.Context) (observation, error) {
+	return observation{value: wf.price, weight: &wf.weight}, nil
+}
+
+type slowFetcher struct {
+	price decimal.Decimal
+	delay time.Duration
+}
+
+func newSlowFetcher(price int64, delay time.Duration) *slowFetcher {
+	return &slowFetcher{price: decimal.NewFromInt(price), delay: delay}
+}
+
+func (sf *slowFetcher) Fetch() (decimal.Decimal, error) {
+	time.Sleep(sf.delay)
+	return sf.price, nil
+}
@@ -25,6 +25,13 @@ var (
 		},
 		[]string{"url"},
 	)
+	promFMFeedInclusions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flux_monitor_feed_inclusions_total",
+			Help: "Number of times each feed's answer was included in or excluded from the aggregate, by status",
+		},
+		[]string{"job_spec_id", "feed", "status"},
+	)
 	promFMSeenValue = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "flux_monitor_seen_value",
@@ -46,13 +53,13 @@ var (
 		},
 		[]string{"job_spec_id"},
 	)
-	promFMResponseTime = prometheus.NewHistogramVec(
+	promFMResponseTime = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "flux_monitor_request_duration_seconds",
-			Help:    "Flux monitor's histogram of request latencies",
+			Help:    "Flux monitor's histogram of request latencies for each feed, by whether it answered, errored or timed out",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{},
+		[]string{"feed", "status"},
 	)
 	promFMResponseSize = promauto.NewHistogram(
 		prometheus.HistogramOpts{
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"chainlink/core/adapters"
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+	"chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	null "gopkg.in/guregu/null.v3"
+)
+
+// ErrJobApprovalDenied is returned when an approval or rejection is refused
+// because of the approver's credentials.
+var ErrJobApprovalDenied = errors.New("Job approval denied: invalid approver credentials")
+
+// JobApprovalReasons returns why the job must be approved before it is
+// started under the configured approval policy, or nothing if it need not be.
+func JobApprovalReasons(job models.JobSpec, config orm.ConfigReader) []string {
+	reasons := []string{}
+	switch config.JobApprovalPolicy() {
+	case orm.ApprovalPolicyAll:
+		reasons = append(reasons, "every job must be approved")
+	case orm.ApprovalPolicySensitive:
+		for _, task := range job.Tasks {
+			if task.Type == adapters.TaskTypeEthTx || task.Type == adapters.TaskTypeEthTxABIEncode {
+				reasons = append(reasons, fmt.Sprintf("sends ethereum transactions with the %s task", task.Type))
+				break
+			}
+		}
+		reasons = append(reasons, approvalKeyReasons(job, config.JobApprovalKeys())...)
+	}
+	return reasons
+}
+
+// ProposeJobIfRequired marks the job as proposed if the approval policy holds
+// it for approval, returning why.
+func ProposeJobIfRequired(job *models.JobSpec, config orm.ConfigReader) []string {
+	reasons := JobApprovalReasons(*job, config)
+	if len(reasons) == 0 {
+		job.ProposedAt = null.Time{}
+		job.ProposedBy = ""
+		return reasons
+	}
+	job.ProposedAt = null.TimeFrom(time.Now())
+	return reasons
+}
+
+// RecordJobProposal adds the proposal of the job's version to its audit trail.
+func RecordJobProposal(tx *orm.ORM, job models.JobSpec, reasons []string) error {
+	logger.Infow("Job awaiting approval", "job", job.ID, "version", job.Version, "actor", job.ProposedBy, "reasons", reasons)
+	return tx.CreateJobApprovalEvent(&models.JobApprovalEvent{
+		JobSpecID: job.ID,
+		Version:   job.Version,
+		Action:    models.JobApprovalProposed,
+		Actor:     job.ProposedBy,
+		Detail:    strings.Join(reasons, "; "),
+	})
+}
+
+func approvalKeyReasons(job models.JobSpec, keys []string) []string {
+	reasons := []string{}
+	if len(keys) == 0 {
+		return reasons
+	}
+	b, err := json.Marshal(job.Request())
+	if err != nil {
+		logger.Errorw("Unable to check job for approval keys", "job", job.ID, "error", err)
+		return append(reasons, "unable to check the keys it uses")
+	}
+	spec := strings.ToLower(string(b))
+	for _, key := range keys {
+		if strings.Contains(spec, key) {
+			reasons = append(reasons, fmt.Sprintf("uses key %s", key))
+		}
+	}
+	return reasons
+}
+
+// NewJobApproval returns what an approver is shown of the job: why it needs
+// approval, the changes made since its last approved version, and the audit
+// trail of its approvals.
+func NewJobApproval(job models.JobSpec, store *store.Store) (models.JobApproval, error) {
+	approval := models.JobApproval{
+		JobSpecID:       job.ID,
+		Version:         job.Version,
+		ApprovedVersion: job.ApprovedVersion,
+		ProposedAt:      job.ProposedAt,
+		ProposedBy:      job.ProposedBy,
+		Reasons:         JobApprovalReasons(job, store.Config),
+	}
+
+	to, err := store.FindJobSpecVersion(job.ID, job.Version)
+	if err != nil {
+		return approval, errors.Wrap(err, "unable to find the job's current version")
+	}
+	// A job never approved is diffed against an empty spec
+	from := models.JobSpecVersion{JobSpecID: job.ID}
+	from.Spec, _ = models.ParseJSON([]byte("{}"))
+	if job.ApprovedVersion > 0 {
+		from, err = store.FindJobSpecVersion(job.ID, job.ApprovedVersion)
+		if err != nil {
+			return approval, errors.Wrap(err, "unable to find the job's approved version")
+		}
+	}
+	if approval.Diff, err = models.NewJobSpecDiff(from, to); err != nil {
+		return approval, err
+	}
+
+	approval.Events, err = store.JobApprovalEvents(job.ID)
+	return approval, err
+}
+
+// AuthenticateJobApprover checks the approver's credentials for deciding on
+// the job, who may not be who proposed it. Refusals are recorded in the job's
+// approval audit trail.
+func AuthenticateJobApprover(job models.JobSpec, request models.JobApprovalRequest, store *store.Store) error {
+	approver, err := store.FindJobApprover(request.Email)
+	if err != nil && errors.Cause(err) != orm.ErrorNotFound {
+		return err
+	}
+
+	var detail string
+	if err != nil || !utils.CheckPasswordHash(request.Password, approver.HashedPassword) {
+		detail = "invalid credentials"
+	} else if strings.EqualFold(approver.Email, job.ProposedBy) {
+		detail = "approver proposed the job"
+	} else {
+		return nil
+	}
+
+	logger.Warnw("Job approval denied", "job", job.ID, "version", request.Version, "actor", request.Email, "reason", detail)
+	event := models.JobApprovalEvent{
+		JobSpecID: job.ID,
+		Version:   request.Version,
+		Action:    models.JobApprovalDenied,
+		Actor:     request.Email,
+		Detail:    detail,
+	}
+	if err := store.CreateJobApprovalEvent(&event); err != nil {
+		return err
+	}
+	return ErrJobApprovalDenied
+}
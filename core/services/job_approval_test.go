@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobApprovalReasons(t *testing.T) {
+	t.Parallel()
+
+	httpJob := cltest.NewJobWithWebInitiator()
+	ethTxJob := cltest.NewJobWithWebInitiator()
+	ethTxJob.Tasks = append(ethTxJob.Tasks, cltest.NewTask(t, "ethtx"))
+	keyJob := cltest.NewJobWithWebInitiator()
+	keyJob.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "httppost", `{"post": "https://example.com", "from": "0x3CB8e3FD9d27e39a5e9e6852b0e96160061fd4ea"}`),
+	}
+
+	tests := []struct {
+		name    string
+		policy  string
+		job     models.JobSpec
+		reasons int
+	}{
+		{"off", "off", ethTxJob, 0},
+		{"all", "all", httpJob, 1},
+		{"sensitive without ethtx", "sensitive", httpJob, 0},
+		{"sensitive with ethtx", "sensitive", ethTxJob, 1},
+		{"sensitive with approval key", "sensitive", keyJob, 1},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := cltest.NewConfig(t)
+			defer cleanup()
+			config.Set("JOB_APPROVAL_POLICY", test.policy)
+			config.Set("JOB_APPROVAL_KEYS", "0x3cb8e3fd9d27e39a5e9e6852b0e96160061fd4ea")
+
+			assert.Len(t, services.JobApprovalReasons(test.job, config.Config), test.reasons)
+		})
+	}
+}
+
+func TestAuthenticateJobApprover(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	approver, err := models.NewJobApprover("approver@chain.link", "p4SsW0rD1!@#_")
+	require.NoError(t, err)
+	require.NoError(t, store.CreateJobApprover(&approver))
+
+	job := cltest.NewJobWithWebInitiator()
+	job.ProposedBy = "proposer@chain.link"
+	require.NoError(t, store.CreateJob(&job))
+
+	request := models.JobApprovalRequest{Email: "approver@chain.link", Password: "p4SsW0rD1!@#_", Version: 1}
+	assert.NoError(t, services.AuthenticateJobApprover(job, request, store))
+
+	request.Password = "wrong"
+	assert.Equal(t, services.ErrJobApprovalDenied, services.AuthenticateJobApprover(job, request, store))
+
+	job.ProposedBy = "approver@chain.link"
+	request.Password = "p4SsW0rD1!@#_"
+	assert.Equal(t, services.ErrJobApprovalDenied, services.AuthenticateJobApprover(job, request, store))
+
+	events, err := store.JobApprovalEvents(job.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.JobApprovalDenied, events[0].Action)
+	assert.Equal(t, "invalid credentials", events[0].Detail)
+	assert.Equal(t, "approver proposed the job", events[1].Detail)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"chainlink/core/assets"
+	"chainlink/core/logger"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+)
+
+// JobConsumption returns how much of its run rate limit and daily gas budget
+// the job has used as of now.
+func JobConsumption(job *models.JobSpec, orm *orm.ORM, now time.Time) (*models.JobConsumption, error) {
+	consumption := &models.JobConsumption{
+		JobSpecID:        job.ID,
+		MaxRuns:          job.MaxRuns,
+		MaxRunsWindow:    job.MaxRunsWindow,
+		MaxDailyGasSpend: job.MaxDailyGasSpend,
+		DailyGasSpend:    assets.NewEth(0),
+	}
+
+	if job.MaxRunsWindow > 0 {
+		runs, err := orm.AdmittedJobRunsCountSince(job.ID, now.Add(-job.MaxRunsWindow.Duration()))
+		if err != nil {
+			return nil, err
+		}
+		consumption.Runs = uint32(runs)
+	}
+
+	spend, err := orm.JobGasSpendSince(job.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	consumption.DailyGasSpend = (*assets.Eth)(spend)
+	return consumption, nil
+}
+
+// ValidateRunLimits errors the run if its job has reached its run rate limit
+// or daily gas budget.
+func ValidateRunLimits(run *models.JobRun, job *models.JobSpec, consumption *models.JobConsumption) {
+	var err error
+	if job.MaxRuns > 0 && consumption.Runs >= job.MaxRuns {
+		err = fmt.Errorf(
+			"Rejecting run of job %s: rate limit of %d runs per %s reached",
+			job.ID, job.MaxRuns, job.MaxRunsWindow)
+	} else if job.MaxDailyGasSpend != nil && consumption.DailyGasSpend.Cmp(job.MaxDailyGasSpend) >= 0 {
+		err = fmt.Errorf(
+			"Rejecting run of job %s: %s ETH spent on gas in the last 24h, reaching its budget of %s ETH",
+			job.ID, consumption.DailyGasSpend, job.MaxDailyGasSpend)
+	}
+
+	if err != nil {
+		logger.Debugw("Rejecting run of job over its limits", run.ForLogger("error", err)...)
+		run.SetError(err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"chainlink/core/logger"
 	"chainlink/core/store"
 	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -74,17 +75,21 @@ func (js *jobSubscriber) Stop() error {
 }
 
 // AddJob subscribes to ethereum log events for each "runlog" and "ethlog"
-// initiator in the passed job spec.
+// initiator in the passed job spec. The subscription of a version the job was
+// updated from is unsubscribed only once the new one has started, so that no
+// log is missed in between.
 func (js *jobSubscriber) AddJob(job models.JobSpec, bn *models.Head) error {
 	if !job.IsLogInitiated() {
+		js.replaceSubscription(job.ID, nil)
 		return nil
 	}
 
 	sub, err := StartJobSubscription(job, bn, js.store, js.runManager)
 	if err != nil {
+		js.replaceSubscription(job.ID, nil)
 		return err
 	}
-	js.addSubscription(sub)
+	js.replaceSubscription(job.ID, &sub)
 	return nil
 }
 
@@ -115,18 +120,32 @@ func (js *jobSubscriber) Jobs() []models.JobSpec {
 	return jobs
 }
 
-func (js *jobSubscriber) addSubscription(sub JobSubscription) {
+// replaceSubscription unsubscribes the job's subscription, if it has one,
+// after putting the given one, if any, in its place.
+func (js *jobSubscriber) replaceSubscription(ID *models.ID, sub *JobSubscription) {
 	js.jobsMutex.Lock()
-	defer js.jobsMutex.Unlock()
-
-	js.jobSubscriptions[sub.Job.ID.String()] = sub
+	old, ok := js.jobSubscriptions[ID.String()]
+	if sub != nil {
+		js.jobSubscriptions[ID.String()] = *sub
+	} else {
+		delete(js.jobSubscriptions, ID.String())
+	}
 	numberJobSubscriptions.Set(float64(len(js.jobSubscriptions)))
+	js.jobsMutex.Unlock()
+
+	if ok {
+		old.Unsubscribe()
+	}
 }
 
 // Connect connects the jobs to the ethereum node by creating corresponding subscriptions.
 func (js *jobSubscriber) Connect(bn *models.Head) error {
 	var merr error
+	dropPaused := js.store.Config.PausedJobRunLogPolicy() == orm.PausedRunPolicyDrop
 	err := js.store.Jobs(func(j *models.JobSpec) bool {
+		if j.Proposed() || (j.Paused() && dropPaused) {
+			return true
+		}
 		merr = multierr.Append(merr, js.AddJob(*j, bn))
 		return true
 	}, models.InitiatorEthLog, models.InitiatorRunLog, models.InitiatorServiceAgreementExecutionLog)
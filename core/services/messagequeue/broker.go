@@ -0,0 +1,70 @@
+package messagequeue
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// Broker is a message broker that queues can be consumed from.
+type Broker interface {
+	// Subscribe consumes the queue's messages on behalf of the named
+	// consumer. Messages dead lettered are published to the dead letter
+	// queue, if one is given.
+	Subscribe(consumer, queue, deadLetterQueue string) (Subscription, error)
+	Close() error
+}
+
+// Subscription delivers the messages consumed from a queue, until it is
+// unsubscribed or its connection to the broker is lost, when Deliveries is
+// closed.
+type Subscription interface {
+	Deliveries() <-chan Delivery
+	Unsubscribe()
+}
+
+// Delivery is a message consumed from a queue, which must be settled with
+// one of Ack, Nack or DeadLetter once it is handled.
+type Delivery interface {
+	Body() []byte
+	// Ack settles the message as handled, so it is not delivered again.
+	Ack() error
+	// Nack settles the message as not handled, so it is delivered again.
+	Nack() error
+	// DeadLetter settles the message as one that can never be handled,
+	// moving it to the subscription's dead letter queue.
+	DeadLetter(reason string) error
+}
+
+// Dialer connects to the broker at the URL.
+type Dialer func(*url.URL) (Broker, error)
+
+var (
+	dialers      = map[string]Dialer{}
+	dialersMutex sync.RWMutex
+)
+
+func init() {
+	RegisterDialer("memory", dialMemory)
+	RegisterDialer("nats", dialNATS)
+	RegisterDialer("tls", dialNATS)
+}
+
+// RegisterDialer makes brokers with URLs of the scheme available to Dial.
+func RegisterDialer(scheme string, dialer Dialer) {
+	dialersMutex.Lock()
+	defer dialersMutex.Unlock()
+	dialers[scheme] = dialer
+}
+
+// Dial connects to the broker at the URL, by the dialer registered for its
+// scheme.
+func Dial(u *url.URL) (Broker, error) {
+	dialersMutex.RLock()
+	dialer, ok := dialers[u.Scheme]
+	dialersMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported message broker scheme %s", u.Scheme)
+	}
+	return dialer(u)
+}
@@ -0,0 +1,177 @@
+package messagequeue
+
+import (
+	"net/url"
+	"sync"
+)
+
+var (
+	memoryBrokers      = map[string]*MemoryBroker{}
+	memoryBrokersMutex sync.Mutex
+)
+
+// MemoryBrokerNamed returns the in-process broker that URLs of the form
+// memory://name connect to, creating it if need be.
+func MemoryBrokerNamed(name string) *MemoryBroker {
+	memoryBrokersMutex.Lock()
+	defer memoryBrokersMutex.Unlock()
+	broker, ok := memoryBrokers[name]
+	if !ok {
+		broker = NewMemoryBroker()
+		memoryBrokers[name] = broker
+	}
+	return broker
+}
+
+func dialMemory(u *url.URL) (Broker, error) {
+	return MemoryBrokerNamed(u.Host), nil
+}
+
+// DeadLetter is a message a MemoryBroker dead lettered, and why.
+type DeadLetter struct {
+	Body   []byte
+	Reason string
+}
+
+// MemoryBroker is an in-process broker, standing in for a real one in tests
+// and development. Each message published to a queue is delivered to one of
+// its subscriptions, and delivered again until it is acked or dead lettered.
+type MemoryBroker struct {
+	mutex  sync.Mutex
+	queues map[string]*memoryQueue
+}
+
+type memoryQueue struct {
+	pending      chan []byte
+	acked        [][]byte
+	deadLettered []DeadLetter
+}
+
+// memoryQueueSize is how many unsettled messages a memory queue holds before
+// publishing blocks.
+const memoryQueueSize = 1000
+
+// NewMemoryBroker returns an in-process broker with no queues.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{queues: map[string]*memoryQueue{}}
+}
+
+func (mb *MemoryBroker) queue(name string) *memoryQueue {
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	q, ok := mb.queues[name]
+	if !ok {
+		q = &memoryQueue{pending: make(chan []byte, memoryQueueSize)}
+		mb.queues[name] = q
+	}
+	return q
+}
+
+// Publish queues the message.
+func (mb *MemoryBroker) Publish(queue string, body []byte) {
+	mb.queue(queue).pending <- body
+}
+
+// Acked returns the messages of the queue that have been acked.
+func (mb *MemoryBroker) Acked(queue string) [][]byte {
+	q := mb.queue(queue)
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	return append([][]byte{}, q.acked...)
+}
+
+// DeadLettered returns the messages of the queue that have been dead
+// lettered.
+func (mb *MemoryBroker) DeadLettered(queue string) []DeadLetter {
+	q := mb.queue(queue)
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	return append([]DeadLetter{}, q.deadLettered...)
+}
+
+// Subscribe delivers the queue's messages until unsubscribed, whichever
+// consumer it is for.
+func (mb *MemoryBroker) Subscribe(_, queue, deadLetterQueue string) (Subscription, error) {
+	sub := &memorySubscription{
+		broker:          mb,
+		queue:           mb.queue(queue),
+		deadLetterQueue: deadLetterQueue,
+		deliveries:      make(chan Delivery),
+		done:            make(chan struct{}),
+	}
+	go sub.deliver()
+	return sub, nil
+}
+
+// Close is a no-op, the broker living as long as the process.
+func (mb *MemoryBroker) Close() error {
+	return nil
+}
+
+type memorySubscription struct {
+	broker          *MemoryBroker
+	queue           *memoryQueue
+	deadLetterQueue string
+	deliveries      chan Delivery
+	done            chan struct{}
+	unsubscribeOnce sync.Once
+}
+
+func (ms *memorySubscription) deliver() {
+	defer close(ms.deliveries)
+	for {
+		select {
+		case body := <-ms.queue.pending:
+			select {
+			case ms.deliveries <- &memoryDelivery{subscription: ms, body: body}:
+			case <-ms.done:
+				ms.queue.pending <- body
+				return
+			}
+		case <-ms.done:
+			return
+		}
+	}
+}
+
+func (ms *memorySubscription) Deliveries() <-chan Delivery {
+	return ms.deliveries
+}
+
+func (ms *memorySubscription) Unsubscribe() {
+	ms.unsubscribeOnce.Do(func() { close(ms.done) })
+}
+
+type memoryDelivery struct {
+	subscription *memorySubscription
+	body         []byte
+}
+
+func (md *memoryDelivery) Body() []byte {
+	return md.body
+}
+
+func (md *memoryDelivery) Ack() error {
+	mb := md.subscription.broker
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	md.subscription.queue.acked = append(md.subscription.queue.acked, md.body)
+	return nil
+}
+
+func (md *memoryDelivery) Nack() error {
+	md.subscription.queue.pending <- md.body
+	return nil
+}
+
+func (md *memoryDelivery) DeadLetter(reason string) error {
+	mb := md.subscription.broker
+	if md.subscription.deadLetterQueue != "" {
+		mb.Publish(md.subscription.deadLetterQueue, md.body)
+	}
+	mb.mutex.Lock()
+	defer mb.mutex.Unlock()
+	q := md.subscription.queue
+	q.deadLettered = append(q.deadLettered, DeadLetter{Body: md.body, Reason: reason})
+	return nil
+}
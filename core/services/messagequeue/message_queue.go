@@ -0,0 +1,255 @@
+package messagequeue
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"chainlink/core/logger"
+	"chainlink/core/services"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+
+	"github.com/pkg/errors"
+)
+
+// RetryDelay is how long a message whose run could not be created waits
+// before it is nacked to be delivered again, and how long a lost
+// subscription waits before it is resubscribed.
+const RetryDelay = time.Second
+
+// RunManager creates the runs of the messages consumed.
+type RunManager interface {
+	Create(
+		jobSpecID *models.ID,
+		initiator *models.Initiator,
+		creationHeight *big.Int,
+		runRequest *models.RunRequest,
+	) (*models.JobRun, error)
+}
+
+// Service consumes the queues of jobs' messagequeue initiators from the
+// node's message broker, creating a run for each message.
+type Service interface {
+	AddJob(models.JobSpec) error
+	RemoveJob(*models.ID)
+	Start() error
+	Stop()
+}
+
+type messageQueue struct {
+	store      *store.Store
+	runManager RunManager
+	broker     Broker
+	consumers  map[models.ID][]*consumer
+	mutex      sync.Mutex
+}
+
+// New returns a Service consuming from the broker at the configured
+// MESSAGE_QUEUE_URL, which it connects to once a job needs it.
+func New(store *store.Store, runManager RunManager) Service {
+	return &messageQueue{
+		store:      store,
+		runManager: runManager,
+		consumers:  map[models.ID][]*consumer{},
+	}
+}
+
+// Start consumes the queues of the jobs that can be run.
+func (mq *messageQueue) Start() error {
+	return mq.store.Jobs(func(j *models.JobSpec) bool {
+		if j.Paused() || j.Proposed() {
+			return true
+		}
+		logger.ErrorIf(mq.AddJob(*j))
+		return true
+	}, models.InitiatorMessageQueue)
+}
+
+// Stop stops consuming every queue.
+func (mq *messageQueue) Stop() {
+	mq.mutex.Lock()
+	defer mq.mutex.Unlock()
+	for id, consumers := range mq.consumers {
+		for _, c := range consumers {
+			c.stop()
+		}
+		delete(mq.consumers, id)
+	}
+	if mq.broker != nil {
+		logger.ErrorIf(mq.broker.Close())
+		mq.broker = nil
+	}
+}
+
+// AddJob consumes the queue of each of the job's messagequeue initiators.
+// The consumers of a version the job was updated from are stopped only once
+// the new version's have started, so that its queues are never left without
+// a consumer.
+func (mq *messageQueue) AddJob(job models.JobSpec) error {
+	initrs := job.InitiatorsFor(models.InitiatorMessageQueue)
+
+	mq.mutex.Lock()
+	defer mq.mutex.Unlock()
+	consumers := []*consumer{}
+	if len(initrs) > 0 {
+		broker, err := mq.connect()
+		if err != nil {
+			return err
+		}
+		for _, initr := range initrs {
+			c := newConsumer(broker, mq.runManager, initr)
+			go c.consume()
+			consumers = append(consumers, c)
+		}
+	}
+
+	for _, c := range mq.consumers[*job.ID] {
+		c.stop()
+	}
+	if len(consumers) > 0 {
+		mq.consumers[*job.ID] = consumers
+	} else {
+		delete(mq.consumers, *job.ID)
+	}
+	return nil
+}
+
+// RemoveJob stops consuming the job's queues. Messages being handled are
+// settled first.
+func (mq *messageQueue) RemoveJob(id *models.ID) {
+	mq.mutex.Lock()
+	defer mq.mutex.Unlock()
+	for _, c := range mq.consumers[*id] {
+		c.stop()
+	}
+	delete(mq.consumers, *id)
+}
+
+func (mq *messageQueue) connect() (Broker, error) {
+	if mq.broker != nil {
+		return mq.broker, nil
+	}
+	u := mq.store.Config.MessageQueueURL()
+	if u == nil {
+		return nil, errors.New("MESSAGE_QUEUE_URL must be set to consume message queues")
+	}
+	broker, err := Dial(u)
+	if err != nil {
+		return nil, err
+	}
+	mq.broker = broker
+	return broker, nil
+}
+
+// consumer creates a run for each message of an initiator's queue. A message
+// is acked only once its run is saved. Messages that can never start a run,
+// as they are not JSON objects or the job no longer accepts runs, are dead
+// lettered. Those whose run could not be saved, or was refused only for now
+// as the job is paused, awaiting approval, being updated or not yet started,
+// are nacked to be delivered again.
+type consumer struct {
+	broker     Broker
+	runManager RunManager
+	initr      models.Initiator
+	chStop     chan struct{}
+	chDone     chan struct{}
+}
+
+func newConsumer(broker Broker, runManager RunManager, initr models.Initiator) *consumer {
+	return &consumer{
+		broker:     broker,
+		runManager: runManager,
+		initr:      initr,
+		chStop:     make(chan struct{}),
+		chDone:     make(chan struct{}),
+	}
+}
+
+func (c *consumer) stop() {
+	close(c.chStop)
+	<-c.chDone
+}
+
+// name identifies the consumer to the broker by its job and initiator, so that
+// each initiator consuming a queue is delivered every message of it.
+func (c *consumer) name() string {
+	return fmt.Sprintf("%s_%d", c.initr.JobSpecID, c.initr.ID)
+}
+
+// consume subscribes to the queue, subscribing again whenever the
+// subscription is lost.
+func (c *consumer) consume() {
+	defer close(c.chDone)
+	for {
+		sub, err := c.broker.Subscribe(c.name(), c.initr.Queue, c.initr.DeadLetterQueue)
+		if err != nil {
+			logger.Errorw("Unable to subscribe to message queue", "job", c.initr.JobSpecID, "queue", c.initr.Queue, "error", err)
+		} else {
+			stopped := c.handleDeliveries(sub)
+			sub.Unsubscribe()
+			if stopped {
+				return
+			}
+		}
+
+		select {
+		case <-c.chStop:
+			return
+		case <-time.After(RetryDelay):
+		}
+	}
+}
+
+// handleDeliveries handles the subscription's messages until it is lost, or
+// until the consumer is stopped, when it returns true.
+func (c *consumer) handleDeliveries(sub Subscription) bool {
+	for {
+		select {
+		case <-c.chStop:
+			return true
+		case delivery, ok := <-sub.Deliveries():
+			if !ok {
+				return false
+			}
+			if stopped := c.handle(delivery); stopped {
+				return true
+			}
+		}
+	}
+}
+
+// handle creates a run for the message, returning true if the consumer was
+// stopped while it waited to retry.
+func (c *consumer) handle(delivery Delivery) bool {
+	logFields := []interface{}{"job", c.initr.JobSpecID, "queue", c.initr.Queue}
+
+	requestParams, err := models.ParseJSON(delivery.Body())
+	if err != nil || !requestParams.IsObject() {
+		reason := "message body is not a JSON object"
+		logger.Warnw("Dead lettering message", append(logFields, "reason", reason)...)
+		logger.ErrorIf(delivery.DeadLetter(reason))
+		return false
+	}
+
+	_, err = c.runManager.Create(c.initr.JobSpecID, &c.initr, nil, models.NewRunRequest(requestParams))
+	if err != nil && services.ExpectedRecurringScheduleJobError(err) && !services.TemporaryRecurringScheduleJobError(err) {
+		logger.Warnw("Dead lettering message", append(logFields, "reason", err.Error())...)
+		logger.ErrorIf(delivery.DeadLetter(err.Error()))
+		return false
+	} else if err != nil {
+		logger.Errorw("Unable to create run for message, retrying", append(logFields, "error", err)...)
+		select {
+		case <-c.chStop:
+			logger.ErrorIf(delivery.Nack())
+			return true
+		case <-time.After(RetryDelay):
+			logger.ErrorIf(delivery.Nack())
+			return false
+		}
+	}
+
+	logger.ErrorIf(delivery.Ack())
+	return false
+}
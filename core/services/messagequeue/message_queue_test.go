@@ -0,0 +1,137 @@
+package messagequeue_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/internal/mocks"
+	"chainlink/core/services"
+	"chainlink/core/services/messagequeue"
+	"chainlink/core/store/models"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageQueue_AddJob(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("MESSAGE_QUEUE_URL", "memory://"+t.Name())
+	broker := messagequeue.MemoryBrokerNamed(t.Name())
+
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type: models.InitiatorMessageQueue,
+		InitiatorParams: models.InitiatorParams{
+			Queue:           "requests",
+			DeadLetterQueue: "requests.dead",
+		},
+	}}
+	require.NoError(t, store.CreateJob(&job))
+
+	paused := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&paused))
+	require.NoError(t, store.PauseJob(paused.ID))
+	_, errPaused := services.NewRunManager(nil, store.Config, store.ORM, nil, store.TxManager, store.Clock).
+		Create(paused.ID, &paused.Initiators[0], nil, &models.RunRequest{})
+	require.True(t, services.TemporaryRecurringScheduleJobError(errPaused))
+
+	withRequest := func(key, value string) interface{} {
+		return mock.MatchedBy(func(rr *models.RunRequest) bool {
+			return rr.RequestParams.Get(key).String() == value
+		})
+	}
+	runManager := new(mocks.RunManager)
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "created")).
+		Return(&models.JobRun{}, nil).Once()
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "retried")).
+		Return(nil, errors.New("database unavailable")).Once()
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "retried")).
+		Return(&models.JobRun{}, nil).Once()
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "refused")).
+		Return(nil, services.RecurringScheduleJobError{}).Once()
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "paused")).
+		Return(nil, errPaused).Once()
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, withRequest("id", "paused")).
+		Return(&models.JobRun{}, nil).Once()
+
+	mq := messagequeue.New(store, runManager)
+	require.NoError(t, mq.AddJob(job))
+	defer mq.Stop()
+
+	broker.Publish("requests", []byte(`{"id":"created"}`))
+	broker.Publish("requests", []byte(`not json`))
+	broker.Publish("requests", []byte(`{"id":"retried"}`))
+	broker.Publish("requests", []byte(`{"id":"refused"}`))
+	broker.Publish("requests", []byte(`{"id":"paused"}`))
+
+	g.Eventually(func() int { return len(broker.Acked("requests")) }, 5*time.Second).Should(gomega.Equal(3))
+	g.Eventually(func() int { return len(broker.DeadLettered("requests")) }, 5*time.Second).Should(gomega.Equal(2))
+
+	acked := broker.Acked("requests")
+	assert.JSONEq(t, `{"id":"created"}`, string(acked[0]))
+	assert.JSONEq(t, `{"id":"retried"}`, string(acked[1]))
+	assert.JSONEq(t, `{"id":"paused"}`, string(acked[2]))
+
+	deadLettered := broker.DeadLettered("requests")
+	assert.Equal(t, "not json", string(deadLettered[0].Body))
+	assert.JSONEq(t, `{"id":"refused"}`, string(deadLettered[1].Body))
+	assert.Len(t, broker.Acked("requests.dead"), 0)
+	runManager.AssertExpectations(t)
+}
+
+func TestMessageQueue_RemoveJob_LeavesMessagesQueued(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("MESSAGE_QUEUE_URL", "memory://"+t.Name())
+	broker := messagequeue.MemoryBrokerNamed(t.Name())
+
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type:            models.InitiatorMessageQueue,
+		InitiatorParams: models.InitiatorParams{Queue: "requests"},
+	}}
+	require.NoError(t, store.CreateJob(&job))
+
+	runManager := new(mocks.RunManager)
+	mq := messagequeue.New(store, runManager)
+	require.NoError(t, mq.AddJob(job))
+	mq.RemoveJob(job.ID)
+
+	broker.Publish("requests", []byte(`{}`))
+	time.Sleep(100 * time.Millisecond)
+	runManager.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, mock.Anything).Return(&models.JobRun{}, nil).Once()
+	require.NoError(t, mq.AddJob(job))
+	defer mq.Stop()
+
+	gomega.NewGomegaWithT(t).Eventually(func() int { return len(broker.Acked("requests")) }).Should(gomega.Equal(1))
+	runManager.AssertExpectations(t)
+}
+
+func TestMessageQueue_AddJob_WithoutURL(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	job.Initiators = []models.Initiator{{
+		Type:            models.InitiatorMessageQueue,
+		InitiatorParams: models.InitiatorParams{Queue: "requests"},
+	}}
+
+	mq := messagequeue.New(store, new(mocks.RunManager))
+	assert.Error(t, mq.AddJob(job))
+	assert.NoError(t, mq.AddJob(cltest.NewJobWithWebInitiator()))
+}
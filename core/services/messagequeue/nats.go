@@ -0,0 +1,172 @@
+package messagequeue
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+// natsDialTimeout is how long connecting to a NATS server may take.
+const natsDialTimeout = 10 * time.Second
+
+// natsBroker consumes subjects of a NATS server through JetStream, with a
+// durable consumer for each initiator consuming a subject so that messages
+// not yet acked are delivered again, whether nacked, left unsettled or
+// published while the node was away. The connection is reestablished whenever it is lost.
+//
+// The URL's scheme is nats, or tls to require TLS. Its ca query parameter
+// names a file of root certificates to trust, and its cert and key
+// parameters the files of a client certificate to present.
+type natsBroker struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+func dialNATS(u *url.URL) (Broker, error) {
+	if u.Host == "" {
+		return nil, errors.New("NATS URL must have a host")
+	}
+	options := []nats.Option{
+		nats.Name("chainlink"),
+		nats.Timeout(natsDialTimeout),
+		nats.MaxReconnects(-1),
+	}
+	query := u.Query()
+	if ca := query.Get("ca"); ca != "" {
+		options = append(options, nats.RootCAs(ca))
+	}
+	if cert, key := query.Get("cert"), query.Get("key"); cert != "" || key != "" {
+		if cert == "" || key == "" {
+			return nil, errors.New("NATS URL must give both the cert and key of a client certificate")
+		}
+		options = append(options, nats.ClientCert(cert, key))
+	}
+
+	server := *u
+	server.RawQuery = ""
+	conn, err := nats.Connect(server.String(), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to NATS server")
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "unable to use NATS JetStream")
+	}
+	return &natsBroker{conn: conn, js: js}, nil
+}
+
+// Subscribe consumes the subject with a durable JetStream consumer named
+// after the consumer and the subject, which a stream of the server must
+// capture. A durable consumer created anew starts with the messages published
+// from then on, so that the initiators of an updated job do not consume again
+// those its earlier version did. Messages dead lettered are published to the
+// dead letter subject through JetStream too, and stay unsettled if no stream
+// stores them.
+func (nb *natsBroker) Subscribe(consumer, subject, deadLetterSubject string) (Subscription, error) {
+	msgs := make(chan *nats.Msg, nats.DefaultSubPendingMsgsLimit)
+	natsSub, err := nb.js.ChanSubscribe(subject, msgs,
+		nats.Durable(natsDurableName(consumer, subject)),
+		nats.DeliverNew(),
+		nats.AckExplicit(),
+		nats.ManualAck(),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to subscribe to NATS subject %s", subject)
+	}
+	sub := &natsSubscription{
+		broker:            nb,
+		subscription:      natsSub,
+		msgs:              msgs,
+		deadLetterSubject: deadLetterSubject,
+		deliveries:        make(chan Delivery),
+		done:              make(chan struct{}),
+	}
+	go sub.deliver()
+	return sub, nil
+}
+
+func (nb *natsBroker) Close() error {
+	nb.conn.Close()
+	return nil
+}
+
+// natsDurableName returns the name of the consumer's durable consumer of the
+// subject, which may not contain the subject's separators or wildcards.
+func natsDurableName(consumer, subject string) string {
+	return "chainlink_" + strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(consumer+"_"+subject)
+}
+
+type natsSubscription struct {
+	broker            *natsBroker
+	subscription      *nats.Subscription
+	msgs              chan *nats.Msg
+	deadLetterSubject string
+	deliveries        chan Delivery
+	done              chan struct{}
+	unsubscribeOnce   sync.Once
+}
+
+func (ns *natsSubscription) deliver() {
+	defer close(ns.deliveries)
+	for {
+		select {
+		case msg := <-ns.msgs:
+			select {
+			case ns.deliveries <- &natsDelivery{subscription: ns, msg: msg}:
+			case <-ns.done:
+				return
+			}
+		case <-ns.done:
+			return
+		}
+	}
+}
+
+func (ns *natsSubscription) Deliveries() <-chan Delivery {
+	return ns.deliveries
+}
+
+// Unsubscribe stops delivering messages, leaving the durable consumer on the
+// server for the messages not yet acked to be delivered once subscribed
+// again. The subscription is drained, as unsubscribing would delete the
+// consumer.
+func (ns *natsSubscription) Unsubscribe() {
+	ns.unsubscribeOnce.Do(func() {
+		close(ns.done)
+		_ = ns.subscription.Drain()
+	})
+}
+
+type natsDelivery struct {
+	subscription *natsSubscription
+	msg          *nats.Msg
+}
+
+func (nd *natsDelivery) Body() []byte {
+	return nd.msg.Data
+}
+
+func (nd *natsDelivery) Ack() error {
+	return nd.msg.Ack()
+}
+
+func (nd *natsDelivery) Nack() error {
+	return nd.msg.Nak()
+}
+
+func (nd *natsDelivery) DeadLetter(reason string) error {
+	if nd.subscription.deadLetterSubject != "" {
+		msg := nats.NewMsg(nd.subscription.deadLetterSubject)
+		msg.Data = nd.msg.Data
+		msg.Header.Set("Chainlink-Dead-Letter-Reason", reason)
+		if _, err := nd.subscription.broker.js.PublishMsg(msg); err != nil {
+			return errors.Wrap(err, "unable to publish to dead letter subject")
+		}
+	}
+	return nd.msg.Term()
+}
@@ -0,0 +1,310 @@
+package messagequeue_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"chainlink/core/services/messagequeue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// natsPublish is a message published by the client to the fake server.
+type natsPublish struct {
+	subject string
+	reply   string
+	headers string
+	body    string
+}
+
+// fakeJetStreamServer accepts a single client and answers the JetStream API
+// requests made to subscribe with a durable consumer of the REQUESTS
+// stream. Every other message the client publishes is recorded, and answered
+// as stored in the DEAD stream if it expects a reply.
+type fakeJetStreamServer struct {
+	listener  net.Listener
+	connect   chan string
+	published chan natsPublish
+
+	mutex   sync.Mutex
+	conn    net.Conn
+	subs    map[string]string
+	durable string
+	policy  string
+	deliver string
+}
+
+func newFakeJetStreamServer(t *testing.T) *fakeJetStreamServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeJetStreamServer{
+		listener:  listener,
+		connect:   make(chan string, 1),
+		published: make(chan natsPublish, 100),
+		subs:      map[string]string{},
+	}
+	go server.serve()
+	return server
+}
+
+func (s *fakeJetStreamServer) URL() *url.URL {
+	return &url.URL{Scheme: "nats", Host: s.listener.Addr().String(), User: url.UserPassword("node", "secret")}
+}
+
+func (s *fakeJetStreamServer) Close() {
+	s.listener.Close()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+func (s *fakeJetStreamServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	s.mutex.Lock()
+	s.conn = conn
+	s.mutex.Unlock()
+	defer conn.Close()
+
+	s.write(`INFO {"server_id":"fake","version":"2.2.0","proto":1,"headers":true,"max_payload":1048576}` + "\r\n")
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(strings.TrimRight(line, "\r\n"))
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.connect <- strings.Join(fields[1:], " ")
+		case "PING":
+			s.write("PONG\r\n")
+		case "SUB":
+			s.mutex.Lock()
+			s.subs[fields[1]] = fields[len(fields)-1]
+			s.mutex.Unlock()
+		case "PUB", "HPUB":
+			msg, err := readNATSPublish(reader, fields)
+			if err != nil {
+				return
+			}
+			s.handle(msg)
+		}
+	}
+}
+
+// readNATSPublish reads the payload of a message published with
+// PUB <subject> [reply] <#bytes> or
+// HPUB <subject> [reply] <#header bytes> <#total bytes>.
+func readNATSPublish(reader *bufio.Reader, fields []string) (natsPublish, error) {
+	msg := natsPublish{subject: fields[1]}
+	sizes := 1
+	if fields[0] == "HPUB" {
+		sizes = 2
+	}
+	if len(fields) == 3+sizes {
+		msg.reply = fields[2]
+	}
+	total, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return msg, err
+	}
+	headerSize := 0
+	if sizes == 2 {
+		if headerSize, err = strconv.Atoi(fields[len(fields)-2]); err != nil {
+			return msg, err
+		}
+	}
+	payload := make([]byte, total+2)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return msg, err
+	}
+	msg.headers = string(payload[:headerSize])
+	msg.body = string(payload[headerSize:total])
+	return msg, nil
+}
+
+func (s *fakeJetStreamServer) handle(msg natsPublish) {
+	const consumerAPI = "$JS.API.CONSUMER."
+	switch {
+	case msg.subject == "$JS.API.INFO":
+		s.reply(msg.reply, `{}`)
+	case msg.subject == "$JS.API.STREAM.NAMES":
+		s.reply(msg.reply, `{"streams":["REQUESTS"]}`)
+	case strings.HasPrefix(msg.subject, consumerAPI+"INFO."):
+		s.mutex.Lock()
+		durable, deliver := s.durable, s.deliver
+		s.mutex.Unlock()
+		if deliver == "" {
+			s.reply(msg.reply, `{"error":{"code":404,"description":"consumer not found"}}`)
+		} else {
+			s.reply(msg.reply, fmt.Sprintf(`{"stream_name":"REQUESTS","name":"%s","config":{"durable_name":"%s","deliver_subject":"%s","filter_subject":"requests","ack_policy":"explicit"}}`, durable, durable, deliver))
+		}
+	case strings.HasPrefix(msg.subject, consumerAPI+"DURABLE.CREATE.REQUESTS."):
+		var request struct {
+			Config json.RawMessage `json:"config"`
+		}
+		var config struct {
+			DeliverSubject string `json:"deliver_subject"`
+			DeliverPolicy  string `json:"deliver_policy"`
+		}
+		if json.Unmarshal([]byte(msg.body), &request) != nil || json.Unmarshal(request.Config, &config) != nil {
+			return
+		}
+		durable := strings.TrimPrefix(msg.subject, consumerAPI+"DURABLE.CREATE.REQUESTS.")
+		s.mutex.Lock()
+		s.durable = durable
+		s.policy = config.DeliverPolicy
+		s.deliver = config.DeliverSubject
+		s.mutex.Unlock()
+		s.reply(msg.reply, fmt.Sprintf(`{"stream_name":"REQUESTS","name":"%s","config":%s}`, durable, request.Config))
+	default:
+		s.published <- msg
+		if msg.reply != "" {
+			s.reply(msg.reply, `{"stream":"DEAD","seq":1}`)
+		}
+	}
+}
+
+// reply sends the data to the reply subject, through the subscription of the
+// client's response inbox.
+func (s *fakeJetStreamServer) reply(subject, data string) {
+	s.mutex.Lock()
+	var sid string
+	for pattern, id := range s.subs {
+		if pattern == subject || (strings.HasSuffix(pattern, ".*") && strings.HasPrefix(subject, strings.TrimSuffix(pattern, "*"))) {
+			sid = id
+		}
+	}
+	s.mutex.Unlock()
+	s.write(fmt.Sprintf("MSG %s %s %d\r\n%s\r\n", subject, sid, len(data), data))
+}
+
+// send delivers a message of the stream to the consumer, to be settled by
+// replying to the ack subject.
+func (s *fakeJetStreamServer) send(t *testing.T, ackSubject, body string) {
+	s.mutex.Lock()
+	deliver := s.deliver
+	sid := s.subs[deliver]
+	s.mutex.Unlock()
+	require.NotEmpty(t, deliver)
+	s.write(fmt.Sprintf("MSG %s %s %s %d\r\n%s\r\n", deliver, sid, ackSubject, len(body), body))
+}
+
+func (s *fakeJetStreamServer) write(line string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn != nil {
+		fmt.Fprint(s.conn, line)
+	}
+}
+
+func (s *fakeJetStreamServer) expect(t *testing.T, subject string) natsPublish {
+	for {
+		select {
+		case msg := <-s.published:
+			if msg.subject == subject {
+				return msg
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timed out waiting for a message published to %s", subject)
+			return natsPublish{}
+		}
+	}
+}
+
+func TestNATSBroker_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeJetStreamServer(t)
+	defer server.Close()
+
+	broker, err := messagequeue.Dial(server.URL())
+	require.NoError(t, err)
+	defer broker.Close()
+
+	connect := <-server.connect
+	assert.Contains(t, connect, `"user":"node"`)
+	assert.Contains(t, connect, `"pass":"secret"`)
+
+	sub, err := broker.Subscribe("job_1", "requests", "requests.dead")
+	require.NoError(t, err)
+
+	// Each initiator has its own durable consumer, starting with new messages
+	server.mutex.Lock()
+	assert.Equal(t, "chainlink_job_1_requests", server.durable)
+	assert.Equal(t, "new", server.policy)
+	server.mutex.Unlock()
+
+	ack := func(seq int) string {
+		return fmt.Sprintf("$JS.ACK.REQUESTS.chainlink_job_1_requests.1.%d.%d.1590000000000000000.0", seq, seq)
+	}
+	server.send(t, ack(1), `{"id":"one"}`)
+	server.send(t, ack(2), `bad`)
+	server.send(t, ack(3), `{"id":"two"}`)
+
+	next := func() messagequeue.Delivery {
+		select {
+		case delivery := <-sub.Deliveries():
+			return delivery
+		case <-time.After(3 * time.Second):
+			t.Fatal("timed out waiting for delivery")
+			return nil
+		}
+	}
+
+	delivery := next()
+	assert.Equal(t, `{"id":"one"}`, string(delivery.Body()))
+	require.NoError(t, delivery.Ack())
+	assert.Equal(t, "+ACK", server.expect(t, ack(1)).body)
+
+	delivery = next()
+	require.NoError(t, delivery.DeadLetter("not JSON"))
+	dead := server.expect(t, "requests.dead")
+	assert.Equal(t, "bad", dead.body)
+	assert.Contains(t, dead.headers, "not JSON")
+	assert.Equal(t, "+TERM", server.expect(t, ack(2)).body)
+
+	delivery = next()
+	require.NoError(t, delivery.Nack())
+	assert.Equal(t, "-NAK", server.expect(t, ack(3)).body)
+
+	// The durable consumer is kept for the messages left unacked
+	sub.Unsubscribe()
+	select {
+	case msg := <-server.published:
+		assert.NotContains(t, msg.subject, "$JS.API.CONSUMER.DELETE")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNATSBroker_Dial_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	_, err := messagequeue.Dial(&url.URL{Scheme: "tls", Host: "127.0.0.1:4222", RawQuery: "cert=client.pem"})
+	assert.Error(t, err)
+}
+
+func TestDial_UnsupportedScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := messagequeue.Dial(&url.URL{Scheme: "carrier-pigeon", Host: "loft"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,523 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// NodeBundleConflictMode decides what ImportNodeBundle does with an item of a
+// bundle that the node already has.
+type NodeBundleConflictMode string
+
+const (
+	// NodeBundleConflictFail imports nothing if any item is already present
+	NodeBundleConflictFail = NodeBundleConflictMode("fail")
+	// NodeBundleConflictSkip keeps the node's own copy of any item already
+	// present
+	NodeBundleConflictSkip = NodeBundleConflictMode("skip")
+	// NodeBundleConflictOverwrite replaces the node's own copy of any item
+	// already present with the bundle's
+	NodeBundleConflictOverwrite = NodeBundleConflictMode("overwrite")
+)
+
+// ParseNodeBundleConflictMode returns the conflict mode named, defaulting to
+// NodeBundleConflictFail.
+func ParseNodeBundleConflictMode(s string) (NodeBundleConflictMode, error) {
+	switch mode := NodeBundleConflictMode(strings.ToLower(s)); mode {
+	case "":
+		return NodeBundleConflictFail, nil
+	case NodeBundleConflictFail, NodeBundleConflictSkip, NodeBundleConflictOverwrite:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown conflict mode '%s', must be one of fail, skip or overwrite", s)
+}
+
+// NodeBundleExportOptions are the options for ExportNodeBundle.
+type NodeBundleExportOptions struct {
+	// Passphrase encrypts the bridges' and external initiators' outgoing
+	// tokens.
+	Passphrase string
+	// IncludeKeys adds the node's ETH and VRF keys, as encrypted by the node.
+	IncludeKeys bool
+	// ScryptN and ScryptP are the scrypt parameters used to encrypt the
+	// secrets, defaulting to those of the node's keystore.
+	ScryptN, ScryptP int
+}
+
+// ExportNodeBundle returns a bundle of the node's jobs, service agreements,
+// bridges, external initiators and stored configuration, signed by the node's
+// account. The keystore must be unlocked.
+func ExportNodeBundle(store *store.Store, opts NodeBundleExportOptions) (models.NodeBundle, error) {
+	if opts.Passphrase == "" {
+		return models.NodeBundle{}, errors.New("a passphrase is needed to encrypt the bundle's secrets")
+	}
+	if opts.ScryptN == 0 || opts.ScryptP == 0 {
+		opts.ScryptN, opts.ScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+	}
+	contents := models.NodeBundleContents{
+		CreatedAt:          time.Now(),
+		Jobs:               []models.JobSpec{},
+		BridgeTypes:        []models.NodeBundleBridgeType{},
+		ExternalInitiators: []models.NodeBundleInitiator{},
+		Configuration:      map[string]string{},
+	}
+	secrets := models.NodeBundleSecrets{
+		BridgeTokens:       map[string]string{},
+		ExternalInitiators: map[string]models.NodeBundleOutgoingAuth{},
+	}
+
+	sas, err := store.ServiceAgreements()
+	if err != nil {
+		return models.NodeBundle{}, errors.Wrap(err, "failed to load service agreements")
+	}
+	agreementJobs := map[string]bool{}
+	for _, sa := range sas {
+		agreementJobs[sa.JobSpecID.String()] = true
+	}
+	contents.ServiceAgreements = sas
+
+	err = store.Jobs(func(j *models.JobSpec) bool {
+		if !agreementJobs[j.ID.String()] {
+			contents.Jobs = append(contents.Jobs, *j)
+		}
+		return true
+	})
+	if err != nil {
+		return models.NodeBundle{}, errors.Wrap(err, "failed to load jobs")
+	}
+
+	bridges, err := store.AllBridgeTypes()
+	if err != nil {
+		return models.NodeBundle{}, errors.Wrap(err, "failed to load bridges")
+	}
+	for _, bt := range bridges {
+		contents.BridgeTypes = append(contents.BridgeTypes, models.NodeBundleBridgeType{
+			Name:                   bt.Name,
+			URL:                    bt.URL,
+			Confirmations:          bt.Confirmations,
+			IncomingTokenHash:      bt.IncomingTokenHash,
+			Salt:                   bt.Salt,
+			MinimumContractPayment: bt.MinimumContractPayment,
+		})
+		secrets.BridgeTokens[bt.Name.String()] = bt.OutgoingToken
+	}
+
+	exis, err := store.ExternalInitiators()
+	if err != nil {
+		return models.NodeBundle{}, errors.Wrap(err, "failed to load external initiators")
+	}
+	for _, exi := range exis {
+		contents.ExternalInitiators = append(contents.ExternalInitiators, models.NodeBundleInitiator{
+			Name:         exi.Name,
+			URL:          exi.URL,
+			AccessKey:    exi.AccessKey,
+			Salt:         exi.Salt,
+			HashedSecret: exi.HashedSecret,
+		})
+		secrets.ExternalInitiators[exi.Name] = models.NodeBundleOutgoingAuth{
+			OutgoingToken:  exi.OutgoingToken,
+			OutgoingSecret: exi.OutgoingSecret,
+		}
+	}
+
+	configs, err := store.ConfigValues()
+	if err != nil {
+		return models.NodeBundle{}, errors.Wrap(err, "failed to load configuration")
+	}
+	for _, config := range configs {
+		contents.Configuration[config.Name] = config.Value
+	}
+
+	if opts.IncludeKeys {
+		keys, err := store.Keys()
+		if err != nil {
+			return models.NodeBundle{}, errors.Wrap(err, "failed to load keys")
+		}
+		for _, k := range keys {
+			contents.Keys = append(contents.Keys, *k)
+		}
+		vrfKeys, err := store.FindEncryptedSecretVRFKeys()
+		if err != nil {
+			return models.NodeBundle{}, errors.Wrap(err, "failed to load VRF keys")
+		}
+		for _, k := range vrfKeys {
+			contents.VRFKeys = append(contents.VRFKeys, *k)
+		}
+	}
+
+	if err := contents.EncryptSecrets(secrets, opts.Passphrase, opts.ScryptN, opts.ScryptP); err != nil {
+		return models.NodeBundle{}, err
+	}
+	account, err := store.KeyStore.GetFirstAccount()
+	if err != nil {
+		return models.NodeBundle{}, err
+	}
+	return models.NewNodeBundle(contents, store.KeyStore, account.Address)
+}
+
+// NodeBundleImportOptions are the options for ImportNodeBundle.
+type NodeBundleImportOptions struct {
+	// Passphrase decrypts the bundle's secrets.
+	Passphrase string
+	// Signer is the only account whose bundles are accepted, defaulting to
+	// the node's NODE_BUNDLE_SIGNER. Bundles are refused if neither is set.
+	Signer *common.Address
+	// OnConflict decides what is done with items the node already has.
+	OnConflict NodeBundleConflictMode
+}
+
+// NodeBundleImportReport lists the items of a bundle by what ImportNodeBundle
+// did with them.
+type NodeBundleImportReport struct {
+	Signer  common.Address `json:"signer"`
+	Created []string       `json:"created"`
+	Updated []string       `json:"updated"`
+	Skipped []string       `json:"skipped"`
+}
+
+// nodeBundleItem is an item of a bundle to import. Exists is set when the
+// node already has the item, and Blocked when it cannot be imported at all.
+type nodeBundleItem struct {
+	Label   string
+	Exists  bool
+	Blocked error
+	// Replaceable items are replaced in NodeBundleConflictOverwrite mode.
+	// Items identified by their contents, such as keys, are not.
+	Replaceable bool
+	Import      func(overwrite bool) error
+	// Saved, when set, finishes the import of the item outside the database
+	// once the import has been committed.
+	Saved func() error
+}
+
+// ImportNodeBundle checks that the bundle is signed by the trusted signer and
+// adds its contents to the node in a single database transaction, so that a
+// bundle failing to import changes nothing. Every item is checked for
+// conflicts before anything is written. Jobs keep their IDs; a job's link to
+// a spec template is dropped unless the node has the template, and a job
+// archived on the node stays archived. Jobs the approval policy holds for
+// approval are saved as proposed by the bundle's signer.
+func ImportNodeBundle(store *store.Store, bundle models.NodeBundle, opts NodeBundleImportOptions) (NodeBundleImportReport, error) {
+	report := NodeBundleImportReport{Signer: bundle.Signer, Created: []string{}, Updated: []string{}, Skipped: []string{}}
+	if opts.OnConflict == "" {
+		opts.OnConflict = NodeBundleConflictFail
+	}
+	signer := opts.Signer
+	if signer == nil {
+		signer = store.Config.NodeBundleSigner()
+	}
+	if signer == nil {
+		return report, errors.New("no trusted node bundle signer, must be given as the signer or set as NODE_BUNDLE_SIGNER")
+	}
+	if *signer != bundle.Signer {
+		return report, fmt.Errorf("node bundle is signed by %s, not %s", bundle.Signer.Hex(), signer.Hex())
+	}
+	contents, err := bundle.Open()
+	if err != nil {
+		return report, err
+	}
+	secrets, err := contents.DecryptSecrets(opts.Passphrase)
+	if err != nil {
+		return report, err
+	}
+
+	imported := report
+	var saved []func() error
+	err = store.Transaction(func(tx *orm.ORM) error {
+		items, err := nodeBundleItems(tx, store.Config, bundle.Signer, contents, secrets)
+		if err != nil {
+			return err
+		}
+
+		var conflicts []string
+		for _, item := range items {
+			if item.Blocked != nil {
+				return errors.Wrap(item.Blocked, item.Label)
+			}
+			if item.Exists {
+				conflicts = append(conflicts, item.Label)
+			}
+		}
+		if len(conflicts) > 0 && opts.OnConflict == NodeBundleConflictFail {
+			return fmt.Errorf("node already has %s", strings.Join(conflicts, ", "))
+		}
+
+		for _, item := range items {
+			switch {
+			case !item.Exists:
+				if err := item.Import(false); err != nil {
+					return errors.Wrapf(err, "failed to import %s", item.Label)
+				}
+				imported.Created = append(imported.Created, item.Label)
+			case opts.OnConflict == NodeBundleConflictOverwrite && item.Replaceable:
+				if err := item.Import(true); err != nil {
+					return errors.Wrapf(err, "failed to import %s", item.Label)
+				}
+				imported.Updated = append(imported.Updated, item.Label)
+			default:
+				imported.Skipped = append(imported.Skipped, item.Label)
+				continue
+			}
+			if item.Saved != nil {
+				saved = append(saved, item.Saved)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, save := range saved {
+		if err := save(); err != nil {
+			return imported, err
+		}
+	}
+	return imported, nil
+}
+
+// nodeBundleItems returns the items of the bundle in the order they are to be
+// imported, so that bridges exist before the jobs that use them.
+func nodeBundleItems(tx *orm.ORM, config orm.ConfigReader, signer common.Address, contents models.NodeBundleContents, secrets models.NodeBundleSecrets) ([]nodeBundleItem, error) {
+	var items []nodeBundleItem
+
+	configs, err := tx.ConfigValues()
+	if err != nil {
+		return nil, err
+	}
+	haveConfig := map[string]bool{}
+	for _, config := range configs {
+		haveConfig[config.Name] = true
+	}
+	var names []string
+	for name := range contents.Configuration {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := contents.Configuration[name]
+		item := nodeBundleItem{
+			Label:       fmt.Sprintf("config %s", name),
+			Exists:      haveConfig[name],
+			Replaceable: true,
+		}
+		field, ok := orm.ConfigSchemaField(name)
+		if !ok {
+			item.Blocked = errors.New("is not a configuration variable of this node")
+		}
+		item.Import = func(bool) error {
+			return tx.SetConfigValue(field, nodeBundleConfigValue(value))
+		}
+		items = append(items, item)
+	}
+
+	for _, b := range contents.BridgeTypes {
+		b := b
+		existing, err := tx.FindBridge(b.Name)
+		if err != nil && errors.Cause(err) != orm.ErrorNotFound {
+			return nil, err
+		}
+		items = append(items, nodeBundleItem{
+			Label:       fmt.Sprintf("bridge %s", b.Name),
+			Exists:      err == nil,
+			Replaceable: true,
+			Import: func(overwrite bool) error {
+				if overwrite {
+					if err := tx.DeleteBridgeType(&existing); err != nil {
+						return err
+					}
+				}
+				return tx.CreateBridgeType(&models.BridgeType{
+					Name:                   b.Name,
+					URL:                    b.URL,
+					Confirmations:          b.Confirmations,
+					IncomingTokenHash:      b.IncomingTokenHash,
+					Salt:                   b.Salt,
+					OutgoingToken:          secrets.BridgeTokens[b.Name.String()],
+					MinimumContractPayment: b.MinimumContractPayment,
+				})
+			},
+		})
+	}
+
+	for _, ei := range contents.ExternalInitiators {
+		ei := ei
+		_, err := tx.FindExternalInitiatorByName(ei.Name)
+		if err != nil && errors.Cause(err) != orm.ErrorNotFound {
+			return nil, err
+		}
+		items = append(items, nodeBundleItem{
+			Label:       fmt.Sprintf("external initiator %s", ei.Name),
+			Exists:      err == nil,
+			Replaceable: true,
+			Import: func(overwrite bool) error {
+				if overwrite {
+					if err := tx.DeleteExternalInitiator(ei.Name); err != nil {
+						return err
+					}
+				}
+				outgoing := secrets.ExternalInitiators[ei.Name]
+				return tx.CreateExternalInitiator(&models.ExternalInitiator{
+					Name:           ei.Name,
+					URL:            ei.URL,
+					AccessKey:      ei.AccessKey,
+					Salt:           ei.Salt,
+					HashedSecret:   ei.HashedSecret,
+					OutgoingToken:  outgoing.OutgoingToken,
+					OutgoingSecret: outgoing.OutgoingSecret,
+				})
+			},
+		})
+	}
+
+	for _, j := range contents.Jobs {
+		job := j
+		item := nodeBundleItem{Label: fmt.Sprintf("job %s", job.ID), Replaceable: true}
+		existing, err := tx.Unscoped().FindJob(job.ID)
+		if err != nil && errors.Cause(err) != orm.ErrorNotFound {
+			return nil, err
+		}
+		if err == nil {
+			item.Exists = true
+			if existing.DeletedAt.Valid {
+				item.Replaceable = false
+			}
+		}
+		if err := unlinkMissingTemplate(tx, &job); err != nil {
+			return nil, err
+		}
+		item.Import = func(overwrite bool) error {
+			resetJobAssociations(&job)
+			return saveBundleJob(tx, config, signer, &job, func() error {
+				if overwrite {
+					return tx.UpdateJob(&job)
+				}
+				return tx.CreateJob(&job)
+			})
+		}
+		items = append(items, item)
+	}
+
+	for _, s := range contents.ServiceAgreements {
+		sa := s
+		_, err := tx.FindServiceAgreement(sa.ID)
+		if err != nil && errors.Cause(err) != orm.ErrorNotFound {
+			return nil, err
+		}
+		items = append(items, nodeBundleItem{
+			Label:  fmt.Sprintf("service agreement %s", sa.ID),
+			Exists: err == nil,
+			Import: func(bool) error {
+				resetJobAssociations(&sa.JobSpec)
+				sa.JobSpecID = sa.JobSpec.ID
+				sa.Encumbrance.ID = 0
+				return saveBundleJob(tx, config, signer, &sa.JobSpec, func() error {
+					return tx.CreateServiceAgreement(&sa)
+				})
+			},
+		})
+	}
+
+	keys, err := tx.Keys()
+	if err != nil {
+		return nil, err
+	}
+	haveKey := map[common.Address]bool{}
+	for _, k := range keys {
+		haveKey[k.Address.Address()] = true
+	}
+	for _, k := range contents.Keys {
+		key := k
+		items = append(items, nodeBundleItem{
+			Label:  fmt.Sprintf("key %s", key.Address.String()),
+			Exists: haveKey[key.Address.Address()],
+			Import: func(bool) error {
+				return tx.FirstOrCreateKey(&key)
+			},
+			Saved: func() error {
+				if err := os.MkdirAll(config.KeysDir(), 0700); err != nil {
+					return err
+				}
+				return key.WriteToDisk(filepath.Join(config.KeysDir(), fmt.Sprintf("%s.json", key.Address.String())))
+			},
+		})
+	}
+
+	for _, k := range contents.VRFKeys {
+		key := k
+		found, err := tx.FindEncryptedSecretVRFKeys(models.EncryptedSecretVRFKey{PublicKey: key.PublicKey})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, nodeBundleItem{
+			Label:  fmt.Sprintf("VRF key %s", key.PublicKey.String()),
+			Exists: len(found) > 0,
+			Import: func(bool) error {
+				return tx.FirstOrCreateEncryptedSecretVRFKey(&key)
+			},
+		})
+	}
+
+	return items, nil
+}
+
+// resetJobAssociations clears the IDs of the job's initiators and tasks, for
+// them to be given new ones on this node.
+func resetJobAssociations(job *models.JobSpec) {
+	for i := range job.Initiators {
+		job.Initiators[i].ID = 0
+		job.Initiators[i].JobSpecID = job.ID
+	}
+	for i := range job.Tasks {
+		job.Tasks[i].ID = 0
+		job.Tasks[i].JobSpecID = job.ID
+	}
+}
+
+// saveBundleJob saves a job of the bundle with save, holding it for approval
+// as proposed by the bundle's signer if the approval policy requires.
+func saveBundleJob(tx *orm.ORM, config orm.ConfigReader, signer common.Address, job *models.JobSpec, save func() error) error {
+	job.ProposedBy = signer.Hex()
+	job.ApprovedVersion = 0
+	reasons := ProposeJobIfRequired(job, config)
+	if err := save(); err != nil {
+		return err
+	}
+	if job.Proposed() {
+		return RecordJobProposal(tx, *job, reasons)
+	}
+	return nil
+}
+
+func unlinkMissingTemplate(tx *orm.ORM, job *models.JobSpec) error {
+	if job.SpecTemplateID == nil {
+		return nil
+	}
+	_, err := tx.FindSpecTemplate(job.SpecTemplateID)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		job.SpecTemplateID = nil
+		job.SpecTemplateVersion = 0
+		job.TemplateVariables = nil
+		return nil
+	}
+	return err
+}
+
+// nodeBundleConfigValue is a configuration value as stored by the node.
+type nodeBundleConfigValue string
+
+func (v nodeBundleConfigValue) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeBundleConflictMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  services.NodeBundleConflictMode
+		err   bool
+	}{
+		{"", services.NodeBundleConflictFail, false},
+		{"fail", services.NodeBundleConflictFail, false},
+		{"Skip", services.NodeBundleConflictSkip, false},
+		{"overwrite", services.NodeBundleConflictOverwrite, false},
+		{"merge", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			mode, err := services.ParseNodeBundleConflictMode(test.input)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, mode)
+			}
+		})
+	}
+}
+
+func TestNodeBundle_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+	store := app.Store
+
+	_, bt := cltest.NewBridgeType(t, "prices", "https://example.com/prices")
+	require.NoError(t, store.CreateBridgeType(bt))
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+
+	bundle, err := services.ExportNodeBundle(store, services.NodeBundleExportOptions{
+		Passphrase: "passphrase",
+		ScryptN:    2,
+		ScryptP:    1,
+	})
+	require.NoError(t, err)
+
+	contents, err := bundle.Open()
+	require.NoError(t, err)
+	require.Len(t, contents.Jobs, 1)
+	assert.Equal(t, job.ID, contents.Jobs[0].ID)
+	require.Len(t, contents.BridgeTypes, 1)
+	assert.Empty(t, contents.Keys)
+
+	_, err = services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+		OnConflict: services.NodeBundleConflictSkip,
+	})
+	assert.Error(t, err, "no trusted signer")
+
+	store.Config.Set("NODE_BUNDLE_SIGNER", bundle.Signer.Hex())
+	_, err = services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+	})
+	assert.Error(t, err)
+
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	_, err = services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+		Signer:     &other,
+		OnConflict: services.NodeBundleConflictSkip,
+	})
+	assert.Error(t, err)
+
+	_, err = services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "wrong",
+		OnConflict: services.NodeBundleConflictSkip,
+	})
+	assert.Error(t, err)
+
+	require.NoError(t, store.DeleteBridgeType(bt))
+	report, err := services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+		OnConflict: services.NodeBundleConflictSkip,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bridge prices"}, report.Created)
+	assert.Equal(t, []string{"job " + job.ID.String()}, report.Skipped)
+
+	imported, err := store.FindBridge(bt.Name)
+	require.NoError(t, err)
+	assert.Equal(t, bt.OutgoingToken, imported.OutgoingToken)
+	assert.Equal(t, bt.IncomingTokenHash, imported.IncomingTokenHash)
+
+	report, err = services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+		OnConflict: services.NodeBundleConflictOverwrite,
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"bridge prices", "job " + job.ID.String()}, report.Updated)
+
+	updated, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), updated.Version)
+}
+
+func TestNodeBundle_ImportHeldForApproval(t *testing.T) {
+	t.Parallel()
+
+	exporter, cleanup := cltest.NewApplicationWithKey(t)
+	defer cleanup()
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, exporter.Store.CreateJob(&job))
+	bundle, err := services.ExportNodeBundle(exporter.Store, services.NodeBundleExportOptions{
+		Passphrase: "passphrase",
+		ScryptN:    2,
+		ScryptP:    1,
+	})
+	require.NoError(t, err)
+
+	app, cleanup := cltest.NewApplication(t)
+	defer cleanup()
+	store := app.Store
+	store.Config.Set("JOB_APPROVAL_POLICY", "all")
+	report, err := services.ImportNodeBundle(store, bundle, services.NodeBundleImportOptions{
+		Passphrase: "passphrase",
+		Signer:     &bundle.Signer,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job " + job.ID.String()}, report.Created)
+
+	imported, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, imported.Proposed())
+	assert.Equal(t, uint32(0), imported.ApprovedVersion)
+	assert.Equal(t, bundle.Signer.Hex(), imported.ProposedBy)
+
+	events, err := store.JobApprovalEvents(job.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.JobApprovalProposed, events[0].Action)
+}
@@ -27,4 +27,11 @@ func (sr *storeReaper) Work() {
 	if err != nil {
 		logger.Error("unable to reap stale sessions: ", err)
 	}
+
+	if retention := sr.config.HTTPAuditRetention(); retention > 0 {
+		err = sr.store.DeleteHTTPAuditRecordsBefore(time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("unable to reap expired HTTP audit records: ", err)
+		}
+	}
 }
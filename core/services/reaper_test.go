@@ -59,3 +59,36 @@ func TestStoreReaper_ReapSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreReaper_ReapHTTPAuditRecords(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("HTTP_AUDIT_RETENTION", "1h")
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	for _, createdAt := range []time.Time{time.Now(), time.Now().Add(-2 * time.Hour)} {
+		record := models.HTTPAuditRecord{
+			TaskRunID: run.TaskRuns[0].ID,
+			CreatedAt: createdAt,
+			Method:    "GET",
+			URL:       "https://example.com",
+		}
+		require.NoError(t, store.CreateHTTPAuditRecord(&record))
+	}
+
+	r := services.NewStoreReaper(store)
+	defer r.Stop()
+	r.WakeUp()
+
+	gomega.NewGomegaWithT(t).Eventually(func() []models.HTTPAuditRecord {
+		records, err := store.HTTPAuditRecordsFor(run.ID)
+		assert.NoError(t, err)
+		return records
+	}).Should(gomega.HaveLen(1))
+}
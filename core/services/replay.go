@@ -0,0 +1,158 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"chainlink/core/adapters"
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+)
+
+// ReplaySpec selects the tasks that a replayed run executes.
+type ReplaySpec string
+
+const (
+	// ReplaySpecOriginal replays the tasks as they were when the run was made.
+	ReplaySpecOriginal ReplaySpec = "original"
+	// ReplaySpecCurrent replays the job's current tasks.
+	ReplaySpecCurrent ReplaySpec = "current"
+)
+
+// ReplayRun re-executes a persisted run against its original run request and
+// initiator without side effects, and compares each task's result with the
+// original. HTTP requests of tasks whose exchanges were captured in the HTTP
+// audit trail are answered from it. Tasks that send HTTP requests, bridges and
+// the httpget and httppost adapters, never send them afresh, as they could
+// have effects outside the node, such as a bridge calling back into the
+// original run, and error when their exchanges were not captured.
+func ReplayRun(original models.JobRun, spec ReplaySpec, store *store.Store) (*models.RunReplay, error) {
+	job := models.JobSpec{ID: original.JobSpecID}
+	switch spec {
+	case ReplaySpecOriginal:
+		for _, tr := range original.TaskRuns {
+			job.Tasks = append(job.Tasks, tr.TaskSpec)
+		}
+	case ReplaySpecCurrent:
+		current, err := store.FindJob(original.JobSpecID)
+		if err != nil {
+			return nil, err
+		}
+		job.Tasks = current.Tasks
+	default:
+		return nil, fmt.Errorf("unknown spec to replay %q, must be %q or %q", spec, ReplaySpecOriginal, ReplaySpecCurrent)
+	}
+	if len(job.Tasks) == 0 {
+		return nil, fmt.Errorf("invariant for run %s: no tasks to replay", original.ID)
+	}
+
+	records, err := store.HTTPAuditRecordsFor(original.ID)
+	if err != nil {
+		return nil, err
+	}
+	recordsByTaskRun := make(map[models.ID][]models.HTTPAuditRecord)
+	for _, record := range records {
+		recordsByTaskRun[*record.TaskRunID] = append(recordsByTaskRun[*record.TaskRunID], record)
+	}
+
+	runRequest := original.RunRequest
+	run, _ := NewRun(&job, &original.Initiator, nil, &runRequest, store.Config, store.ORM, time.Now())
+	// Adapters that look up their run, such as bridges answered from the
+	// audit trail, find the original
+	run.ID = original.ID
+
+	replay := &models.RunReplay{
+		JobRunID:       original.ID,
+		Spec:           string(spec),
+		OriginalStatus: original.Status,
+		TaskRuns:       make([]models.TaskReplay, len(run.TaskRuns)),
+	}
+	for i := range run.TaskRuns {
+		taskRun := &run.TaskRuns[i]
+		taskRun.JobRunID = run.ID
+		replayed := &replay.TaskRuns[i]
+		replayed.Type = job.Tasks[i].Type
+
+		var originalTaskRun *models.TaskRun
+		if i < len(original.TaskRuns) && original.TaskRuns[i].TaskSpec.Type == replayed.Type {
+			originalTaskRun = &original.TaskRuns[i]
+			replayed.Original = models.TaskReplayResult{
+				Status: originalTaskRun.Status,
+				Output: originalTaskRun.Result.Data,
+				Error:  originalTaskRun.Result.ErrorMessage,
+			}
+		}
+
+		if run.Status.Runnable() {
+			var recordedHTTP []models.HTTPAuditRecord
+			if originalTaskRun != nil {
+				recordedHTTP = recordsByTaskRun[*originalTaskRun.ID]
+			}
+			replayed.Recorded = recordedHTTP != nil
+			if err := unrecordedHTTPError(taskRun.TaskSpec, recordedHTTP); err != nil {
+				result := models.NewRunOutputError(err)
+				taskRun.ApplyOutput(result)
+				run.ApplyOutput(result)
+			} else {
+				simulateTask(run, taskRun, recordedHTTP, store)
+			}
+			logger.Debugw(fmt.Sprintf("Replayed task %s", taskRun.TaskSpec.Type), run.ForLogger("task", taskRun.ID.String())...)
+		}
+		replayed.Replayed = models.TaskReplayResult{
+			Status: taskRun.Status,
+			Output: taskRun.Result.Data,
+			Error:  taskRun.Result.ErrorMessage,
+		}
+
+		replayed.ChangedKeys = changedKeys(replayed.Original.Output, replayed.Replayed.Output)
+		replayed.Changed = originalTaskRun == nil ||
+			replayed.Original.Status != replayed.Replayed.Status ||
+			replayed.Original.Error != replayed.Replayed.Error ||
+			len(replayed.ChangedKeys) > 0
+	}
+
+	replay.Status = run.Status
+	replay.Result = run.Result
+	return replay, nil
+}
+
+// unrecordedHTTPError returns an error for a task that sends HTTP requests
+// when none of its exchanges were recorded to replay.
+func unrecordedHTTPError(task models.TaskSpec, recordedHTTP []models.HTTPAuditRecord) error {
+	if recordedHTTP != nil {
+		return nil
+	}
+	switch _, core, _ := adapters.ForCore(task); {
+	case !core:
+		return fmt.Errorf("bridge %s has no recorded response to replay, not posting to the external adapter", task.Type)
+	case task.Type == adapters.TaskTypeHTTPGet || task.Type == adapters.TaskTypeHTTPPost:
+		return fmt.Errorf("%s task has no recorded response to replay, not sending its request", task.Type)
+	}
+	return nil
+}
+
+// changedKeys returns the sorted top level keys whose values differ between
+// two JSON objects.
+func changedKeys(a, b models.JSON) []string {
+	var aValues, bValues map[string]interface{}
+	_ = json.Unmarshal([]byte(a.String()), &aValues)
+	_ = json.Unmarshal([]byte(b.String()), &bValues)
+
+	changed := []string{}
+	for key, aValue := range aValues {
+		if bValue, ok := bValues[key]; !ok || !reflect.DeepEqual(aValue, bValue) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range bValues {
+		if _, ok := aValues[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
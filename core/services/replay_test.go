@@ -0,0 +1,175 @@
+package services_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayRun_RecordedHTTP(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "httpget", `{"get":"https://example.com/price"}`),
+		cltest.NewTask(t, "jsonparse", `{"path":["price"]}`),
+	}
+	require.NoError(t, store.CreateJob(&job))
+
+	run := cltest.NewJobRun(job)
+	run.Status = models.RunStatusCompleted
+	run.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult(`{"price":100}`))
+	run.TaskRuns[1].ApplyOutput(models.NewRunOutputCompleteWithResult("99"))
+	require.NoError(t, store.CreateJobRun(&run))
+
+	record := models.HTTPAuditRecord{
+		TaskRunID:    run.TaskRuns[0].ID,
+		CreatedAt:    time.Now(),
+		Method:       "GET",
+		URL:          "https://example.com/price",
+		StatusCode:   http.StatusOK,
+		ResponseBody: `{"price":100}`,
+	}
+	require.NoError(t, store.CreateHTTPAuditRecord(&record))
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	replay, err := services.ReplayRun(run, services.ReplaySpecOriginal, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, run.ID, replay.JobRunID)
+	assert.Equal(t, models.RunStatusCompleted, replay.Status)
+	require.Len(t, replay.TaskRuns, 2)
+
+	assert.True(t, replay.TaskRuns[0].Recorded)
+	assert.False(t, replay.TaskRuns[0].Changed)
+	assert.Empty(t, replay.TaskRuns[0].ChangedKeys)
+
+	assert.False(t, replay.TaskRuns[1].Recorded)
+	assert.True(t, replay.TaskRuns[1].Changed)
+	assert.Equal(t, []string{"result"}, replay.TaskRuns[1].ChangedKeys)
+	assert.Equal(t, "100", replay.TaskRuns[1].Replayed.Output.Get("result").String())
+
+	count, err := store.ORM.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestReplayRun_UnrecordedRequest(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httpget", `{"get":"https://example.com/price"}`)}
+	require.NoError(t, store.CreateJob(&job))
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	record := models.HTTPAuditRecord{
+		TaskRunID:    run.TaskRuns[0].ID,
+		CreatedAt:    time.Now(),
+		Method:       "GET",
+		URL:          "https://example.com/other",
+		StatusCode:   http.StatusOK,
+		ResponseBody: `{}`,
+	}
+	require.NoError(t, store.CreateHTTPAuditRecord(&record))
+
+	replay, err := services.ReplayRun(run, services.ReplaySpecOriginal, store)
+	require.NoError(t, err)
+
+	require.Len(t, replay.TaskRuns, 1)
+	assert.True(t, replay.TaskRuns[0].Recorded)
+	assert.Equal(t, models.RunStatusErrored, replay.TaskRuns[0].Replayed.Status)
+	assert.Contains(t, replay.TaskRuns[0].Replayed.Error.String, "no recorded response to GET https://example.com/price")
+}
+
+func TestReplayRun_UnrecordedBridge(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	called := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer mockServer.Close()
+
+	_, bt := cltest.NewBridgeType(t, "auctionbridge", mockServer.URL)
+	require.NoError(t, store.CreateBridgeType(bt))
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "auctionbridge")}
+	require.NoError(t, store.CreateJob(&job))
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	replay, err := services.ReplayRun(run, services.ReplaySpecOriginal, store)
+	require.NoError(t, err)
+
+	require.Len(t, replay.TaskRuns, 1)
+	assert.False(t, replay.TaskRuns[0].Recorded)
+	assert.Equal(t, models.RunStatusErrored, replay.TaskRuns[0].Replayed.Status)
+	assert.Contains(t, replay.TaskRuns[0].Replayed.Error.String, "no recorded response")
+	assert.False(t, called, "the external adapter must not be called")
+}
+
+func TestReplayRun_UnrecordedHTTPTask(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	called := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer mockServer.Close()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "httppost", fmt.Sprintf(`{"post":"%s"}`, mockServer.URL))}
+	require.NoError(t, store.CreateJob(&job))
+
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	replay, err := services.ReplayRun(run, services.ReplaySpecOriginal, store)
+	require.NoError(t, err)
+
+	require.Len(t, replay.TaskRuns, 1)
+	assert.False(t, replay.TaskRuns[0].Recorded)
+	assert.Equal(t, models.RunStatusErrored, replay.TaskRuns[0].Replayed.Status)
+	assert.Contains(t, replay.TaskRuns[0].Replayed.Error.String, "httppost task has no recorded response")
+	assert.False(t, called, "the endpoint must not be called")
+}
+
+func TestReplayRun_UnknownSpec(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+
+	_, err := services.ReplayRun(run, services.ReplaySpec("historical"), store)
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"sync"
+
+	"chainlink/core/logger"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+)
+
+// runSubscriptionBufferSize is the number of updates held for a slow
+// subscriber before further updates to it are dropped.
+const runSubscriptionBufferSize = 100
+
+//go:generate mockery -name RunBroadcaster -output ../internal/mocks/ -case=underscore
+
+// RunBroadcaster notifies subscribers of every job run once it is saved, so
+// that status transitions and task results can be streamed rather than
+// polled.
+type RunBroadcaster interface {
+	Start() error
+	Stop() error
+	Subscribe(jobSpecID, runID *models.ID) RunSubscription
+}
+
+// RunSubscription receives the job runs matching its filter as they are
+// saved.
+type RunSubscription interface {
+	Updates() <-chan models.JobRun
+	Unsubscribe()
+}
+
+type runBroadcaster struct {
+	orm         *orm.ORM
+	mutex       sync.RWMutex
+	subscribers map[*runSubscription]struct{}
+}
+
+// NewRunBroadcaster returns a RunBroadcaster for runs saved through the given
+// ORM.
+func NewRunBroadcaster(orm *orm.ORM) RunBroadcaster {
+	return &runBroadcaster{
+		orm:         orm,
+		subscribers: make(map[*runSubscription]struct{}),
+	}
+}
+
+// Start listens to job runs as their creates and updates are committed, so
+// that runs rolled back are never broadcast.
+func (rb *runBroadcaster) Start() error {
+	rb.orm.SetJobRunListener(rb.broadcast)
+	return nil
+}
+
+// Stop stops listening to job runs and closes all subscriptions.
+func (rb *runBroadcaster) Stop() error {
+	rb.orm.SetJobRunListener(nil)
+
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+	for sub := range rb.subscribers {
+		delete(rb.subscribers, sub)
+		close(sub.updates)
+	}
+	return nil
+}
+
+// Subscribe returns a subscription to runs of the given job and/or the given
+// run. Nil filters match everything.
+func (rb *runBroadcaster) Subscribe(jobSpecID, runID *models.ID) RunSubscription {
+	sub := &runSubscription{
+		broadcaster: rb,
+		jobSpecID:   jobSpecID,
+		runID:       runID,
+		updates:     make(chan models.JobRun, runSubscriptionBufferSize),
+	}
+
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+	rb.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (rb *runBroadcaster) unsubscribe(sub *runSubscription) {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+	if _, ok := rb.subscribers[sub]; ok {
+		delete(rb.subscribers, sub)
+		close(sub.updates)
+	}
+}
+
+func (rb *runBroadcaster) broadcast(run models.JobRun) {
+	// The caller keeps mutating its task runs, so subscribers get their own.
+	run.TaskRuns = append([]models.TaskRun(nil), run.TaskRuns...)
+
+	rb.mutex.RLock()
+	defer rb.mutex.RUnlock()
+	for sub := range rb.subscribers {
+		if !sub.matches(run) {
+			continue
+		}
+		select {
+		case sub.updates <- run:
+		default:
+			logger.Warnw("Run subscriber is not keeping up, dropping update", run.ForLogger()...)
+		}
+	}
+}
+
+type runSubscription struct {
+	broadcaster *runBroadcaster
+	jobSpecID   *models.ID
+	runID       *models.ID
+	updates     chan models.JobRun
+}
+
+func (sub *runSubscription) matches(run models.JobRun) bool {
+	if sub.jobSpecID != nil && (run.JobSpecID == nil || *sub.jobSpecID != *run.JobSpecID) {
+		return false
+	}
+	if sub.runID != nil && (run.ID == nil || *sub.runID != *run.ID) {
+		return false
+	}
+	return true
+}
+
+// Updates returns the channel of matching runs. It is closed when the
+// subscription ends.
+func (sub *runSubscription) Updates() <-chan models.JobRun {
+	return sub.updates
+}
+
+// Unsubscribe stops delivery of updates and closes the Updates channel.
+func (sub *runSubscription) Unsubscribe() {
+	sub.broadcaster.unsubscribe(sub)
+}
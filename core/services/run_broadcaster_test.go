@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBroadcaster_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	broadcaster := services.NewRunBroadcaster(store.ORM)
+	require.NoError(t, broadcaster.Start())
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	all := broadcaster.Subscribe(nil, nil)
+	filtered := broadcaster.Subscribe(job.ID, nil)
+
+	otherRun := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&otherRun))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+	stale := run
+	run.Status = models.RunStatusCompleted
+	require.NoError(t, store.SaveJobRun(&run))
+
+	// Saves that are not committed are not broadcast
+	stale.Status = models.RunStatusErrored
+	require.Error(t, store.SaveJobRun(&stale))
+
+	assertRunUpdate(t, all.Updates(), otherRun.ID, models.RunStatusInProgress)
+	assertRunUpdate(t, all.Updates(), run.ID, models.RunStatusInProgress)
+	assertRunUpdate(t, all.Updates(), run.ID, models.RunStatusCompleted)
+	assertRunUpdate(t, filtered.Updates(), run.ID, models.RunStatusInProgress)
+	assertRunUpdate(t, filtered.Updates(), run.ID, models.RunStatusCompleted)
+	assert.Len(t, filtered.Updates(), 0)
+
+	filtered.Unsubscribe()
+	_, open := <-filtered.Updates()
+	assert.False(t, open)
+
+	require.NoError(t, broadcaster.Stop())
+	_, open = <-all.Updates()
+	assert.False(t, open)
+}
+
+func assertRunUpdate(t *testing.T, updates <-chan models.JobRun, id *models.ID, status models.RunStatus) {
+	t.Helper()
+
+	select {
+	case run := <-updates:
+		assert.Equal(t, id, run.ID)
+		assert.Equal(t, status, run.Status)
+	default:
+		t.Fatalf("no update for run %s to be %s", id, status)
+	}
+}
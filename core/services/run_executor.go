@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"chainlink/core/adapters"
@@ -19,11 +20,13 @@ import (
 // RunExecutor handles the actual running of the job tasks
 type RunExecutor interface {
 	Execute(*models.ID) error
+	OnRunFinished(func(*models.JobRun))
 }
 
 type runExecutor struct {
 	store       *store.Store
 	statsPusher synchronization.StatsPusher
+	runFinished func(*models.JobRun)
 }
 
 // NewRunExecutor initializes a RunExecutor.
@@ -31,9 +34,17 @@ func NewRunExecutor(store *store.Store, statsPusher synchronization.StatsPusher)
 	return &runExecutor{
 		store:       store,
 		statsPusher: statsPusher,
+		runFinished: func(*models.JobRun) {},
 	}
 }
 
+// OnRunFinished sets the callback for each run the executor finishes, such as
+// the RunManager's RunFinished, which cannot be passed to NewRunExecutor as
+// the RunManager is built from the executor's RunQueue.
+func (re *runExecutor) OnRunFinished(callback func(*models.JobRun)) {
+	re.runFinished = callback
+}
+
 // Execute performs the work associate with a job run
 func (re *runExecutor) Execute(runID *models.ID) error {
 	run, err := re.store.Unscoped().FindJobRun(runID)
@@ -55,7 +66,7 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 		if meetsMinimumConfirmations(&run, taskRun, run.ObservedHeight) {
 			start := time.Now()
 
-			result := re.executeTask(&run, taskRun)
+			result := executeTask(&run, taskRun, re.store)
 
 			taskRun.ApplyOutput(result)
 			run.ApplyOutput(result)
@@ -89,22 +100,37 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 		} else {
 			logger.Debugw("All tasks complete for run", run.ForLogger()...)
 		}
+		re.runFinished(&run)
 	}
 	return nil
 }
 
-func (re *runExecutor) executeTask(run *models.JobRun, taskRun *models.TaskRun) models.RunOutput {
+func executeTask(run *models.JobRun, taskRun *models.TaskRun, store *store.Store) models.RunOutput {
+	input, adapter, err := prepareTask(run, taskRun, store)
+	if err != nil {
+		return models.NewRunOutputError(err)
+	}
+	return adapter.Perform(*input, store)
+}
+
+// prepareTask resolves the adapter for the task and builds its input from the
+// run's request params and the previous task's result.
+func prepareTask(run *models.JobRun, taskRun *models.TaskRun, store *store.Store) (*models.RunInput, *adapters.PipelineAdapter, error) {
 	taskCopy := taskRun.TaskSpec // deliberately copied to keep mutations local
 
-	params, err := models.Merge(run.RunRequest.RequestParams, taskCopy.Params)
+	requestParams, err := taskRequestParams(run, store)
 	if err != nil {
-		return models.NewRunOutputError(err)
+		return nil, nil, err
+	}
+	params, err := models.Merge(requestParams, taskCopy.Params)
+	if err != nil {
+		return nil, nil, err
 	}
 	taskCopy.Params = params
 
-	adapter, err := adapters.For(taskCopy, re.store.Config, re.store.ORM)
+	adapter, err := adapters.For(taskCopy, store.Config, store.ORM)
 	if err != nil {
-		return models.NewRunOutputError(err)
+		return nil, nil, err
 	}
 
 	previousTaskRun := run.PreviousTaskRun()
@@ -116,10 +142,38 @@ func (re *runExecutor) executeTask(run *models.JobRun, taskRun *models.TaskRun)
 
 	data, err := models.Merge(run.RunRequest.RequestParams, previousTaskInput, taskRun.Result.Data)
 	if err != nil {
-		return models.NewRunOutputError(err)
+		return nil, nil, err
 	}
 
-	input := *models.NewRunInput(run.ID, data, taskRun.Status)
-	result := adapter.Perform(input, re.store)
-	return result
+	return models.NewTaskRunInput(run.ID, taskRun.ID, data, taskRun.Status), adapter, nil
+}
+
+// taskRequestParams returns the request params of the run that may be used as
+// params of its tasks. The key a transaction is sent from is never taken from
+// the request, however its fromAddress is cased, so that no requester can pick
+// which of the node's keys signs. It may only be chosen by the task spec, or
+// by the initiator of a flux monitor's runs, which submit from the key the
+// flux monitor checks the aggregator with.
+func taskRequestParams(run *models.JobRun, store *store.Store) (models.JSON, error) {
+	params, err := run.RunRequest.RequestParams.AsMap()
+	if err != nil {
+		return models.JSON{}, err
+	}
+	for key := range params {
+		if strings.EqualFold(key, "fromAddress") {
+			delete(params, key)
+		}
+	}
+	if run.Initiator.Type == models.InitiatorFluxMonitor {
+		oracle := run.Initiator.OracleAddress
+		if oracle == nil {
+			account, err := store.KeyStore.GetFirstAccount()
+			if err != nil {
+				return models.JSON{}, err
+			}
+			oracle = &account.Address
+		}
+		params["fromAddress"] = oracle.Hex()
+	}
+	return models.JSON{}.MultiAdd(params)
 }
@@ -37,7 +37,8 @@ var (
 
 // RecurringScheduleJobError contains the field for the error message.
 type RecurringScheduleJobError struct {
-	msg string
+	msg       string
+	temporary bool
 }
 
 // Error returns the error message for the run.
@@ -45,6 +46,12 @@ func (err RecurringScheduleJobError) Error() string {
 	return err.msg
 }
 
+// Temporary is true if the job may accept the run later, as it would once
+// resumed, approved, started or done being updated.
+func (err RecurringScheduleJobError) Temporary() bool {
+	return err.temporary
+}
+
 //go:generate mockery -name RunManager -output ../internal/mocks/ -case=underscore
 
 // RunManager supplies methods for queueing, resuming and cancelling jobs in
@@ -67,6 +74,8 @@ type RunManager interface {
 	ResumeAllInProgress() error
 	ResumeAllConfirming(currentBlockHeight *big.Int) error
 	ResumeAllConnecting() error
+	ResumeAllQueued(jobSpecID *models.ID) error
+	RunFinished(run *models.JobRun)
 }
 
 // runManager implements RunManager
@@ -112,6 +121,7 @@ func NewRun(
 	run := models.JobRun{
 		ID:             models.NewID(),
 		JobSpecID:      job.ID,
+		JobSpecVersion: job.Version,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 		Initiator:      *initiator,
@@ -205,15 +215,20 @@ func (rm *runManager) CreateErrored(
 
 	now := time.Now()
 	run := models.JobRun{
-		ID:          models.NewID(),
-		JobSpecID:   job.ID,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-		InitiatorID: initiator.ID,
+		ID:             models.NewID(),
+		JobSpecID:      job.ID,
+		JobSpecVersion: job.Version,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		InitiatorID:    initiator.ID,
 	}
 	run.SetError(runErr)
 	defer rm.statsPusher.PushNow()
-	return &run, rm.orm.CreateJobRun(&run)
+	if err := rm.orm.CreateJobRun(&run); err != nil {
+		return &run, err
+	}
+	rm.RunFinished(&run)
+	return &run, nil
 }
 
 // Create immediately persists a JobRun and sends it to the RunQueue for
@@ -240,10 +255,41 @@ func (rm *runManager) Create(
 		}
 	}
 
+	// The initiators of the version the job was updated from run it until the
+	// new version's have taken over from them and they are retired
+	if initiator.ID != 0 && !job.HasInitiator(initiator.ID) && rm.orm.IsInitiatorRetired(initiator.ID) {
+		return nil, RecurringScheduleJobError{
+			msg:       fmt.Sprintf("Trying to run job %s from initiator %d, superseded by version %d", job.ID, initiator.ID, job.Version),
+			temporary: true,
+		}
+	}
+
+	if job.Proposed() {
+		return nil, RecurringScheduleJobError{
+			msg:       fmt.Sprintf("Trying to run job %s, awaiting approval of version %d", job.ID, job.Version),
+			temporary: true,
+		}
+	}
+
+	if job.Paused() {
+		if !initiator.IsLogInitiated() {
+			return nil, RecurringScheduleJobError{
+				msg:       fmt.Sprintf("Trying to run paused job %s", job.ID),
+				temporary: true,
+			}
+		}
+		if rm.config.PausedJobRunLogPolicy() == orm.PausedRunPolicyDrop {
+			return nil, RecurringScheduleJobError{
+				msg: fmt.Sprintf("Dropping request to paused job %s", job.ID),
+			}
+		}
+	}
+
 	now := rm.clock.Now()
 	if !job.Started(now) {
 		return nil, RecurringScheduleJobError{
-			msg: fmt.Sprintf("Job runner: Job %v unstarted: %v before job's start time %v", job.ID, now, job.EndAt),
+			msg:       fmt.Sprintf("Job runner: Job %v unstarted: %v before job's start time %v", job.ID, now, job.EndAt),
+			temporary: true,
 		}
 	}
 
@@ -261,22 +307,57 @@ func (rm *runManager) Create(
 	runCost := runCost(&job, rm.config, adapters)
 	ValidateRun(run, runCost)
 
-	if err := rm.orm.CreateJobRun(run); err != nil {
-		return nil, errors.Wrap(err, "CreateJobRun failed")
+	queued := false
+	// The job is locked while its limits are checked, so that runs created
+	// at once cannot all be admitted under the same consumption
+	err = rm.orm.Transaction(func(tx *orm.ORM) error {
+		if run.Status.Runnable() && job.Limited() {
+			if err := tx.LockJobSpec(job.ID); err != nil {
+				return errors.Wrap(err, "LockJobSpec failed")
+			}
+			consumption, err := JobConsumption(&job, tx, now)
+			if err != nil {
+				return errors.Wrap(err, "JobConsumption failed")
+			}
+			ValidateRunLimits(run, &job, consumption)
+		}
+
+		if job.Paused() && !run.Status.Errored() {
+			if rm.config.PausedJobRunLogPolicy() == orm.PausedRunPolicyQueue {
+				logger.Debugw("Queueing run of paused job", run.ForLogger()...)
+				run.Status = models.RunStatusUnstarted
+				queued = true
+			} else {
+				run.SetError(fmt.Errorf("Rejecting run of job %s: job is paused", job.ID))
+			}
+		}
+
+		return errors.Wrap(tx.CreateJobRun(run), "CreateJobRun failed")
+	})
+	if err != nil {
+		return nil, err
 	}
 	rm.statsPusher.PushNow()
 
-	if run.Status.Runnable() {
+	if run.Status.Runnable() && !queued {
 		logger.Debugw(
 			fmt.Sprintf("Executing run originally initiated by %s", run.Initiator.Type),
 			run.ForLogger()...,
 		)
 		rm.runQueue.Run(run)
 		numberRunsExecuted.Inc()
+	} else if run.Status.Finished() {
+		rm.RunFinished(run)
 	}
 	return run, nil
 }
 
+// RunFinished triggers the runs of the jobs chained to the finished run's job
+// by their jobrun initiators.
+func (rm *runManager) RunFinished(run *models.JobRun) {
+	TriggerChainedRuns(rm, rm.orm, run)
+}
+
 // ResumeAllConfirming wakes up all jobs that were sleeping because they were
 // waiting for block confirmations.
 func (rm *runManager) ResumeAllConfirming(currentBlockHeight *big.Int) error {
@@ -366,6 +447,27 @@ func (rm *runManager) ResumeAllInProgress() error {
 	return rm.orm.UnscopedJobRunsWithStatus(rm.runQueue.Run, models.RunStatusInProgress, models.RunStatusPendingSleep)
 }
 
+// ResumeAllQueued starts the runs of a job that were queued while it was
+// paused, oldest first.
+func (rm *runManager) ResumeAllQueued(jobSpecID *models.ID) error {
+	runs, err := rm.orm.QueuedJobRuns(jobSpecID)
+	if err != nil {
+		return err
+	}
+
+	for i := range runs {
+		run := &runs[i]
+		run.Status = models.RunStatusInProgress
+		if err := rm.orm.SaveJobRun(run); err != nil {
+			return errors.Wrapf(err, "unable to start queued run %s", run.ID)
+		}
+		logger.Debugw("Executing run queued while job was paused", run.ForLogger()...)
+		rm.runQueue.Run(run)
+		numberRunsExecuted.Inc()
+	}
+	return nil
+}
+
 // Cancel suspends a running task.
 func (rm *runManager) Cancel(runID *models.ID) (*models.JobRun, error) {
 	run, err := rm.orm.FindJobRun(runID)
@@ -393,6 +495,7 @@ func (rm *runManager) updateWithError(run *models.JobRun, msg string, args ...in
 		return err
 	}
 	rm.statsPusher.PushNow()
+	rm.RunFinished(run)
 	return nil
 }
 
@@ -405,6 +508,8 @@ func (rm *runManager) updateAndTrigger(run *models.JobRun) error {
 	if run.Status == models.RunStatusInProgress {
 		numberRunsResumed.Inc()
 		rm.runQueue.Run(run)
+	} else if run.Status.Finished() {
+		rm.RunFinished(run)
 	}
 	return nil
 }
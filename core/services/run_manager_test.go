@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -310,6 +311,228 @@ func TestRunManager_Create(t *testing.T) {
 	assert.Equal(t, rr.RequestID, updatedJR.RunRequest.RequestID)
 }
 
+func TestRunManager_Create_RateLimited(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+
+	store := app.Store
+
+	app.StartAndConnect()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "NoOp")}
+	job.MaxRuns = 1
+	job.MaxRunsWindow = models.Duration(time.Hour)
+	require.NoError(t, store.CreateJob(&job))
+
+	initiator := job.Initiators[0]
+	jr, err := app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+	require.NoError(t, err)
+	cltest.WaitForJobRunToComplete(t, store, *jr)
+
+	jr, err = app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusErrored, jr.Status)
+	assert.Contains(t, jr.Result.ErrorMessage.String, "rate limit of 1 runs per 1h0m0s reached")
+
+	consumption, err := services.JobConsumption(&job, store.ORM, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), consumption.Runs)
+}
+
+func TestRunManager_Create_RateLimitedConcurrently(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+
+	store := app.Store
+
+	app.StartAndConnect()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "NoOp")}
+	job.MaxRuns = 2
+	job.MaxRunsWindow = models.Duration(time.Hour)
+	require.NoError(t, store.CreateJob(&job))
+
+	initiator := job.Initiators[0]
+	var wg sync.WaitGroup
+	runs := make(chan *models.JobRun, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jr, err := app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+			assert.NoError(t, err)
+			runs <- jr
+		}()
+	}
+	wg.Wait()
+	close(runs)
+
+	admitted := 0
+	for jr := range runs {
+		if jr != nil && !jr.Status.Errored() {
+			admitted++
+		}
+	}
+	assert.Equal(t, 2, admitted)
+}
+
+func TestRunManager_Create_SupersededInitiator(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+
+	store := app.Store
+
+	app.StartAndConnect()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	initiator := job.Initiators[0]
+
+	update := cltest.NewJobWithWebInitiator()
+	update.ID = job.ID
+	require.NoError(t, app.UpdateJob(update))
+
+	_, err := app.RunManager.Create(job.ID, &initiator, nil, &models.RunRequest{})
+	require.Error(t, err)
+	assert.True(t, services.ExpectedRecurringScheduleJobError(err))
+	assert.True(t, services.TemporaryRecurringScheduleJobError(err))
+
+	updated, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	jr, err := app.RunManager.Create(job.ID, &updated.Initiators[0], nil, &models.RunRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), jr.JobSpecVersion)
+	cltest.WaitForJobRunToComplete(t, store, *jr)
+}
+
+func TestRunManager_RunFinished_TriggersChainedJobs(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+
+	store := app.Store
+
+	app.StartAndConnect()
+
+	upstream := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&upstream))
+
+	onCompleted := cltest.NewJob()
+	onCompleted.Initiators = []models.Initiator{{
+		Type:            models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{JobID: upstream.ID},
+	}}
+	require.NoError(t, store.CreateJob(&onCompleted))
+
+	onErrored := cltest.NewJob()
+	onErrored.Initiators = []models.Initiator{{
+		Type: models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{
+			JobID:    upstream.ID,
+			Statuses: models.RunStatusCollection{models.RunStatusErrored},
+		},
+	}}
+	require.NoError(t, store.CreateJob(&onErrored))
+
+	data := cltest.JSONFromString(t, `{"result":"100"}`)
+	jr, err := app.RunManager.Create(upstream.ID, &upstream.Initiators[0], nil, models.NewRunRequest(data))
+	require.NoError(t, err)
+	cltest.WaitForJobRunToComplete(t, store, *jr)
+
+	runs := cltest.WaitForRuns(t, onCompleted, store, 1)
+	run, err := store.FindJobRun(runs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "100", run.RunRequest.RequestParams.Get("result").String())
+	assert.Equal(t, jr.ID.String(), run.RunRequest.RequestParams.Get("upstream.runId").String())
+	assert.Equal(t, "completed", run.RunRequest.RequestParams.Get("upstream.status").String())
+
+	cltest.WaitForRuns(t, onErrored, store, 0)
+}
+
+func TestRunManager_Create_Paused(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
+	defer cleanup()
+
+	store := app.Store
+
+	app.StartAndConnect()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	require.NoError(t, app.PauseJob(job.ID))
+
+	_, err := app.RunManager.Create(job.ID, &job.Initiators[0], nil, &models.RunRequest{})
+	require.Error(t, err)
+	assert.True(t, services.ExpectedRecurringScheduleJobError(err))
+	assert.True(t, services.TemporaryRecurringScheduleJobError(err))
+
+	require.NoError(t, app.ResumeJob(job.ID))
+	jr, err := app.RunManager.Create(job.ID, &job.Initiators[0], nil, &models.RunRequest{})
+	require.NoError(t, err)
+	cltest.WaitForJobRunToComplete(t, store, *jr)
+}
+
+func TestRunManager_Create_PausedLogInitiatorPolicies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy     string
+		wantError  bool
+		wantStatus models.RunStatus
+	}{
+		{"drop", true, ""},
+		{"queue", false, models.RunStatusUnstarted},
+		{"error", false, models.RunStatusErrored},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.policy, func(t *testing.T) {
+			config, cfgCleanup := cltest.NewConfig(t)
+			defer cfgCleanup()
+			config.Set("PAUSED_JOB_RUNLOG_POLICY", test.policy)
+			app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.EthMockRegisterChainID)
+			defer cleanup()
+
+			app.StartAndConnect()
+
+			job := cltest.NewJobWithLogInitiator()
+			require.NoError(t, app.Store.CreateJob(&job))
+			require.NoError(t, app.Store.PauseJob(job.ID))
+
+			jr, err := app.RunManager.Create(job.ID, &job.Initiators[0], nil, &models.RunRequest{})
+			if test.wantError {
+				require.Error(t, err)
+				assert.True(t, services.ExpectedRecurringScheduleJobError(err))
+				assert.False(t, services.TemporaryRecurringScheduleJobError(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantStatus, jr.Status)
+
+			queued, err := app.Store.QueuedJobRuns(job.ID)
+			require.NoError(t, err)
+			if test.wantStatus != models.RunStatusUnstarted {
+				assert.Len(t, queued, 0)
+				return
+			}
+			require.Len(t, queued, 1)
+
+			require.NoError(t, app.Store.ResumeJob(job.ID))
+			require.NoError(t, app.RunManager.ResumeAllQueued(job.ID))
+			run, err := app.Store.FindJobRun(jr.ID)
+			require.NoError(t, err)
+			assert.NotEqual(t, models.RunStatusUnstarted, run.Status)
+		})
+	}
+}
+
 func TestRunManager_Create_DoesNotSaveToTaskSpec(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.EthMockRegisterChainID)
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -59,11 +60,55 @@ func (s *Scheduler) Start() error {
 	s.started = true
 
 	return s.store.Jobs(func(j *models.JobSpec) bool {
+		if j.Proposed() {
+			return true
+		}
 		s.addJob(j)
+		if !j.Paused() {
+			s.catchUp(*j)
+		}
 		return true
 	}, models.InitiatorCron, models.InitiatorRunAt)
 }
 
+// catchUp runs the job's cron initiators for the times they were due while
+// the node was down, since the last cron run of the job, by their catch up
+// policy. The last run may be of an earlier version of the job.
+func (s *Scheduler) catchUp(job models.JobSpec) {
+	now := time.Now()
+	for _, initr := range job.InitiatorsFor(models.InitiatorCron) {
+		limit := MaxCronCatchUp
+		switch initr.CatchUp {
+		case models.CronCatchUpLatest:
+			limit = 1
+		case models.CronCatchUpAll:
+		default:
+			continue
+		}
+
+		lastRun, err := s.store.LastJobRunCreatedAt(job.ID, models.InitiatorCron)
+		if err != nil {
+			logger.Errorw("Error finding last cron run to catch up from", "job", job.ID, "error", err)
+			continue
+		} else if !lastRun.Valid {
+			continue
+		}
+		schedule, err := models.NewCronSchedule(initr)
+		if err != nil {
+			logger.Errorw("Error catching up on cron runs", "job", job.ID, "error", err)
+			continue
+		}
+
+		missed := schedule.Between(lastRun.Time, now, limit)
+		if len(missed) > 0 {
+			logger.Infow(fmt.Sprintf("Catching up on %d missed cron runs", len(missed)), "job", job.ID, "since", lastRun.Time)
+		}
+		for _, scheduledAt := range missed {
+			s.Recurring.run(job, initr, scheduledAt)
+		}
+	}
+}
+
 // Stop is the governing function for both Recurring and OneTime
 // Stop function. Sets the started field to false.
 func (s *Scheduler) Stop() {
@@ -92,6 +137,39 @@ func (s *Scheduler) AddJob(job models.JobSpec) {
 	s.addJob(&job)
 }
 
+// RemoveJob stops scheduling the job's cron initiators, such as those of a
+// version awaiting approval.
+func (s *Scheduler) RemoveJob(jobID *models.ID) {
+	s.startedMutex.RLock()
+	defer s.startedMutex.RUnlock()
+	if !s.started {
+		return
+	}
+	s.Recurring.RemoveJob(jobID)
+}
+
+// ResumeJob runs the job's runat initiators that came due while it was
+// paused. Its cron initiators kept their schedule, and are no longer rejected.
+func (s *Scheduler) ResumeJob(job models.JobSpec) {
+	s.startedMutex.RLock()
+	defer s.startedMutex.RUnlock()
+	if !s.started {
+		return
+	}
+
+	now := time.Now()
+	for _, initiator := range job.InitiatorsFor(models.InitiatorRunAt) {
+		if initiator.Ran || !initiator.Time.Valid || initiator.Time.Time.After(now) {
+			continue
+		}
+		go s.OneTime.RunJobAt(initiator, job)
+	}
+}
+
+// MaxCronCatchUp is the most missed times a cron initiator is run for when
+// catching up on all of them.
+const MaxCronCatchUp = 100
+
 // Recurring is used for runs that need to execute on a schedule,
 // and is configured with cron.
 // Instances of Recurring must be initialized using NewRecurring().
@@ -99,12 +177,16 @@ type Recurring struct {
 	Cron       Cron
 	Clock      utils.Nower
 	runManager RunManager
+
+	schedulesMutex sync.Mutex
+	schedules      map[models.ID][]*removableSchedule
 }
 
 // NewRecurring create a new instance of Recurring, ready to use.
 func NewRecurring(runManager RunManager) *Recurring {
 	return &Recurring{
 		runManager: runManager,
+		schedules:  make(map[models.ID][]*removableSchedule),
 	}
 }
 
@@ -122,12 +204,23 @@ func (r *Recurring) Stop() {
 }
 
 // AddJob looks for "cron" initiators, adds them to cron's schedule
-// for execution when specified.
+// for execution when specified. Those of a version the job was updated from
+// are removed from the schedule once the new version's are on it.
 func (r *Recurring) AddJob(job models.JobSpec) {
+	schedules := []*removableSchedule{}
 	for _, initr := range job.InitiatorsFor(models.InitiatorCron) {
-		r.Cron.AddFunc(string(initr.Schedule), func() {
+		initr := initr
+		schedule, err := models.NewCronSchedule(initr)
+		if err != nil {
+			logger.Errorw("Error scheduling cron initiator", "job", job.ID, "error", err)
+			continue
+		}
+		removable := &removableSchedule{Schedule: schedule}
+		schedules = append(schedules, removable)
+
+		r.Cron.Schedule(removable, cron.FuncJob(func() {
 			now := time.Now()
-			if !job.Started(now) || job.Ended(now) {
+			if removable.removed.Get() || !job.Started(now) || job.Ended(now) {
 				return
 			}
 
@@ -135,7 +228,50 @@ func (r *Recurring) AddJob(job models.JobSpec) {
 			if err != nil && !ExpectedRecurringScheduleJobError(err) {
 				logger.Errorw(err.Error())
 			}
-		})
+		}))
+	}
+
+	r.schedulesMutex.Lock()
+	defer r.schedulesMutex.Unlock()
+	r.removeSchedules(job.ID)
+	if len(schedules) > 0 {
+		r.schedules[*job.ID] = schedules
+	}
+}
+
+// RemoveJob removes the job's cron initiators from cron's schedule.
+func (r *Recurring) RemoveJob(jobID *models.ID) {
+	r.schedulesMutex.Lock()
+	defer r.schedulesMutex.Unlock()
+	r.removeSchedules(jobID)
+}
+
+func (r *Recurring) removeSchedules(jobID *models.ID) {
+	for _, schedule := range r.schedules[*jobID] {
+		schedule.removed.Set(true)
+		r.Cron.Remove(schedule)
+	}
+	delete(r.schedules, *jobID)
+}
+
+// removableSchedule is the cron schedule of one of a job's cron initiators.
+// Once removed, it no longer runs the job even if cron already found it due.
+type removableSchedule struct {
+	cron.Schedule
+	removed utils.AtomicBool
+}
+
+// run creates a run of the job for a time its cron initiator was due and
+// missed, with the time as scheduledAt in its request.
+func (r *Recurring) run(job models.JobSpec, initr models.Initiator, scheduledAt time.Time) {
+	requestParams, err := models.JSON{}.Add("scheduledAt", scheduledAt.Format(time.RFC3339))
+	if err != nil {
+		logger.Errorw(err.Error())
+		return
+	}
+	_, err = r.runManager.Create(job.ID, &initr, nil, models.NewRunRequest(requestParams))
+	if err != nil && !ExpectedRecurringScheduleJobError(err) {
+		logger.Errorw(err.Error())
 	}
 }
 
@@ -202,24 +338,93 @@ func ExpectedRecurringScheduleJobError(err error) bool {
 	}
 }
 
+// TemporaryRecurringScheduleJobError is true if the error is a
+// RecurringScheduleJobError refusing a run the job may accept later.
+func TemporaryRecurringScheduleJobError(err error) bool {
+	e, ok := errors.Cause(err).(RecurringScheduleJobError)
+	return ok && e.Temporary()
+}
+
 // Cron is an interface for scheduling recurring functions to run.
 // Cron's schedule format is similar to the standard cron format
 // but with an extra field at the beginning for seconds.
 type Cron interface {
 	Start()
 	Stop()
-	AddFunc(string, func()) error
+	Schedule(cron.Schedule, cron.Job)
+	Remove(cron.Schedule)
+}
+
+type chainlinkCronEntry struct {
+	schedule cron.Schedule
+	job      cron.Job
 }
 
+// chainlinkCron is a cron that can remove its entries. The underlying cron
+// cannot, so it is replaced by one with the remaining entries instead.
 type chainlinkCron struct {
-	*cron.Cron
+	mutex   sync.Mutex
+	cron    *cron.Cron
+	retired []*cron.Cron
+	entries []chainlinkCronEntry
+	started bool
 }
 
 func newChainlinkCron() *chainlinkCron {
-	return &chainlinkCron{cron.New()}
+	return &chainlinkCron{cron: cron.New()}
+}
+
+func (cc *chainlinkCron) Start() {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.started = true
+	cc.cron.Start()
 }
 
+// Stop stops the cron and waits for running jobs to finish, including those
+// started before entries were removed.
 func (cc *chainlinkCron) Stop() {
-	cc.Cron.Stop()
-	cc.Cron.Wait()
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.started = false
+	cc.cron.Stop()
+	cc.cron.Wait()
+	for _, retired := range cc.retired {
+		retired.Wait()
+	}
+	cc.retired = nil
+}
+
+func (cc *chainlinkCron) Schedule(schedule cron.Schedule, job cron.Job) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.entries = append(cc.entries, chainlinkCronEntry{schedule, job})
+	cc.cron.Schedule(schedule, job)
+}
+
+// Remove removes the entries of the schedule.
+func (cc *chainlinkCron) Remove(schedule cron.Schedule) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	remaining := cc.entries[:0]
+	for _, entry := range cc.entries {
+		if entry.schedule != schedule {
+			remaining = append(remaining, entry)
+		}
+	}
+	if len(remaining) == len(cc.entries) {
+		return
+	}
+	cc.entries = remaining
+
+	cc.cron.Stop()
+	cc.retired = append(cc.retired, cc.cron)
+	cc.cron = cron.New()
+	for _, entry := range cc.entries {
+		cc.cron.Schedule(entry.schedule, entry.job)
+	}
+	if cc.started {
+		cc.cron.Start()
+	}
 }
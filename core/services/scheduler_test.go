@@ -7,6 +7,7 @@ import (
 	"chainlink/core/internal/cltest"
 	"chainlink/core/internal/mocks"
 	"chainlink/core/services"
+	"chainlink/core/store/models"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -48,6 +49,90 @@ func TestScheduler_Start_LoadingRecurringJobs(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestScheduler_Start_CatchesUpMissedCronRuns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		catchUp string
+		runs    int
+	}{
+		{"none", models.CronCatchUpNone, 0},
+		{"latest", models.CronCatchUpLatest, 1},
+		{"all", models.CronCatchUpAll, 3},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore(t)
+			defer cleanup()
+
+			job := cltest.NewJobWithSchedule("0 0 * * * *")
+			job.Initiators[0].Timezone = "UTC"
+			job.Initiators[0].CatchUp = test.catchUp
+			require.NoError(t, store.CreateJob(&job))
+
+			// The last run was just after the hour three hours ago
+			lastHour := time.Now().UTC().Truncate(time.Hour)
+			run := cltest.NewJobRun(job)
+			run.CreatedAt = lastHour.Add(-3*time.Hour + time.Second)
+			require.NoError(t, store.CreateJobRun(&run))
+
+			runManager := new(mocks.RunManager)
+			if test.runs > 0 {
+				runManager.On("Create", job.ID, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).
+					Times(test.runs)
+			}
+
+			sched := services.NewScheduler(store, runManager)
+			require.NoError(t, sched.Start())
+			sched.Stop()
+
+			runManager.AssertExpectations(t)
+			if test.runs > 0 {
+				calls := runManager.Calls
+				request := calls[len(calls)-1].Arguments.Get(3).(*models.RunRequest)
+				assert.Equal(t, lastHour.Format(time.RFC3339), request.RequestParams.Get("scheduledAt").String())
+			}
+		})
+	}
+}
+
+func TestScheduler_Start_CatchesUpAcrossVersions(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithSchedule("0 0 * * * *")
+	job.Initiators[0].Timezone = "UTC"
+	job.Initiators[0].CatchUp = models.CronCatchUpLatest
+	require.NoError(t, store.CreateJob(&job))
+
+	lastHour := time.Now().UTC().Truncate(time.Hour)
+	run := cltest.NewJobRun(job)
+	run.CreatedAt = lastHour.Add(-3*time.Hour + time.Second)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	// The updated version's initiator has not created a run yet
+	update := job
+	update.Initiators = []models.Initiator{job.Initiators[0]}
+	require.NoError(t, store.UpdateJob(&update))
+
+	runManager := new(mocks.RunManager)
+	runManager.On("Create", job.ID, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	sched := services.NewScheduler(store, runManager)
+	require.NoError(t, sched.Start())
+	sched.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
 func TestRecurring_AddJob(t *testing.T) {
 	executeJobChannel := make(chan struct{}, 1)
 	runManager := new(mocks.RunManager)
@@ -82,6 +167,24 @@ func TestRecurring_AddJob(t *testing.T) {
 	runManager.AssertExpectations(t)
 }
 
+func TestRecurring_RemoveJob(t *testing.T) {
+	runManager := new(mocks.RunManager)
+
+	r := services.NewRecurring(runManager)
+	cron := cltest.NewMockCron()
+	r.Cron = cron
+
+	job := cltest.NewJobWithSchedule("* * * * *")
+	r.AddJob(job)
+	r.RemoveJob(job.ID)
+
+	assert.Empty(t, cron.Entries, "a removed job leaves no entries in cron")
+
+	r.Stop()
+
+	runManager.AssertExpectations(t)
+}
+
 func TestRecurring_AddJob_PastEnd(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
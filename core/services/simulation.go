@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"chainlink/core/logger"
+	"chainlink/core/store"
+	"chainlink/core/store/models"
+)
+
+// SimulateRun executes the job's tasks against the given request data without
+// persisting a run. Adapters with side effects outside of the node, such as
+// ethtx, report what they would have done instead of doing it.
+func SimulateRun(job models.JobSpec, requestData models.JSON, store *store.Store) (*models.RunSimulation, error) {
+	if len(job.Tasks) == 0 {
+		return nil, fmt.Errorf("invariant for job %s: no tasks to simulate", job.ID)
+	}
+
+	initiator := models.Initiator{Type: models.InitiatorWeb}
+	if len(job.Initiators) > 0 {
+		initiator = job.Initiators[0]
+	}
+
+	run, _ := NewRun(&job, &initiator, nil, models.NewRunRequest(requestData), store.Config, store.ORM, time.Now())
+	simulation := &models.RunSimulation{
+		ID:       run.ID,
+		TaskRuns: make([]models.TaskSimulation, len(run.TaskRuns)),
+	}
+	for i, taskRun := range run.TaskRuns {
+		simulation.TaskRuns[i] = models.TaskSimulation{
+			Type:   taskRun.TaskSpec.Type,
+			Params: taskRun.TaskSpec.Params,
+			Status: taskRun.Status,
+		}
+	}
+
+	for i := range run.TaskRuns {
+		taskRun := &run.TaskRuns[i]
+		if !run.Status.Runnable() {
+			break
+		}
+
+		simulation.TaskRuns[i].Input = simulateTask(run, taskRun, nil, store)
+		simulation.TaskRuns[i].Output = taskRun.Result.Data
+		simulation.TaskRuns[i].Status = taskRun.Status
+		simulation.TaskRuns[i].Error = taskRun.Result.ErrorMessage
+		logger.Debugw(fmt.Sprintf("Simulated task %s", taskRun.TaskSpec.Type), run.ForLogger("task", taskRun.ID.String())...)
+	}
+
+	simulation.Status = run.Status
+	simulation.Result = run.Result
+	return simulation, nil
+}
+
+// simulateTask performs a task of an unsaved run without side effects,
+// answering its HTTP requests from recorded responses if any are given. It
+// returns the task's input.
+func simulateTask(run *models.JobRun, taskRun *models.TaskRun, recordedHTTP []models.HTTPAuditRecord, store *store.Store) models.JSON {
+	input, adapter, err := prepareTask(run, taskRun, store)
+	var result models.RunOutput
+	if err != nil {
+		result = models.NewRunOutputError(err)
+	} else {
+		// The task run is never saved, so nothing may be recorded against it
+		input = models.NewReplayRunInput(input.JobRunID(), input.Data(), input.Status(), recordedHTTP)
+		result = adapter.Simulate(*input, store)
+	}
+
+	taskRun.ApplyOutput(result)
+	run.ApplyOutput(result)
+	if input == nil {
+		return models.JSON{}
+	}
+	return input.Data()
+}
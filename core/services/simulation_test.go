@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/internal/mocks"
+	"chainlink/core/services"
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateRun(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	txManager.On("NextActiveAccount").Return(nil)
+	txManager.On("Connected").Return(false)
+	store.TxManager = txManager
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "noop"),
+		cltest.NewTask(t, "ethtx", `{"address":"0x356a04bCe728ba4c62A30294A55E6A8600a320B3"}`),
+	}
+
+	input := cltest.JSONFromString(t, `{"result":"0x1234"}`)
+	simulation, err := services.SimulateRun(job, input, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusCompleted, simulation.Status)
+	require.Len(t, simulation.TaskRuns, 2)
+	assert.Equal(t, "0x1234", simulation.TaskRuns[0].Output.Get("result").String())
+	assert.Equal(t, "0x1234", simulation.TaskRuns[1].Input.Get("result").String())
+	assert.Equal(t,
+		"0x356a04bCe728ba4c62A30294A55E6A8600a320B3",
+		simulation.TaskRuns[1].Output.Get("simulatedTx.to").String())
+	assert.NotEmpty(t, simulation.TaskRuns[1].Output.Get("simulatedTx.gasEstimateError").String())
+
+	count, err := store.ORM.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	txManager.AssertNotCalled(t, "CreateTxWithGas", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSimulateRun_FromAddressRequested(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	txManager := new(mocks.TxManager)
+	txManager.On("NextActiveAccount").Return(nil)
+	txManager.On("Connected").Return(false)
+	store.TxManager = txManager
+
+	requested := cltest.NewAddress()
+	tasks := []models.TaskSpec{
+		cltest.NewTask(t, "ethtx", `{"address":"0x356a04bCe728ba4c62A30294A55E6A8600a320B3"}`),
+	}
+
+	// No request chooses the key a run is sent from, however it is cased
+	for _, key := range []string{"fromAddress", "FromAddress", "FROMADDRESS"} {
+		input := cltest.JSONFromString(t, `{"result":"0x1234","%s":"%s"}`, key, requested.Hex())
+
+		job := cltest.NewJobWithWebInitiator()
+		job.Tasks = tasks
+		simulation, err := services.SimulateRun(job, input, store)
+		require.NoError(t, err)
+		require.Len(t, simulation.TaskRuns, 1)
+		assert.False(t, simulation.TaskRuns[0].Output.Get("simulatedTx.from").Exists(), key)
+
+		// A flux monitor's runs are sent from the key its initiator gives
+		oracle := cltest.NewAddress()
+		job = cltest.NewJobWithFluxMonitorInitiator()
+		job.Initiators[0].OracleAddress = &oracle
+		job.Tasks = tasks
+		simulation, err = services.SimulateRun(job, input, store)
+		require.NoError(t, err)
+		require.Len(t, simulation.TaskRuns, 1)
+		assert.Equal(t, oracle, common.HexToAddress(simulation.TaskRuns[0].Output.Get("simulatedTx.from").String()), key)
+	}
+}
+
+func TestSimulateRun_InvalidTask(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{cltest.NewTask(t, "idonotexist")}
+
+	simulation, err := services.SimulateRun(job, models.JSON{}, store)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusErrored, simulation.Status)
+	assert.Equal(t, "idonotexist is not a supported adapter type", simulation.Result.ErrorMessage.ValueOrZero())
+}
@@ -166,7 +166,9 @@ func runJob(runManager RunManager, le models.LogRequest) {
 	}
 
 	_, err = runManager.Create(jobSpecID, &initiator, le.BlockNumber(), &rr)
-	if err != nil {
+	if err != nil && ExpectedRecurringScheduleJobError(err) {
+		logger.Infow(err.Error(), le.ForLogger()...)
+	} else if err != nil {
 		logger.Errorw(err.Error(), le.ForLogger()...)
 	}
 }
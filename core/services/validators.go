@@ -16,6 +16,7 @@ import (
 	"chainlink/core/utils"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 )
@@ -23,13 +24,7 @@ import (
 // ValidateJob checks the job and its associated Initiators and Tasks for any
 // application logic errors.
 func ValidateJob(j models.JobSpec, store *store.Store) error {
-	fe := models.NewJSONAPIErrors()
-	if j.StartAt.Valid && j.EndAt.Valid && j.StartAt.Time.After(j.EndAt.Time) {
-		fe.Add("StartAt cannot be before EndAt")
-	}
-	if len(j.Initiators) < 1 || len(j.Tasks) < 1 {
-		fe.Add("Must have at least one Initiator and one Task")
-	}
+	fe := validateJobSpec(j)
 	for _, i := range j.Initiators {
 		if err := ValidateInitiator(i, j, store); err != nil {
 			fe.Merge(err)
@@ -40,6 +35,90 @@ func ValidateJob(j models.JobSpec, store *store.Store) error {
 			fe.Merge(err)
 		}
 	}
+	if err := adapters.TypeCheckPipeline(j.Tasks); err != nil {
+		fe.Merge(err)
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+// LintJob checks the job as ValidateJob does, without a store, so that job
+// files can be checked before they are added to a node. Tasks that are not
+// core adapters are assumed to be bridges and reported as warnings, since
+// they cannot be looked up, and the bridges of flux monitor feeds are not
+// checked.
+func LintJob(j models.JobSpec) ([]string, error) {
+	var warnings []string
+	fe := validateJobSpec(j)
+	for _, i := range j.Initiators {
+		if err := ValidateInitiator(i, j, nil); err != nil {
+			fe.Merge(err)
+		}
+	}
+	for n, task := range j.Tasks {
+		_, ok, err := adapters.ForCore(task)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"task %d (%s) is not a core adapter, so it must be the name of a bridge added to the node", n+1, task.Type))
+		} else if err != nil {
+			fe.Add(fmt.Sprintf("task %d (%s) has invalid params: %v", n+1, task.Type, err))
+		}
+	}
+	if err := adapters.TypeCheckPipeline(j.Tasks); err != nil {
+		fe.Merge(err)
+	}
+	return warnings, fe.CoerceEmptyToNil()
+}
+
+func validateJobSpec(j models.JobSpec) *models.JSONAPIErrors {
+	fe := models.NewJSONAPIErrors()
+	if j.StartAt.Valid && j.EndAt.Valid && j.StartAt.Time.After(j.EndAt.Time) {
+		fe.Add("StartAt cannot be before EndAt")
+	}
+	if len(j.Initiators) < 1 || len(j.Tasks) < 1 {
+		fe.Add("Must have at least one Initiator and one Task")
+	}
+	if (j.MaxRuns > 0) != (j.MaxRunsWindow > 0) {
+		fe.Add("MaxRuns and MaxRunsWindow must be set together")
+	}
+	if j.MaxRunsWindow < 0 {
+		fe.Add("MaxRunsWindow must be positive")
+	}
+	if j.MaxDailyGasSpend != nil && j.MaxDailyGasSpend.Cmp(assets.NewEth(0)) <= 0 {
+		fe.Add("MaxDailyGasSpend must be positive")
+	}
+	if len(j.Name) > 255 {
+		fe.Add("Name must be at most 255 characters")
+	}
+	seen := map[string]bool{}
+	for _, label := range j.Labels {
+		if !govalidator.StringMatches(label, "^[a-zA-Z0-9_.:/=-]{1,63}$") {
+			fe.Add(fmt.Sprintf("Label '%s' must be 1 to 63 characters, alphanumeric or one of '-_.:/='", label))
+		} else if seen[label] {
+			fe.Add(fmt.Sprintf("Label '%s' is given more than once", label))
+		}
+		seen[label] = true
+	}
+	return fe
+}
+
+// ValidateSpecTemplate checks the template's name and variables, and that
+// its spec is a JSON object. The jobs created from it are validated as they
+// are created, since they depend on the variables they are given.
+func ValidateSpecTemplate(t models.SpecTemplate, store *store.Store) error {
+	fe := models.NewJSONAPIErrors()
+	if len(t.Name) == 0 {
+		fe.Add("No name specified")
+	} else if !govalidator.StringMatches(t.Name, "^[a-zA-Z0-9-_]*$") {
+		fe.Add("Name must be alphanumeric and may contain '_' or '-'")
+	} else if existing, err := store.FindSpecTemplateByName(t.Name); err == nil && *existing.ID != *t.ID {
+		fe.Add(fmt.Sprintf("Spec template %s already exists", t.Name))
+	}
+	if !t.Spec.IsObject() {
+		fe.Add("Spec must be a JSON object")
+	}
+	if err := t.ValidateVariables(); err != nil {
+		fe.Merge(err)
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -101,7 +180,7 @@ func ValidateInitiator(i models.Initiator, j models.JobSpec, store *store.Store)
 	case models.InitiatorCron:
 		return validateCronInitiator(i)
 	case models.InitiatorExternal:
-		return validateExternalInitiator(i)
+		return validateExternalInitiator(i, j, store)
 	case models.InitiatorServiceAgreementExecutionLog:
 		return validateServiceAgreementInitiator(i, j)
 	case models.InitiatorRunLog:
@@ -114,6 +193,14 @@ func ValidateInitiator(i models.Initiator, j models.JobSpec, store *store.Store)
 		return nil
 	case models.InitiatorRandomnessLog:
 		return validateRandomnessLogInitiator(i, j)
+	case models.InitiatorJobRun:
+		return validateJobRunInitiator(i, j, store)
+	case models.InitiatorBlockInterval:
+		return validateBlockIntervalInitiator(i)
+	case models.InitiatorBalance:
+		return validateBalanceInitiator(i)
+	case models.InitiatorMessageQueue:
+		return validateMessageQueueInitiator(i)
 	default:
 		return models.NewJSONAPIErrorsWith(fmt.Sprintf("type %v does not exist", i.Type))
 	}
@@ -128,9 +215,24 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if i.IdleThreshold != 0 && i.IdleThreshold < i.PollingInterval {
 		fe.Add("idleThreshold must be equal or greater than the pollingInterval")
 	}
-	if i.Threshold <= 0 {
+	if i.Threshold < 0 {
 		fe.Add("bad threshold")
 	}
+	if i.AbsoluteThreshold < 0 {
+		fe.Add("bad absoluteThreshold")
+	}
+	if i.Threshold == 0 && i.AbsoluteThreshold == 0 {
+		fe.Add("bad threshold, either threshold or absoluteThreshold must be set")
+	}
+	switch i.ThresholdMode {
+	case "", fluxmonitor.ThresholdModeEither:
+	case fluxmonitor.ThresholdModeBoth:
+		if i.Threshold == 0 || i.AbsoluteThreshold == 0 {
+			fe.Add("thresholdMode both requires both threshold and absoluteThreshold")
+		}
+	default:
+		fe.Add(fmt.Sprintf("thresholdMode %q does not exist", i.ThresholdMode))
+	}
 	if i.RequestData.String() == "" {
 		fe.Add("no requestdata")
 	}
@@ -142,10 +244,64 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if err := validateFeeds(i.Feeds, store); err != nil {
 		fe.Add(err.Error())
 	}
+	if !fluxmonitor.IsValidAggregation(i.Aggregation) {
+		fe.Add(fmt.Sprintf("aggregation %q does not exist", i.Aggregation))
+	}
+	if i.TrimPercent < 0 || i.TrimPercent >= 50 {
+		fe.Add("trimPercent must be at least 0 and less than 50")
+	}
+	var feeds []interface{}
+	if json.Unmarshal(i.Feeds.Bytes(), &feeds) == nil && int(i.MinFeeds) > len(feeds) {
+		fe.Add("minFeeds must not exceed the number of feeds")
+	}
+	if i.OutlierThreshold < 0 {
+		fe.Add("outlierThreshold must not be negative")
+	}
+	if i.FeedTimeout < 0 || i.FeedTimeout > fluxmonitor.MinimumPollingInterval {
+		fe.Add("feedTimeout must be at least 0 and at most " + fluxmonitor.MinimumPollingInterval.String())
+	}
+	if err := validateGasThresholds(i.GasThresholds); err != nil {
+		fe.Add(err.Error())
+	}
+	if i.GasPriceCeiling != nil && i.GasPriceCeiling.ToInt().Sign() <= 0 {
+		fe.Add("gasPriceCeiling must be greater than 0")
+	}
+	if i.EmergencyThreshold < 0 {
+		fe.Add("emergencyThreshold must not be negative")
+	} else if i.EmergencyThreshold > 0 && i.GasPriceCeiling == nil {
+		fe.Add("emergencyThreshold requires a gasPriceCeiling")
+	}
+	if i.OracleAddress != nil && store != nil && !hasAccount(store, *i.OracleAddress) {
+		fe.Add(fmt.Sprintf("oracleAddress %s is not one of the node's keys", i.OracleAddress.Hex()))
+	}
 
 	return fe.CoerceEmptyToNil()
 }
 
+func hasAccount(store *store.Store, address common.Address) bool {
+	for _, account := range store.KeyStore.GetAccounts() {
+		if account.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+func validateGasThresholds(thresholds models.GasPriceThresholds) error {
+	for i, threshold := range thresholds {
+		if threshold.GasPrice == nil || threshold.GasPrice.ToInt().Sign() < 0 {
+			return errors.New("gasThresholds must each have a gasPrice of at least 0")
+		}
+		if threshold.Threshold < 0 {
+			return errors.New("gasThresholds must not have a negative threshold")
+		}
+		if i > 0 && threshold.GasPrice.ToInt().Cmp(thresholds[i-1].GasPrice.ToInt()) <= 0 {
+			return errors.New("gasThresholds must be in increasing order of gasPrice")
+		}
+	}
+	return nil
+}
+
 func validateFeeds(feeds models.Feeds, store *store.Store) error {
 	var feedsData []interface{}
 	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
@@ -174,6 +330,9 @@ func validateFeeds(feeds models.Feeds, store *store.Store) error {
 			return errors.New("unknown feed type")
 		}
 	}
+	if store == nil {
+		return nil
+	}
 	if _, err := store.ORM.FindBridgesByNames(bridgeNames); err != nil {
 		return err
 	}
@@ -183,11 +342,8 @@ func validateFeeds(feeds models.Feeds, store *store.Store) error {
 
 func validateRunLogInitiator(i models.Initiator, j models.JobSpec) error {
 	fe := models.NewJSONAPIErrors()
-	ethTxCount := 0
 	for _, task := range j.Tasks {
 		if task.Type == adapters.TaskTypeEthTx {
-			ethTxCount += 1
-
 			task.Params.ForEach(func(k, _ gjson.Result) bool {
 				key := strings.ToLower(k.String())
 				if key == "functionselector" {
@@ -199,9 +355,6 @@ func validateRunLogInitiator(i models.Initiator, j models.JobSpec) error {
 			})
 		}
 	}
-	if ethTxCount > 1 {
-		fe.Add("Cannot RunLog initiated jobs cannot have more than one EthTx Task")
-	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -221,13 +374,36 @@ func validateCronInitiator(i models.Initiator) error {
 	if i.Schedule == "" {
 		return models.NewJSONAPIErrorsWith("Schedule must have a cron")
 	}
-	return nil
+	fe := models.NewJSONAPIErrors()
+	if _, err := models.NewCronSchedule(i); err != nil {
+		fe.Add(err.Error())
+	}
+	if i.Jitter < 0 {
+		fe.Add("Cron jitter cannot be negative")
+	}
+	switch i.CatchUp {
+	case "", models.CronCatchUpNone, models.CronCatchUpLatest, models.CronCatchUpAll:
+	default:
+		fe.Add(fmt.Sprintf("Cron catchUp must be one of %s, %s or %s", models.CronCatchUpNone, models.CronCatchUpLatest, models.CronCatchUpAll))
+	}
+	return fe.CoerceEmptyToNil()
 }
 
-func validateExternalInitiator(i models.Initiator) error {
+func validateExternalInitiator(i models.Initiator, j models.JobSpec, store *store.Store) error {
 	if len([]rune(i.Name)) == 0 {
 		return models.NewJSONAPIErrorsWith("External must have a name")
 	}
+	if len(j.InitiatorsFor(models.InitiatorExternal)) > 1 {
+		return models.NewJSONAPIErrorsWith("must have one or less External Initiators")
+	}
+	if store == nil {
+		return nil
+	}
+	if _, err := store.FindExternalInitiatorByName(i.Name); err == orm.ErrorNotFound {
+		return models.NewJSONAPIErrorsWith(fmt.Sprintf("External Initiator %s does not exist", i.Name))
+	} else if err != nil {
+		return errors.Wrap(err, "validating external initiator")
+	}
 	return nil
 }
 
@@ -239,6 +415,94 @@ func validateServiceAgreementInitiator(i models.Initiator, j models.JobSpec) err
 	return fe.CoerceEmptyToNil()
 }
 
+func validateJobRunInitiator(i models.Initiator, j models.JobSpec, store *store.Store) error {
+	fe := models.NewJSONAPIErrors()
+	for _, status := range i.Statuses {
+		if status != models.RunStatusCompleted && status != models.RunStatusErrored {
+			fe.Add(fmt.Sprintf("jobrun initiators can only be triggered by completed or errored runs, not %s", status))
+		}
+	}
+	if i.JobID == nil {
+		fe.Add("jobrun initiator must have a jobId")
+	} else if err := validateChain(i.JobID, j, store); err != nil {
+		fe.Add(err.Error())
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func validateBlockIntervalInitiator(i models.Initiator) error {
+	fe := models.NewJSONAPIErrors()
+	if i.Every == 0 {
+		fe.Add("blockinterval initiator must run every 1 or more heads")
+	} else if i.Offset >= i.Every {
+		fe.Add(fmt.Sprintf("blockinterval offset must be less than every (%d)", i.Every))
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func validateBalanceInitiator(i models.Initiator) error {
+	fe := models.NewJSONAPIErrors()
+	if len(i.Addresses) == 0 {
+		fe.Add("balance initiator must watch at least one address")
+	}
+	if i.BalanceThreshold == nil {
+		fe.Add("balance initiator must have a balanceThreshold")
+	} else if i.BalanceThreshold.ToInt().Sign() < 0 {
+		fe.Add("balance initiator balanceThreshold cannot be negative")
+	}
+	if i.Hysteresis != nil && i.Hysteresis.ToInt().Sign() < 0 {
+		fe.Add("balance initiator hysteresis cannot be negative")
+	}
+	if i.Token != nil && *i.Token == utils.ZeroAddress {
+		fe.Add("balance initiator token must be an ERC20 contract address, or omitted for ETH")
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func validateMessageQueueInitiator(i models.Initiator) error {
+	fe := models.NewJSONAPIErrors()
+	if i.Queue == "" {
+		fe.Add("messagequeue initiator must have a queue")
+	} else if i.Queue == i.DeadLetterQueue {
+		fe.Add("messagequeue initiator cannot dead letter to the queue it consumes")
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+// validateChain walks up the jobs triggering runs of the upstream job, and
+// errors if it does not exist or if they chain back to the job.
+func validateChain(upstream *models.ID, j models.JobSpec, store *store.Store) error {
+	upstreams := []*models.ID{upstream}
+	visited := map[string]bool{}
+	for len(upstreams) > 0 {
+		id := upstreams[0]
+		upstreams = upstreams[1:]
+		if j.ID != nil && id.String() == j.ID.String() {
+			return errors.New("jobrun initiator chains back to this job, creating a loop")
+		}
+		if visited[id.String()] || store == nil {
+			continue
+		}
+		visited[id.String()] = true
+
+		job, err := store.FindJob(id)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			if id == upstream {
+				return fmt.Errorf("jobrun initiator watches job %s, which does not exist", id)
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+		for _, initr := range job.InitiatorsFor(models.InitiatorJobRun) {
+			if initr.JobID != nil {
+				upstreams = append(upstreams, initr.JobID)
+			}
+		}
+	}
+	return nil
+}
+
 func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec) error {
 	fe := models.NewJSONAPIErrors()
 	if len(j.Initiators) != 1 {
@@ -252,6 +516,9 @@ func validateRandomnessLogInitiator(i models.Initiator, j models.JobSpec) error
 
 func validateTask(task models.TaskSpec, store *store.Store) error {
 	adapter, err := adapters.For(task, store.Config, store.ORM)
+	if err != nil {
+		return err
+	}
 	if !store.Config.Dev() {
 		if _, ok := adapter.BaseAdapter.(*adapters.Sleep); ok {
 			return errors.New("Sleep Adapter is not implemented yet")
@@ -260,7 +527,7 @@ func validateTask(task models.TaskSpec, store *store.Store) error {
 			return errors.New("EthTxABIEncode Adapter is not implemented yet")
 		}
 	}
-	return err
+	return nil
 }
 
 // ValidateServiceAgreement checks the ServiceAgreement for any application logic errors.
@@ -3,16 +3,20 @@ package services_test
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	"chainlink/core/adapters"
 	"chainlink/core/assets"
+	"chainlink/core/auth"
 	"chainlink/core/internal/cltest"
 	"chainlink/core/services"
+	"chainlink/core/services/fluxmonitor"
 	"chainlink/core/store/models"
 	"chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,7 +72,12 @@ func TestValidateJob(t *testing.T) {
 		{
 			"runlog with two ethtx tasks",
 			cltest.MustReadFile(t, "testdata/runlog_2_ethlogs_job.json"),
-			models.NewJSONAPIErrorsWith("Cannot RunLog initiated jobs cannot have more than one EthTx Task"),
+			models.NewJSONAPIErrorsWith("task 2 (ethtx) sends a second transaction after the ethtx task; a job can only send one, so split it into separate jobs"),
+		},
+		{
+			"max runs without a window",
+			cltest.MustReadFile(t, "testdata/max_runs_wo_window_job.json"),
+			models.NewJSONAPIErrorsWith("MaxRuns and MaxRunsWindow must be set together"),
 		},
 	}
 
@@ -99,6 +108,58 @@ func TestValidateJob_DevRejectsSleepAdapter(t *testing.T) {
 	assert.Error(t, services.ValidateJob(sleepingJob, store))
 }
 
+func TestValidateJob_Labels(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   error
+	}{
+		{"none", nil, nil},
+		{"valid", []string{"env:prod", "pair=eth/usd", "v1.2_beta"}, nil},
+		{"empty", []string{""}, models.NewJSONAPIErrorsWith("Label '' must be 1 to 63 characters, alphanumeric or one of '-_.:/='")},
+		{"spaces", []string{"two words"}, models.NewJSONAPIErrorsWith("Label 'two words' must be 1 to 63 characters, alphanumeric or one of '-_.:/='")},
+		{"repeated", []string{"prod", "prod"}, models.NewJSONAPIErrorsWith("Label 'prod' is given more than once")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := cltest.NewJobWithWebInitiator()
+			job.Labels = test.labels
+			assert.Equal(t, test.want, services.ValidateJob(job, store))
+		})
+	}
+}
+
+func TestLintJob(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "httpget", `{"get":"https://example.com"}`),
+		cltest.NewTask(t, "prices"),
+		cltest.NewTask(t, "ethtx"),
+	}
+	warnings, err := services.LintJob(job)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"task 2 (prices) is not a core adapter, so it must be the name of a bridge added to the node"}, warnings)
+
+	job.Tasks = []models.TaskSpec{
+		cltest.NewTask(t, "httpget", `{"get":"https://example.com"}`),
+		cltest.NewTask(t, "multiply", `{"times":100}`),
+		cltest.NewTask(t, "ethtx"),
+	}
+	warnings, err = services.LintJob(job)
+	assert.Empty(t, warnings)
+	assert.Equal(t, models.NewJSONAPIErrorsWith(
+		"task 3 (ethtx) cannot take the number result of task 2 (multiply): format the result with an ethbytes32, ethint256, ethuint256 or ethbool task first, or set the ethtx format param",
+	), err)
+}
+
 func TestValidateBridgeType(t *testing.T) {
 	t.Parallel()
 
@@ -273,6 +334,10 @@ func TestValidateInitiator(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
 
+	ei, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{Name: "bitcoin"})
+	require.NoError(t, err)
+	require.NoError(t, store.CreateExternalInitiator(ei))
+
 	startAt := time.Now()
 	endAt := startAt.Add(time.Second)
 	job := cltest.NewJob()
@@ -286,6 +351,7 @@ func TestValidateInitiator(t *testing.T) {
 		{"web", `{"type":"web"}`, false},
 		{"ethlog", `{"type":"ethlog"}`, false},
 		{"external", `{"type":"external","params":{"name":"bitcoin"}}`, false},
+		{"external that does not exist", `{"type":"external","params":{"name":"dogecoin"}}`, true},
 		{"runlog", `{"type":"runlog"}`, false},
 		{"runat", fmt.Sprintf(`{"type":"runat","params": {"time":"%v"}}`, utils.ISO8601UTC(startAt)), false},
 		{"runat w/o time", `{"type":"runat"}`, true},
@@ -386,6 +452,159 @@ const validInitiator = `{
 	}
 }`
 
+func TestValidateInitiator_JobRun(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	upstream := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&upstream))
+
+	downstream := cltest.NewJob()
+	downstream.Initiators = []models.Initiator{{
+		Type:            models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{JobID: upstream.ID},
+	}}
+	require.NoError(t, services.ValidateInitiator(downstream.Initiators[0], downstream, store))
+	require.NoError(t, store.CreateJob(&downstream))
+
+	loop := models.Initiator{
+		Type:            models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{JobID: downstream.ID},
+	}
+	assert.Error(t, services.ValidateInitiator(loop, upstream, store))
+
+	self := models.Initiator{
+		Type:            models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{JobID: upstream.ID},
+	}
+	assert.Error(t, services.ValidateInitiator(self, upstream, store))
+
+	missing := models.Initiator{
+		Type:            models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{JobID: models.NewID()},
+	}
+	assert.Error(t, services.ValidateInitiator(missing, cltest.NewJob(), store))
+
+	badStatus := models.Initiator{
+		Type: models.InitiatorJobRun,
+		InitiatorParams: models.InitiatorParams{
+			JobID:    upstream.ID,
+			Statuses: models.RunStatusCollection{models.RunStatusCancelled},
+		},
+	}
+	assert.Error(t, services.ValidateInitiator(badStatus, cltest.NewJob(), store))
+	assert.Error(t, services.ValidateInitiator(models.Initiator{Type: models.InitiatorJobRun}, cltest.NewJob(), store))
+}
+
+func TestValidateInitiator_Cron(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  models.InitiatorParams
+		wantErr bool
+	}{
+		{"schedule", models.InitiatorParams{Schedule: "0 0 * * * *"}, false},
+		{"all options", models.InitiatorParams{Schedule: "0 0 * * * *", Timezone: "America/New_York", Jitter: models.Duration(time.Minute), CatchUp: models.CronCatchUpAll}, false},
+		{"no schedule", models.InitiatorParams{}, true},
+		{"unknown time zone", models.InitiatorParams{Schedule: "0 0 * * * *", Timezone: "Nowhere"}, true},
+		{"negative jitter", models.InitiatorParams{Schedule: "0 0 * * * *", Jitter: models.Duration(-time.Minute)}, true},
+		{"unknown catch up", models.InitiatorParams{Schedule: "0 0 * * * *", CatchUp: "some"}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			initr := models.Initiator{Type: models.InitiatorCron, InitiatorParams: test.params}
+			err := services.ValidateInitiator(initr, cltest.NewJob(), nil)
+			cltest.AssertError(t, test.wantErr, err)
+		})
+	}
+}
+
+func TestValidateInitiator_Balance(t *testing.T) {
+	t.Parallel()
+
+	address := cltest.NewAddress()
+	token := cltest.NewAddress()
+	zero := common.Address{}
+	threshold := utils.NewBig(big.NewInt(100))
+	negative := utils.NewBig(big.NewInt(-1))
+
+	tests := []struct {
+		name    string
+		params  models.InitiatorParams
+		wantErr bool
+	}{
+		{"eth", models.InitiatorParams{Addresses: models.AddressCollection{address}, BalanceThreshold: threshold}, false},
+		{"erc20", models.InitiatorParams{Addresses: models.AddressCollection{address}, Token: &token, BalanceThreshold: threshold, Hysteresis: threshold}, false},
+		{"no addresses", models.InitiatorParams{BalanceThreshold: threshold}, true},
+		{"no threshold", models.InitiatorParams{Addresses: models.AddressCollection{address}}, true},
+		{"negative threshold", models.InitiatorParams{Addresses: models.AddressCollection{address}, BalanceThreshold: negative}, true},
+		{"negative hysteresis", models.InitiatorParams{Addresses: models.AddressCollection{address}, BalanceThreshold: threshold, Hysteresis: negative}, true},
+		{"zero token", models.InitiatorParams{Addresses: models.AddressCollection{address}, Token: &zero, BalanceThreshold: threshold}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			initr := models.Initiator{Type: models.InitiatorBalance, InitiatorParams: test.params}
+			err := services.ValidateInitiator(initr, cltest.NewJob(), nil)
+			cltest.AssertError(t, test.wantErr, err)
+		})
+	}
+}
+
+func TestValidateInitiator_MessageQueue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  models.InitiatorParams
+		wantErr bool
+	}{
+		{"queue", models.InitiatorParams{Queue: "requests"}, false},
+		{"dead letter queue", models.InitiatorParams{Queue: "requests", DeadLetterQueue: "requests.dead"}, false},
+		{"no queue", models.InitiatorParams{}, true},
+		{"dead letters to itself", models.InitiatorParams{Queue: "requests", DeadLetterQueue: "requests"}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			initr := models.Initiator{Type: models.InitiatorMessageQueue, InitiatorParams: test.params}
+			err := services.ValidateInitiator(initr, cltest.NewJob(), nil)
+			cltest.AssertError(t, test.wantErr, err)
+		})
+	}
+}
+
+func TestValidateInitiator_BlockInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  models.InitiatorParams
+		wantErr bool
+	}{
+		{"every head", models.InitiatorParams{Every: 1}, false},
+		{"with offset", models.InitiatorParams{Every: 10, Offset: 9}, false},
+		{"no interval", models.InitiatorParams{}, true},
+		{"offset past interval", models.InitiatorParams{Every: 10, Offset: 10}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			initr := models.Initiator{Type: models.InitiatorBlockInterval, InitiatorParams: test.params}
+			err := services.ValidateInitiator(initr, cltest.NewJob(), nil)
+			cltest.AssertError(t, test.wantErr, err)
+		})
+	}
+}
+
 func TestValidateInitiator_FluxMonitorHappy(t *testing.T) {
 	t.Parallel()
 
@@ -397,6 +616,35 @@ func TestValidateInitiator_FluxMonitorHappy(t *testing.T) {
 	require.NoError(t, json.Unmarshal([]byte(validInitiator), &initr))
 	err := services.ValidateInitiator(initr, job, store)
 	require.NoError(t, err)
+
+	initr.Aggregation = fluxmonitor.AggregationTrimmedMean
+	initr.TrimPercent = 25
+	initr.MinFeeds = 3
+	initr.OutlierThreshold = 10
+	initr.FeedTimeout = models.Duration(2 * time.Second)
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	initr.Threshold = 0
+	initr.AbsoluteThreshold = 0.01
+	initr.IdleThresholdBlocks = 100
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	initr.Threshold = 0.5
+	initr.ThresholdMode = fluxmonitor.ThresholdModeBoth
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	initr.GasThresholds = models.GasPriceThresholds{
+		{GasPrice: utils.NewBig(big.NewInt(20000000000)), Threshold: 0.5},
+		{GasPrice: utils.NewBig(big.NewInt(100000000000)), Threshold: 2},
+	}
+	initr.GasPriceCeiling = utils.NewBig(big.NewInt(200000000000))
+	initr.EmergencyThreshold = 5
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
+
+	account, err := store.KeyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	initr.OracleAddress = &account.Address
+	require.NoError(t, services.ValidateInitiator(initr, job, store))
 }
 
 func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
@@ -418,6 +666,28 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 		{"pollingInterval", cltest.MustJSONDel(t, validInitiator, "params.pollingInterval")},
 		{"pollingInterval", cltest.MustJSONSet(t, validInitiator, "params.pollingInterval", "1s")},
 		{"idleThreshold", cltest.MustJSONSet(t, validInitiator, "params.idleThreshold", "30s")},
+		{"aggregation", cltest.MustJSONSet(t, validInitiator, "params.aggregation", "average")},
+		{"trimPercent", cltest.MustJSONSet(t, validInitiator, "params.trimPercent", 50)},
+		{"trimPercent", cltest.MustJSONSet(t, validInitiator, "params.trimPercent", -1)},
+		{"minFeeds", cltest.MustJSONSet(t, validInitiator, "params.minFeeds", 4)},
+		{"outlierThreshold", cltest.MustJSONSet(t, validInitiator, "params.outlierThreshold", -1)},
+		{"feedTimeout", cltest.MustJSONSet(t, validInitiator, "params.feedTimeout", "1m")},
+		{"absoluteThreshold", cltest.MustJSONSet(t, validInitiator, "params.absoluteThreshold", -1)},
+		{"thresholdMode", cltest.MustJSONSet(t, validInitiator, "params.thresholdMode", "neither")},
+		{"thresholdMode", cltest.MustJSONSet(t, validInitiator, "params.thresholdMode", "both")},
+		{"gasThresholds", cltest.MustJSONSet(t, validInitiator, "params.gasThresholds", []map[string]interface{}{
+			{"gasPrice": 100, "threshold": 1}, {"gasPrice": 50, "threshold": 2},
+		})},
+		{"gasThresholds", cltest.MustJSONSet(t, validInitiator, "params.gasThresholds", []map[string]interface{}{
+			{"gasPrice": 100, "threshold": -1},
+		})},
+		{"gasThresholds", cltest.MustJSONSet(t, validInitiator, "params.gasThresholds", []map[string]interface{}{
+			{"threshold": 1},
+		})},
+		{"gasPriceCeiling", cltest.MustJSONSet(t, validInitiator, "params.gasPriceCeiling", 0)},
+		{"emergencyThreshold", cltest.MustJSONSet(t, validInitiator, "params.emergencyThreshold", -1)},
+		{"emergencyThreshold", cltest.MustJSONSet(t, validInitiator, "params.emergencyThreshold", 5)},
+		{"oracleAddress", cltest.MustJSONSet(t, validInitiator, "params.oracleAddress", cltest.NewAddress().Hex())},
 	}
 	for _, test := range tests {
 		t.Run("bad "+test.Field, func(t *testing.T) {
@@ -34,6 +34,25 @@ import (
 	"chainlink/core/store/migrations/migration1580904019"
 	"chainlink/core/store/migrations/migration1581240419"
 	"chainlink/core/store/migrations/migration1584377646"
+	"chainlink/core/store/migrations/migration1586163842"
+	"chainlink/core/store/migrations/migration1586342453"
+	"chainlink/core/store/migrations/migration1586369235"
+	"chainlink/core/store/migrations/migration1586437122"
+	"chainlink/core/store/migrations/migration1586512386"
+	"chainlink/core/store/migrations/migration1586618371"
+	"chainlink/core/store/migrations/migration1586704519"
+	"chainlink/core/store/migrations/migration1586790922"
+	"chainlink/core/store/migrations/migration1586877406"
+	"chainlink/core/store/migrations/migration1586963861"
+	"chainlink/core/store/migrations/migration1587050298"
+	"chainlink/core/store/migrations/migration1587136740"
+	"chainlink/core/store/migrations/migration1587222514"
+	"chainlink/core/store/migrations/migration1587308329"
+	"chainlink/core/store/migrations/migration1587395247"
+	"chainlink/core/store/migrations/migration1587481683"
+	"chainlink/core/store/migrations/migration1587563271"
+	"chainlink/core/store/migrations/migration1587650114"
+	"chainlink/core/store/migrations/migration1587736390"
 
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -176,6 +195,82 @@ func MigrateTo(db *gorm.DB, migrationID string) error {
 			ID:      "1584377646",
 			Migrate: migration1584377646.Migrate,
 		},
+		{
+			ID:      "1586163842",
+			Migrate: migration1586163842.Migrate,
+		},
+		{
+			ID:      "1586342453",
+			Migrate: migration1586342453.Migrate,
+		},
+		{
+			ID:      "1586369235",
+			Migrate: migration1586369235.Migrate,
+		},
+		{
+			ID:      "1586437122",
+			Migrate: migration1586437122.Migrate,
+		},
+		{
+			ID:      "1586512386",
+			Migrate: migration1586512386.Migrate,
+		},
+		{
+			ID:      "1586618371",
+			Migrate: migration1586618371.Migrate,
+		},
+		{
+			ID:      "1586704519",
+			Migrate: migration1586704519.Migrate,
+		},
+		{
+			ID:      "1586790922",
+			Migrate: migration1586790922.Migrate,
+		},
+		{
+			ID:      "1586877406",
+			Migrate: migration1586877406.Migrate,
+		},
+		{
+			ID:      "1586963861",
+			Migrate: migration1586963861.Migrate,
+		},
+		{
+			ID:      "1587050298",
+			Migrate: migration1587050298.Migrate,
+		},
+		{
+			ID:      "1587136740",
+			Migrate: migration1587136740.Migrate,
+		},
+		{
+			ID:      "1587222514",
+			Migrate: migration1587222514.Migrate,
+		},
+		{
+			ID:      "1587308329",
+			Migrate: migration1587308329.Migrate,
+		},
+		{
+			ID:      "1587395247",
+			Migrate: migration1587395247.Migrate,
+		},
+		{
+			ID:      "1587481683",
+			Migrate: migration1587481683.Migrate,
+		},
+		{
+			ID:      "1587563271",
+			Migrate: migration1587563271.Migrate,
+		},
+		{
+			ID:      "1587650114",
+			Migrate: migration1587650114.Migrate,
+		},
+		{
+			ID:      "1587736390",
+			Migrate: migration1587736390.Migrate,
+		},
 	}
 
 	m := gormigrate.New(db, &options, migrations)
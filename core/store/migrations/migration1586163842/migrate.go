@@ -0,0 +1,33 @@
+package migration1586163842
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// Migrate adds the http_audit_records table, which holds the raw HTTP
+// requests and responses made for task runs.
+func Migrate(tx *gorm.DB) error {
+	err := tx.Exec(`
+		CREATE TABLE http_audit_records (
+			"id" BIGSERIAL PRIMARY KEY,
+			"task_run_id" uuid NOT NULL REFERENCES task_runs(id) ON DELETE CASCADE,
+			"created_at" timestamp with time zone NOT NULL,
+			"method" text NOT NULL,
+			"url" text NOT NULL,
+			"request_headers" text,
+			"request_body" text,
+			"request_body_hash" text,
+			"request_body_truncated" boolean NOT NULL DEFAULT false,
+			"status_code" integer,
+			"response_headers" text,
+			"response_body" text,
+			"response_body_hash" text,
+			"response_body_truncated" boolean NOT NULL DEFAULT false,
+			"error" text
+		);
+		CREATE INDEX idx_http_audit_records_task_run_id ON http_audit_records(task_run_id);
+		CREATE INDEX idx_http_audit_records_created_at ON http_audit_records(created_at);
+	`).Error
+	return errors.Wrap(err, "could not add http_audit_records table")
+}
@@ -0,0 +1,14 @@
+package migration1586342453
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the run rate limit and daily gas budget to job specs.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN "max_runs" bigint NOT NULL DEFAULT 0;
+		ALTER TABLE job_specs ADD COLUMN "max_runs_window" bigint NOT NULL DEFAULT 0;
+		ALTER TABLE job_specs ADD COLUMN "max_daily_gas_spend" varchar(255);
+	`).Error
+}
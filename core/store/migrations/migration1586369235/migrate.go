@@ -0,0 +1,25 @@
+package migration1586369235
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate versions job specs, tagging their initiators, tasks and runs with
+// the version they belong to, and adds the table of version snapshots.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+		ALTER TABLE initiators ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+		ALTER TABLE task_specs ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+		ALTER TABLE job_runs ADD COLUMN "job_spec_version" bigint NOT NULL DEFAULT 1;
+
+		CREATE TABLE "job_spec_versions" (
+			"id" BIGSERIAL PRIMARY KEY,
+			"job_spec_id" uuid NOT NULL REFERENCES job_specs(id) ON DELETE CASCADE,
+			"version" bigint NOT NULL,
+			"created_at" timestamp with time zone NOT NULL,
+			"spec" text NOT NULL
+		);
+		CREATE UNIQUE INDEX idx_job_spec_versions_job_spec_id_version ON job_spec_versions(job_spec_id, version);
+	`).Error
+}
@@ -0,0 +1,12 @@
+package migration1586437122
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the time a job was paused at to job specs.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN "paused_at" timestamp with time zone;
+	`).Error
+}
@@ -0,0 +1,27 @@
+package migration1586512386
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds job spec templates and links the jobs created from them back
+// to the template.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE TABLE "spec_templates" (
+			"id" uuid PRIMARY KEY,
+			"name" text NOT NULL,
+			"variables" text NOT NULL,
+			"spec" text NOT NULL,
+			"version" bigint NOT NULL DEFAULT 1,
+			"created_at" timestamp with time zone NOT NULL,
+			"updated_at" timestamp with time zone NOT NULL
+		);
+		CREATE UNIQUE INDEX idx_spec_templates_name ON spec_templates(name);
+
+		ALTER TABLE job_specs ADD COLUMN "spec_template_id" uuid REFERENCES spec_templates(id) ON DELETE SET NULL;
+		ALTER TABLE job_specs ADD COLUMN "spec_template_version" bigint NOT NULL DEFAULT 0;
+		ALTER TABLE job_specs ADD COLUMN "template_variables" text;
+		CREATE INDEX idx_job_specs_spec_template_id ON job_specs(spec_template_id);
+	`).Error
+}
@@ -0,0 +1,23 @@
+package migration1586618371
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds names, descriptions and labels to job specs, and the indexes
+// used to filter and page through job specs and job runs.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN "name" text NOT NULL DEFAULT '';
+		ALTER TABLE job_specs ADD COLUMN "description" text NOT NULL DEFAULT '';
+		ALTER TABLE job_specs ADD COLUMN "labels" text[];
+		CREATE INDEX IF NOT EXISTS idx_job_specs_labels ON job_specs USING GIN (labels);
+		CREATE INDEX IF NOT EXISTS idx_job_specs_created_at_id ON job_specs(created_at, id);
+
+		CREATE INDEX IF NOT EXISTS idx_job_runs_created_at_id ON job_runs(created_at, id);
+		CREATE INDEX IF NOT EXISTS idx_job_runs_initiator_id ON job_runs(initiator_id);
+		CREATE INDEX IF NOT EXISTS idx_job_runs_run_request_id ON job_runs(run_request_id);
+		CREATE INDEX IF NOT EXISTS idx_run_requests_requester ON run_requests(requester);
+		CREATE INDEX IF NOT EXISTS idx_run_requests_tx_hash ON run_requests(tx_hash);
+	`).Error
+}
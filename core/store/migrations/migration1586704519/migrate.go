@@ -0,0 +1,33 @@
+package migration1586704519
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the approval state of job specs, the users who may approve
+// them, and the audit trail of their approvals. Existing jobs are approved.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE job_specs ADD COLUMN "proposed_at" timestamp with time zone;
+		ALTER TABLE job_specs ADD COLUMN "proposed_by" text NOT NULL DEFAULT '';
+		ALTER TABLE job_specs ADD COLUMN "approved_version" bigint NOT NULL DEFAULT 0;
+		UPDATE job_specs SET approved_version = version;
+
+		CREATE TABLE "job_approvers" (
+			"email" text PRIMARY KEY,
+			"hashed_password" text NOT NULL,
+			"created_at" timestamp with time zone NOT NULL
+		);
+
+		CREATE TABLE "job_approval_events" (
+			"id" BIGSERIAL PRIMARY KEY,
+			"job_spec_id" uuid NOT NULL REFERENCES job_specs(id) ON DELETE CASCADE,
+			"version" bigint NOT NULL,
+			"action" text NOT NULL,
+			"actor" text NOT NULL,
+			"detail" text NOT NULL DEFAULT '',
+			"created_at" timestamp with time zone NOT NULL
+		);
+		CREATE INDEX idx_job_approval_events_job_spec_id ON job_approval_events(job_spec_id);
+	`).Error
+}
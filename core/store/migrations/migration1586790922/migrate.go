@@ -0,0 +1,14 @@
+package migration1586790922
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the upstream job and run statuses watched by jobrun initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "upstream_job_id" uuid;
+		ALTER TABLE initiators ADD COLUMN "statuses" text NOT NULL DEFAULT '';
+		CREATE INDEX idx_initiators_upstream_job_id ON initiators(upstream_job_id) WHERE upstream_job_id IS NOT NULL;
+	`).Error
+}
@@ -0,0 +1,13 @@
+package migration1586877406
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the head interval and offset of blockinterval initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "every" integer NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN "block_offset" integer NOT NULL DEFAULT 0;
+	`).Error
+}
@@ -0,0 +1,14 @@
+package migration1586963861
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the time zone, jitter and catch up policy of cron initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "timezone" text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN "jitter" bigint NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN "catch_up" text NOT NULL DEFAULT '';
+	`).Error
+}
@@ -0,0 +1,25 @@
+package migration1587050298
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the addresses, token and threshold watched by balance
+// initiators, and the balances they last saw.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "addresses" text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN "token" bytea;
+		ALTER TABLE initiators ADD COLUMN "balance_threshold" varchar(255);
+		ALTER TABLE initiators ADD COLUMN "hysteresis" varchar(255);
+
+		CREATE TABLE "balance_watches" (
+			"initiator_id" bigint NOT NULL REFERENCES initiators(id) ON DELETE CASCADE,
+			"address" bytea NOT NULL,
+			"balance" varchar(255) NOT NULL,
+			"below" boolean NOT NULL,
+			"updated_at" timestamp with time zone NOT NULL,
+			PRIMARY KEY ("initiator_id", "address")
+		);
+	`).Error
+}
@@ -0,0 +1,13 @@
+package migration1587136740
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the queues consumed by messagequeue initiators.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "queue" text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN "dead_letter_queue" text NOT NULL DEFAULT '';
+	`).Error
+}
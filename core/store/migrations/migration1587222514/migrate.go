@@ -0,0 +1,31 @@
+package migration1587222514
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the health of external initiators, and the outbox of
+// notifications sent to them about the jobs they initiate.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE external_initiators ADD COLUMN "status" text NOT NULL DEFAULT 'unknown';
+		ALTER TABLE external_initiators ADD COLUMN "checked_at" timestamp with time zone;
+		ALTER TABLE external_initiators ADD COLUMN "check_error" text NOT NULL DEFAULT '';
+
+		CREATE TABLE "external_initiator_notifications" (
+			"id" BIGSERIAL PRIMARY KEY,
+			"external_initiator_name" text NOT NULL,
+			"job_spec_id" uuid NOT NULL REFERENCES job_specs(id) ON DELETE CASCADE,
+			"action" text NOT NULL,
+			"body" text NOT NULL,
+			"attempts" bigint NOT NULL DEFAULT 0,
+			"last_error" text NOT NULL DEFAULT '',
+			"next_attempt_at" timestamp with time zone NOT NULL,
+			"sent_at" timestamp with time zone,
+			"failed_at" timestamp with time zone,
+			"created_at" timestamp with time zone NOT NULL
+		);
+		CREATE INDEX idx_external_initiator_notifications_pending ON external_initiator_notifications(next_attempt_at)
+			WHERE sent_at IS NULL AND failed_at IS NULL;
+	`).Error
+}
@@ -0,0 +1,16 @@
+package migration1587308329
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds how flux monitor initiators aggregate the answers of their
+// feeds, and which answers they leave out.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "aggregation" text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN "trim_percent" float NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN "min_feeds" bigint NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN "outlier_threshold" float NOT NULL DEFAULT 0;
+	`).Error
+}
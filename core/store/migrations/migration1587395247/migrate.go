@@ -0,0 +1,13 @@
+package migration1587395247
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds how long each of a flux monitor initiator's feeds may take to
+// answer.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "feed_timeout" bigint NOT NULL DEFAULT 0;
+	`).Error
+}
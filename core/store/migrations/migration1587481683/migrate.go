@@ -0,0 +1,15 @@
+package migration1587481683
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the absolute deviation threshold of flux monitor initiators,
+// how it combines with the relative one, and the idle threshold in blocks.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "absolute_threshold" float NOT NULL DEFAULT 0;
+		ALTER TABLE initiators ADD COLUMN "threshold_mode" text NOT NULL DEFAULT '';
+		ALTER TABLE initiators ADD COLUMN "idle_threshold_blocks" bigint NOT NULL DEFAULT 0;
+	`).Error
+}
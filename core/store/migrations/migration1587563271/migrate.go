@@ -0,0 +1,31 @@
+package migration1587563271
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the table keeping what each flux monitor initiator last did,
+// so that it is kept across restarts.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		CREATE TABLE "flux_monitor_states" (
+			"initiator_id" bigint PRIMARY KEY REFERENCES initiators(id) ON DELETE CASCADE,
+			"job_spec_id" uuid NOT NULL REFERENCES job_specs(id) ON DELETE CASCADE,
+			"polled_at" timestamp with time zone,
+			"polled_answer" text,
+			"latest_answer" text,
+			"deviation" text,
+			"absolute_deviation" text,
+			"reportable_round_id" bigint NOT NULL DEFAULT 0,
+			"eligible" boolean NOT NULL DEFAULT false,
+			"reason" text NOT NULL DEFAULT '',
+			"submitted_round_id" bigint NOT NULL DEFAULT 0,
+			"submitted_at" timestamp with time zone,
+			"submission_run_id" uuid,
+			"submission_tx_hash" text,
+			"feeds" text NOT NULL DEFAULT '[]',
+			"updated_at" timestamp with time zone NOT NULL
+		);
+		CREATE INDEX idx_flux_monitor_states_job_spec_id ON flux_monitor_states(job_spec_id);
+	`).Error
+}
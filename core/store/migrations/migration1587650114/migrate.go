@@ -0,0 +1,16 @@
+package migration1587650114
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the gas price curve flux monitor initiators scale their
+// threshold by, and the gas price above which they only submit deviations
+// past an emergency threshold.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "gas_thresholds" text;
+		ALTER TABLE initiators ADD COLUMN "gas_price_ceiling" varchar(255);
+		ALTER TABLE initiators ADD COLUMN "emergency_threshold" float NOT NULL DEFAULT 0;
+	`).Error
+}
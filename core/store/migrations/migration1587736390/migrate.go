@@ -0,0 +1,15 @@
+package migration1587736390
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds the key flux monitor initiators submit to their aggregator
+// with, and whether it may still submit.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+		ALTER TABLE initiators ADD COLUMN "oracle_address" bytea;
+		ALTER TABLE flux_monitor_states ADD COLUMN "oracle_address" bytea;
+		ALTER TABLE flux_monitor_states ADD COLUMN "oracle_status" text NOT NULL DEFAULT '';
+	`).Error
+}
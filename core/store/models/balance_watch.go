@@ -0,0 +1,38 @@
+package models
+
+import (
+	"math/big"
+	"time"
+
+	"chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceWatch is the balance a balance initiator last saw at one of its
+// addresses, and whether it was below the initiator's threshold. It is kept
+// so that crossings while the node was down start runs once it is back.
+type BalanceWatch struct {
+	InitiatorID uint           `gorm:"primary_key;auto_increment:false"`
+	Address     common.Address `gorm:"primary_key;type:bytea"`
+	Balance     *utils.Big     `gorm:"type:varchar(255);not null"`
+	Below       bool           `gorm:"not null"`
+	UpdatedAt   time.Time
+}
+
+// BalanceBelowThreshold returns whether the balance is below the balance
+// initiator's threshold, given whether it was. A balance falls below the
+// threshold when it is less than it, and rises back above it only once it is
+// at least the threshold plus the hysteresis, so that a balance hovering
+// around the threshold does not flap.
+func BalanceBelowThreshold(initr Initiator, balance *big.Int, wasBelow bool) bool {
+	threshold := initr.BalanceThreshold.ToInt()
+	if !wasBelow {
+		return balance.Cmp(threshold) < 0
+	}
+	rearm := new(big.Int).Set(threshold)
+	if initr.Hysteresis != nil {
+		rearm.Add(rearm, initr.Hysteresis.ToInt())
+	}
+	return balance.Cmp(rearm) < 0
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"math/big"
+	"testing"
+
+	"chainlink/core/store/models"
+	"chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceBelowThreshold(t *testing.T) {
+	t.Parallel()
+
+	initr := models.Initiator{Type: models.InitiatorBalance, InitiatorParams: models.InitiatorParams{
+		BalanceThreshold: utils.NewBig(big.NewInt(100)),
+		Hysteresis:       utils.NewBig(big.NewInt(10)),
+	}}
+	noHysteresis := models.Initiator{Type: models.InitiatorBalance, InitiatorParams: models.InitiatorParams{
+		BalanceThreshold: utils.NewBig(big.NewInt(100)),
+	}}
+
+	tests := []struct {
+		name     string
+		initr    models.Initiator
+		balance  int64
+		wasBelow bool
+		want     bool
+	}{
+		{"above stays above", initr, 105, false, false},
+		{"at threshold stays above", initr, 100, false, false},
+		{"falls below", initr, 99, false, true},
+		{"below stays below", initr, 50, true, true},
+		{"within hysteresis stays below", initr, 109, true, true},
+		{"rises above", initr, 110, true, false},
+		{"rises above without hysteresis", noHysteresis, 100, true, false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			got := models.BalanceBelowThreshold(test.initr, big.NewInt(test.balance), test.wasBelow)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
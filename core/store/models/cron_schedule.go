@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mrwonko/cron"
+)
+
+// Catch up policies of cron initiators for the runs they missed while the
+// node was down.
+const (
+	// CronCatchUpNone runs nothing that was missed. It is the default.
+	CronCatchUpNone = "none"
+	// CronCatchUpLatest runs once for the latest missed schedule time.
+	CronCatchUpLatest = "latest"
+	// CronCatchUpAll runs once for every missed schedule time.
+	CronCatchUpAll = "all"
+)
+
+// CronSchedule is the schedule of a cron initiator in its time zone, which
+// defaults to the node's local time. Each time it is due is delayed by a
+// random duration of up to the initiator's jitter, so that nodes running the
+// same schedule do not all run at once.
+type CronSchedule struct {
+	schedule cron.Schedule
+	location *time.Location
+	jitter   time.Duration
+}
+
+// NewCronSchedule returns the schedule of the cron initiator.
+func NewCronSchedule(initr Initiator) (*CronSchedule, error) {
+	schedule, err := cron.Parse(string(initr.Schedule))
+	if err != nil {
+		return nil, fmt.Errorf("Cron: %v", err)
+	}
+	location := time.Local
+	if initr.Timezone != "" {
+		location, err = time.LoadLocation(initr.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("Cron: unknown time zone %s", initr.Timezone)
+		}
+	}
+	return &CronSchedule{
+		schedule: schedule,
+		location: location,
+		jitter:   initr.Jitter.Duration(),
+	}, nil
+}
+
+// Next returns when the schedule is next due after t, delayed by its jitter.
+// A jitter as long as the schedule's interval skips times it is due.
+func (cs *CronSchedule) Next(t time.Time) time.Time {
+	next := cs.schedule.Next(t.In(cs.location))
+	if next.IsZero() || cs.jitter <= 0 {
+		return next
+	}
+	return next.Add(time.Duration(rand.Int63n(int64(cs.jitter))))
+}
+
+// NextTimes returns up to n times the schedule is due after t, without
+// jitter.
+func (cs *CronSchedule) NextTimes(t time.Time, n int) []time.Time {
+	times := []time.Time{}
+	for len(times) < n {
+		t = cs.schedule.Next(t.In(cs.location))
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// Between returns the latest times, up to limit of them, that the schedule
+// was due after from and up to to, without jitter.
+func (cs *CronSchedule) Between(from, to time.Time, limit int) []time.Time {
+	times := []time.Time{}
+	for t := cs.schedule.Next(from.In(cs.location)); !t.IsZero() && !t.After(to); t = cs.schedule.Next(t) {
+		times = append(times, t)
+		if len(times) > limit {
+			times = times[1:]
+		}
+	}
+	return times
+}
@@ -0,0 +1,102 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCronSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule string
+		timezone string
+		wantErr  bool
+	}{
+		{"local time", "0 0 9 * * *", "", false},
+		{"time zone", "0 0 9 * * *", "Asia/Tokyo", false},
+		{"unknown time zone", "0 0 9 * * *", "Mars/Olympus_Mons", true},
+		{"bad schedule", "every day", "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			initr := models.Initiator{Type: models.InitiatorCron, InitiatorParams: models.InitiatorParams{
+				Schedule: models.Cron(test.schedule),
+				Timezone: test.timezone,
+			}}
+			_, err := models.NewCronSchedule(initr)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestCronSchedule_Timezone(t *testing.T) {
+	t.Parallel()
+
+	initr := models.Initiator{Type: models.InitiatorCron, InitiatorParams: models.InitiatorParams{
+		Schedule: "0 0 9 * * *",
+		Timezone: "Asia/Tokyo",
+	}}
+	schedule, err := models.NewCronSchedule(initr)
+	require.NoError(t, err)
+
+	from := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	times := schedule.NextTimes(from, 2)
+	require.Len(t, times, 2)
+	assert.True(t, time.Date(2020, 4, 2, 0, 0, 0, 0, time.UTC).Equal(times[0]))
+	assert.True(t, time.Date(2020, 4, 3, 0, 0, 0, 0, time.UTC).Equal(times[1]))
+	assert.True(t, times[0].Equal(schedule.Next(from)))
+}
+
+func TestCronSchedule_Jitter(t *testing.T) {
+	t.Parallel()
+
+	initr := models.Initiator{Type: models.InitiatorCron, InitiatorParams: models.InitiatorParams{
+		Schedule: "0 0 * * * *",
+		Timezone: "UTC",
+		Jitter:   models.Duration(time.Minute),
+	}}
+	schedule, err := models.NewCronSchedule(initr)
+	require.NoError(t, err)
+
+	from := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2020, 4, 1, 1, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		next := schedule.Next(from)
+		assert.False(t, next.Before(due))
+		assert.True(t, next.Before(due.Add(time.Minute)))
+	}
+}
+
+func TestCronSchedule_Between(t *testing.T) {
+	t.Parallel()
+
+	initr := models.Initiator{Type: models.InitiatorCron, InitiatorParams: models.InitiatorParams{
+		Schedule: "0 0 * * * *",
+		Timezone: "UTC",
+	}}
+	schedule, err := models.NewCronSchedule(initr)
+	require.NoError(t, err)
+
+	from := time.Date(2020, 4, 1, 0, 30, 0, 0, time.UTC)
+	to := time.Date(2020, 4, 1, 4, 0, 0, 0, time.UTC)
+
+	all := schedule.Between(from, to, 100)
+	require.Len(t, all, 4)
+	assert.True(t, time.Date(2020, 4, 1, 1, 0, 0, 0, time.UTC).Equal(all[0]))
+	assert.True(t, to.Equal(all[3]))
+
+	latest := schedule.Between(from, to, 1)
+	require.Len(t, latest, 1)
+	assert.True(t, to.Equal(latest[0]))
+
+	assert.Empty(t, schedule.Between(to, to, 100))
+}
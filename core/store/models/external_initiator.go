@@ -9,6 +9,29 @@ import (
 	"chainlink/core/utils"
 
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v3"
+)
+
+const (
+	// ExternalInitiatorAccessKeyHeader is the header name for the access key
+	// used by external initiators and the node to authenticate to each other
+	ExternalInitiatorAccessKeyHeader = "X-Chainlink-EA-AccessKey"
+	// ExternalInitiatorSecretHeader is the header name for the secret used by
+	// external initiators and the node to authenticate to each other
+	ExternalInitiatorSecretHeader = "X-Chainlink-EA-Secret"
+)
+
+// Statuses of an external initiator, as last seen by its health check.
+const (
+	// ExternalInitiatorStatusUnknown is the status of external initiators
+	// that have not been checked yet, or have no URL to check.
+	ExternalInitiatorStatusUnknown = "unknown"
+	// ExternalInitiatorStatusHealthy is the status of external initiators
+	// that answered their last health check.
+	ExternalInitiatorStatusHealthy = "healthy"
+	// ExternalInitiatorStatusUnhealthy is the status of external initiators
+	// that failed their last health check.
+	ExternalInitiatorStatusUnhealthy = "unhealthy"
 )
 
 // ExternalInitiatorRequest is the incoming record used to create an ExternalInitiator.
@@ -26,6 +49,9 @@ type ExternalInitiator struct {
 	HashedSecret   string  `gorm:"not null"`
 	OutgoingSecret string  `gorm:"not null"`
 	OutgoingToken  string  `gorm:"not null"`
+	Status         string  `gorm:"not null"`
+	CheckedAt      null.Time
+	CheckError     string `gorm:"not null"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v3"
+)
+
+// Actions an external initiator is notified of on the jobs it initiates.
+const (
+	// ExternalInitiatorNotificationCreate notifies of a new job.
+	ExternalInitiatorNotificationCreate = "create"
+	// ExternalInitiatorNotificationUpdate notifies of a new version of a job.
+	ExternalInitiatorNotificationUpdate = "update"
+	// ExternalInitiatorNotificationArchive notifies of an archived job, which
+	// the external initiator should stop initiating.
+	ExternalInitiatorNotificationArchive = "archive"
+)
+
+// JobSpecNotice is sent to the External Initiator when JobSpecs are created,
+// updated or archived.
+type JobSpecNotice struct {
+	JobID  *ID    `json:"jobId"`
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	Params JSON   `json:"params,omitempty"`
+}
+
+// NewJobSpecNotice returns a new JobSpec.
+func NewJobSpecNotice(initiator Initiator, js JobSpec, action string) (*JobSpecNotice, error) {
+	if initiator.Body == nil {
+		return nil, errors.New("body must be defined")
+	}
+	return &JobSpecNotice{
+		JobID:  js.ID,
+		Type:   initiator.Type,
+		Action: action,
+		Params: *initiator.Body,
+	}, nil
+}
+
+// ExternalInitiatorNotification is a JobSpecNotice in the outbox of an
+// external initiator. It is sent until the external initiator accepts it,
+// backing off between attempts, and fails once it runs out of attempts.
+type ExternalInitiatorNotification struct {
+	ID                    int64  `gorm:"primary_key"`
+	ExternalInitiatorName string `gorm:"not null"`
+	JobSpecID             *ID    `gorm:"not null"`
+	Action                string `gorm:"not null"`
+	Body                  JSON   `gorm:"type:text;not null"`
+	Attempts              uint32 `gorm:"not null"`
+	LastError             string `gorm:"not null"`
+	NextAttemptAt         time.Time
+	SentAt                null.Time
+	FailedAt              null.Time
+	CreatedAt             time.Time
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	null "gopkg.in/guregu/null.v3"
+)
+
+// FluxMonitorState is what a flux monitor initiator last did: when it last
+// polled, the answer it polled and the one on chain, how far apart they were,
+// why it did or did not submit, its last submission, and whether its oracle
+// key may submit to the aggregator. It is kept across restarts, so that
+// operators can see why a feed did not submit.
+type FluxMonitorState struct {
+	InitiatorID       uint                   `json:"initiatorId" gorm:"primary_key;auto_increment:false"`
+	JobSpecID         *ID                    `json:"jobSpecId" gorm:"type:uuid"`
+	PolledAt          null.Time              `json:"polledAt"`
+	PolledAnswer      decimal.NullDecimal    `json:"polledAnswer" gorm:"type:text"`
+	LatestAnswer      decimal.NullDecimal    `json:"latestAnswer" gorm:"type:text"`
+	Deviation         decimal.NullDecimal    `json:"deviation" gorm:"type:text"`
+	AbsoluteDeviation decimal.NullDecimal    `json:"absoluteDeviation" gorm:"type:text"`
+	ReportableRoundID uint32                 `json:"reportableRoundId"`
+	Eligible          bool                   `json:"eligible"`
+	Reason            string                 `json:"reason"`
+	SubmittedRoundID  uint64                 `json:"submittedRoundId"`
+	SubmittedAt       null.Time              `json:"submittedAt"`
+	SubmissionRunID   *ID                    `json:"submissionRunId" gorm:"type:uuid"`
+	SubmissionTxHash  null.String            `json:"submissionTxHash"`
+	OracleAddress     *common.Address        `json:"oracleAddress" gorm:"type:bytea"`
+	OracleStatus      string                 `json:"oracleStatus"`
+	Feeds             FluxMonitorFeedResults `json:"feeds" gorm:"type:text"`
+	UpdatedAt         time.Time              `json:"updatedAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (s FluxMonitorState) GetID() string {
+	return strconv.FormatUint(uint64(s.InitiatorID), 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (s FluxMonitorState) GetName() string {
+	return "flux_monitor_states"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (s *FluxMonitorState) SetID(value string) error {
+	id, err := strconv.ParseUint(value, 10, 32)
+	s.InitiatorID = uint(id)
+	return err
+}
+
+// FluxMonitorFeedResult is what one of a flux monitor's feeds answered when
+// it last polled, and whether its answer was included in the aggregate.
+type FluxMonitorFeedResult struct {
+	Feed   string              `json:"feed"`
+	Status string              `json:"status"`
+	Value  decimal.NullDecimal `json:"value"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// FluxMonitorFeedResults handle the serialization of the results of a flux
+// monitor's feeds to and from the data store.
+type FluxMonitorFeedResults []FluxMonitorFeedResult
+
+// Scan coerces the value returned from the data store to the proper data
+// in this instance.
+func (r *FluxMonitorFeedResults) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case nil:
+		*r = nil
+		return nil
+	default:
+		return fmt.Errorf("Unable to convert %v of %T to FluxMonitorFeedResults", value, value)
+	}
+	return errors.Wrap(json.Unmarshal(b, r), "Unable to convert to FluxMonitorFeedResults")
+}
+
+// Value returns this instance serialized for database storage.
+func (r FluxMonitorFeedResults) Value() (driver.Value, error) {
+	if r == nil {
+		r = FluxMonitorFeedResults{}
+	}
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
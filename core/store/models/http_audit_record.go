@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	null "gopkg.in/guregu/null.v3"
+)
+
+// HTTPAuditRecord is a raw HTTP request made by an adapter on behalf of a
+// TaskRun, along with the response it received. Secrets in headers, query
+// parameters and form or JSON bodies are redacted before it is saved, and
+// bodies are kept up to a configured limit alongside the SHA-256 hash of the
+// whole body as it was sent or received.
+type HTTPAuditRecord struct {
+	ID                    uint        `json:"-" gorm:"primary_key"`
+	TaskRunID             *ID         `json:"taskRunId" gorm:"not null"`
+	CreatedAt             time.Time   `json:"createdAt"`
+	Method                string      `json:"method"`
+	URL                   string      `json:"url"`
+	RequestHeaders        JSON        `json:"requestHeaders" gorm:"type:text"`
+	RequestBody           string      `json:"requestBody"`
+	RequestBodyHash       string      `json:"requestBodyHash"`
+	RequestBodyTruncated  bool        `json:"requestBodyTruncated"`
+	StatusCode            int         `json:"statusCode"`
+	ResponseHeaders       JSON        `json:"responseHeaders" gorm:"type:text"`
+	ResponseBody          string      `json:"responseBody"`
+	ResponseBodyHash      string      `json:"responseBodyHash"`
+	ResponseBodyTruncated bool        `json:"responseBodyTruncated"`
+	Error                 null.String `json:"error"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (r HTTPAuditRecord) GetID() string {
+	return strconv.FormatUint(uint64(r.ID), 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (r HTTPAuditRecord) GetName() string {
+	return "http_audit_records"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (r *HTTPAuditRecord) SetID(value string) error {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return err
+	}
+	r.ID = uint(id)
+	return nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	null "gopkg.in/guregu/null.v3"
+)
+
+// JobApprover is a user who can approve the jobs held as proposals by the
+// job approval policy. Approvers are added on the node itself, apart from the
+// API user, so that a stolen API session or token cannot approve the jobs it
+// proposes.
+type JobApprover struct {
+	Email          string    `json:"email" gorm:"primary_key"`
+	HashedPassword string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// NewJobApprover creates an approver, hashing the passed plainPwd with bcrypt.
+func NewJobApprover(email, plainPwd string) (JobApprover, error) {
+	user, err := NewUser(email, plainPwd)
+	if err != nil {
+		return JobApprover{}, err
+	}
+	return JobApprover{Email: user.Email, HashedPassword: user.HashedPassword}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (a JobApprover) GetID() string {
+	return a.Email
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (a JobApprover) GetName() string {
+	return "job_approvers"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (a *JobApprover) SetID(value string) error {
+	a.Email = value
+	return nil
+}
+
+// JobApprovalAction is a step in the approval of a version of a job.
+type JobApprovalAction string
+
+const (
+	// JobApprovalProposed is taken when a version of a job is held for approval.
+	JobApprovalProposed = JobApprovalAction("proposed")
+	// JobApprovalApproved is taken when an approver approves a version of a
+	// job, starting its initiators.
+	JobApprovalApproved = JobApprovalAction("approved")
+	// JobApprovalRejected is taken when an approver rejects a version of a job.
+	JobApprovalRejected = JobApprovalAction("rejected")
+	// JobApprovalDenied is recorded when an attempt to approve or reject a
+	// version of a job is refused, such as for a wrong password.
+	JobApprovalDenied = JobApprovalAction("denied")
+)
+
+// JobApprovalEvent records a step in the approval of a version of a job and
+// who took it, as an audit trail.
+type JobApprovalEvent struct {
+	ID        uint              `json:"-" gorm:"primary_key"`
+	JobSpecID *ID               `json:"jobSpecId" gorm:"not null"`
+	Version   uint32            `json:"version"`
+	Action    JobApprovalAction `json:"action"`
+	Actor     string            `json:"actor"`
+	Detail    string            `json:"detail,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (e JobApprovalEvent) GetID() string {
+	return strconv.FormatUint(uint64(e.ID), 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (e JobApprovalEvent) GetName() string {
+	return "job_approval_events"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (e *JobApprovalEvent) SetID(value string) error {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return err
+	}
+	e.ID = uint(id)
+	return nil
+}
+
+// JobApprovalRequest is an approver's decision on a version of a job, with
+// their credentials.
+type JobApprovalRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Version  uint32 `json:"version"`
+	Reason   string `json:"reason"`
+}
+
+// JobApproval is what an approver is shown of a proposed job: why it needs
+// approval, the changes made since its last approved version, and the audit
+// trail of its approvals.
+type JobApproval struct {
+	JobSpecID       *ID                `json:"jobSpecId"`
+	Version         uint32             `json:"version"`
+	ApprovedVersion uint32             `json:"approvedVersion"`
+	ProposedAt      null.Time          `json:"proposedAt"`
+	ProposedBy      string             `json:"proposedBy,omitempty"`
+	Reasons         []string           `json:"reasons"`
+	Diff            JobSpecDiff        `json:"diff"`
+	Events          []JobApprovalEvent `json:"events"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (a JobApproval) GetID() string {
+	return a.JobSpecID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (a JobApproval) GetName() string {
+	return "job_approvals"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (a *JobApproval) SetID(value string) error {
+	id, err := NewIDFromString(value)
+	a.JobSpecID = id
+	return err
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"chainlink/core/assets"
+)
+
+// JobConsumption is how much of its run rate limit and daily gas budget a job
+// has used. Runs counts the runs admitted in the last MaxRunsWindow, and
+// DailyGasSpend the wei committed to gas in the last 24 hours.
+type JobConsumption struct {
+	JobSpecID        *ID         `json:"jobSpecId"`
+	MaxRuns          uint32      `json:"maxRuns"`
+	MaxRunsWindow    Duration    `json:"maxRunsWindow"`
+	Runs             uint32      `json:"runs"`
+	MaxDailyGasSpend *assets.Eth `json:"maxDailyGasSpend"`
+	DailyGasSpend    *assets.Eth `json:"dailyGasSpend"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (jc JobConsumption) GetID() string {
+	return jc.JobSpecID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (jc JobConsumption) GetName() string {
+	return "consumptions"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (jc *JobConsumption) SetID(value string) error {
+	jc.JobSpecID = &ID{}
+	return jc.JobSpecID.UnmarshalText([]byte(value))
+}
@@ -17,6 +17,7 @@ import (
 type JobRun struct {
 	ID             *ID          `json:"id" gorm:"primary_key;not null"`
 	JobSpecID      *ID          `json:"jobId" gorm:"index;not null;type:varchar(36) REFERENCES job_specs(id)"`
+	JobSpecVersion uint32       `json:"jobVersion" gorm:"not null;default:1"`
 	Result         RunResult    `json:"result"`
 	ResultID       uint         `json:"-"`
 	RunRequest     RunRequest   `json:"-"`
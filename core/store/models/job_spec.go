@@ -16,17 +16,24 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/imdario/mergo"
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	null "gopkg.in/guregu/null.v3"
 )
 
 // JobSpecRequest represents a schema for the incoming job spec request as used by the API.
 type JobSpecRequest struct {
-	Initiators []InitiatorRequest `json:"initiators"`
-	Tasks      []TaskSpecRequest  `json:"tasks"`
-	StartAt    null.Time          `json:"startAt"`
-	EndAt      null.Time          `json:"endAt"`
-	MinPayment *assets.Link       `json:"minPayment,omitempty"`
+	Name             string             `json:"name,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	Labels           []string           `json:"labels,omitempty"`
+	Initiators       []InitiatorRequest `json:"initiators"`
+	Tasks            []TaskSpecRequest  `json:"tasks"`
+	StartAt          null.Time          `json:"startAt"`
+	EndAt            null.Time          `json:"endAt"`
+	MinPayment       *assets.Link       `json:"minPayment,omitempty"`
+	MaxRuns          uint32             `json:"maxRuns,omitempty"`
+	MaxRunsWindow    Duration           `json:"maxRunsWindow,omitempty"`
+	MaxDailyGasSpend *assets.Eth        `json:"maxDailyGasSpend,omitempty"`
 }
 
 // InitiatorRequest represents a schema for incoming initiator requests as used by the API.
@@ -45,15 +52,46 @@ type TaskSpecRequest struct {
 // JobSpec is the definition for all the work to be carried out by the node
 // for a given contract. It contains the Initiators, Tasks (which are the
 // individual steps to be carried out), StartAt, EndAt, and CreatedAt fields.
+//
+// A job may be limited to MaxRuns runs in any MaxRunsWindow, and to spending
+// MaxDailyGasSpend wei on gas in any 24 hours. Runs beyond either limit are
+// created errored rather than executed.
+//
+// Updating a job keeps its ID and increments its Version, replacing its
+// Initiators and Tasks with ones tagged with the new version. Those of earlier
+// versions are soft deleted, so runs already created finish on them.
+//
+// A job paused at PausedAt starts no runs until it is resumed.
+//
+// A job proposed at ProposedAt starts no runs, and its initiators are not
+// started, until an approver approves its current version. ApprovedVersion is
+// the last version that was approved, or started without needing approval.
 type JobSpec struct {
-	ID         *ID          `json:"id,omitempty" gorm:"primary_key;not null"`
-	CreatedAt  time.Time    `json:"createdAt" gorm:"index"`
-	Initiators []Initiator  `json:"initiators"`
-	MinPayment *assets.Link `json:"minPayment,omitempty" gorm:"type:varchar(255)"`
-	Tasks      []TaskSpec   `json:"tasks"`
-	StartAt    null.Time    `json:"startAt" gorm:"index"`
-	EndAt      null.Time    `json:"endAt" gorm:"index"`
-	DeletedAt  null.Time    `json:"-" gorm:"index"`
+	ID               *ID            `json:"id,omitempty" gorm:"primary_key;not null"`
+	Name             string         `json:"name,omitempty"`
+	Description      string         `json:"description,omitempty"`
+	Labels           pq.StringArray `json:"labels,omitempty" gorm:"type:text[]"`
+	CreatedAt        time.Time      `json:"createdAt" gorm:"index"`
+	Initiators       []Initiator    `json:"initiators"`
+	MinPayment       *assets.Link   `json:"minPayment,omitempty" gorm:"type:varchar(255)"`
+	Tasks            []TaskSpec     `json:"tasks"`
+	StartAt          null.Time      `json:"startAt" gorm:"index"`
+	EndAt            null.Time      `json:"endAt" gorm:"index"`
+	DeletedAt        null.Time      `json:"-" gorm:"index"`
+	MaxRuns          uint32         `json:"maxRuns,omitempty"`
+	MaxRunsWindow    Duration       `json:"maxRunsWindow,omitempty"`
+	MaxDailyGasSpend *assets.Eth    `json:"maxDailyGasSpend,omitempty" gorm:"type:varchar(255)"`
+	Version          uint32         `json:"version" gorm:"not null;default:1"`
+	PausedAt         null.Time      `json:"pausedAt"`
+	ProposedAt       null.Time      `json:"proposedAt"`
+	ProposedBy       string         `json:"proposedBy,omitempty"`
+	ApprovedVersion  uint32         `json:"approvedVersion"`
+
+	// SpecTemplateID links a job created from a SpecTemplate back to it, with
+	// the version of the template and the variables it was created from.
+	SpecTemplateID      *ID    `json:"specTemplateId,omitempty"`
+	SpecTemplateVersion uint32 `json:"specTemplateVersion,omitempty"`
+	TemplateVariables   *JSON  `json:"templateVariables,omitempty" gorm:"type:text"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -77,6 +115,7 @@ func NewJob() JobSpec {
 	return JobSpec{
 		ID:        NewID(),
 		CreatedAt: time.Now(),
+		Version:   1,
 	}
 }
 
@@ -97,17 +136,72 @@ func NewJobFromRequest(jsr JobSpecRequest) JobSpec {
 		})
 	}
 
+	jobSpec.Name = jsr.Name
+	jobSpec.Description = jsr.Description
+	jobSpec.Labels = jsr.Labels
 	jobSpec.EndAt = jsr.EndAt
 	jobSpec.StartAt = jsr.StartAt
 	jobSpec.MinPayment = jsr.MinPayment
+	jobSpec.MaxRuns = jsr.MaxRuns
+	jobSpec.MaxRunsWindow = jsr.MaxRunsWindow
+	jobSpec.MaxDailyGasSpend = jsr.MaxDailyGasSpend
 	return jobSpec
 }
 
+// Request returns the JobSpecRequest this job would be created from. The
+// state initiators record once they have run is left out.
+func (j JobSpec) Request() JobSpecRequest {
+	jsr := JobSpecRequest{
+		Name:             j.Name,
+		Description:      j.Description,
+		Labels:           j.Labels,
+		Initiators:       []InitiatorRequest{},
+		Tasks:            []TaskSpecRequest{},
+		StartAt:          j.StartAt,
+		EndAt:            j.EndAt,
+		MinPayment:       j.MinPayment,
+		MaxRuns:          j.MaxRuns,
+		MaxRunsWindow:    j.MaxRunsWindow,
+		MaxDailyGasSpend: j.MaxDailyGasSpend,
+	}
+	for _, initr := range j.Initiators {
+		params := initr.InitiatorParams
+		params.Ran = false
+		jsr.Initiators = append(jsr.Initiators, InitiatorRequest{
+			Type:            initr.Type,
+			InitiatorParams: params,
+		})
+	}
+	for _, task := range j.Tasks {
+		jsr.Tasks = append(jsr.Tasks, TaskSpecRequest{
+			Type:          task.Type,
+			Confirmations: task.Confirmations,
+			Params:        task.Params,
+		})
+	}
+	return jsr
+}
+
+// Limited returns true if the job has a run rate limit or daily gas budget
+func (j JobSpec) Limited() bool {
+	return j.MaxRuns > 0 || j.MaxDailyGasSpend != nil
+}
+
 // Archived returns true if the job spec has been soft deleted
 func (j JobSpec) Archived() bool {
 	return j.DeletedAt.Valid
 }
 
+// Paused returns true if the job spec has been paused
+func (j JobSpec) Paused() bool {
+	return j.PausedAt.Valid
+}
+
+// Proposed returns true if the job spec's current version awaits approval
+func (j JobSpec) Proposed() bool {
+	return j.ProposedAt.Valid
+}
+
 // InitiatorsFor returns an array of Initiators for the given list of
 // Initiator types.
 func (j JobSpec) InitiatorsFor(types ...string) []Initiator {
@@ -122,6 +216,17 @@ func (j JobSpec) InitiatorsFor(types ...string) []Initiator {
 	return list
 }
 
+// HasInitiator returns true if the initiator with the given ID belongs to the
+// job's current version.
+func (j JobSpec) HasInitiator(id uint) bool {
+	for _, initr := range j.Initiators {
+		if initr.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // InitiatorExternal finds the Job Spec's Initiator field associated with the
 // External Initiator's name using a case insensitive search.
 //
@@ -186,6 +291,18 @@ const (
 	InitiatorFluxMonitor = "fluxmonitor"
 	// InitiatorRandomnessLog for tasks from a VRF specific contract
 	InitiatorRandomnessLog = "randomnesslog"
+	// InitiatorJobRun for tasks in a job to be run when a run of another job
+	// completes or errors, with the upstream run's result.
+	InitiatorJobRun = "jobrun"
+	// InitiatorBlockInterval for tasks in a job to be run every N new heads,
+	// at the heads whose number is the offset past a multiple of N.
+	InitiatorBlockInterval = "blockinterval"
+	// InitiatorBalance for tasks in a job to be run when the ETH or ERC20
+	// balance of a watched address crosses a threshold.
+	InitiatorBalance = "balance"
+	// InitiatorMessageQueue for tasks in a job to be run for each message
+	// consumed from a queue of the node's message broker.
+	InitiatorMessageQueue = "messagequeue"
 )
 
 // Initiator could be thought of as a trigger, defines how a Job can be
@@ -200,6 +317,8 @@ type Initiator struct {
 	CreatedAt       time.Time `gorm:"index"`
 	InitiatorParams `json:"params,omitempty"`
 	DeletedAt       null.Time `json:"-" gorm:"index"`
+	// Version is the version of the job spec this initiator belongs to.
+	Version uint32 `json:"-" gorm:"not null;default:1"`
 }
 
 // InitiatorParams is a collection of the possible parameters that different
@@ -222,6 +341,39 @@ type InitiatorParams struct {
 	Threshold       float32  `json:"threshold,omitempty" gorm:"type:float"`
 	Precision       int32    `json:"precision,omitempty" gorm:"type:smallint"`
 	PollingInterval Duration `json:"pollingInterval,omitempty"`
+
+	Aggregation      string   `json:"aggregation,omitempty"`
+	TrimPercent      float32  `json:"trimPercent,omitempty" gorm:"type:float"`
+	MinFeeds         uint32   `json:"minFeeds,omitempty"`
+	OutlierThreshold float32  `json:"outlierThreshold,omitempty" gorm:"type:float"`
+	FeedTimeout      Duration `json:"feedTimeout,omitempty"`
+
+	AbsoluteThreshold   float32 `json:"absoluteThreshold,omitempty" gorm:"type:float"`
+	ThresholdMode       string  `json:"thresholdMode,omitempty"`
+	IdleThresholdBlocks uint32  `json:"idleThresholdBlocks,omitempty"`
+
+	GasThresholds      GasPriceThresholds `json:"gasThresholds,omitempty" gorm:"type:text"`
+	GasPriceCeiling    *utils.Big         `json:"gasPriceCeiling,omitempty" gorm:"type:varchar(255)"`
+	EmergencyThreshold float32            `json:"emergencyThreshold,omitempty" gorm:"type:float"`
+	OracleAddress      *common.Address    `json:"oracleAddress,omitempty" gorm:"type:bytea"`
+
+	JobID    *ID                 `json:"jobId,omitempty" gorm:"column:upstream_job_id;type:uuid"`
+	Statuses RunStatusCollection `json:"statuses,omitempty" gorm:"type:text"`
+
+	Every  uint32 `json:"every,omitempty"`
+	Offset uint32 `json:"offset,omitempty" gorm:"column:block_offset"`
+
+	Timezone string   `json:"timezone,omitempty"`
+	Jitter   Duration `json:"jitter,omitempty"`
+	CatchUp  string   `json:"catchUp,omitempty"`
+
+	Addresses        AddressCollection `json:"addresses,omitempty" gorm:"type:text"`
+	Token            *common.Address   `json:"token,omitempty" gorm:"type:bytea"`
+	BalanceThreshold *utils.Big        `json:"balanceThreshold,omitempty" gorm:"type:varchar(255)"`
+	Hysteresis       *utils.Big        `json:"hysteresis,omitempty" gorm:"type:varchar(255)"`
+
+	Queue           string `json:"queue,omitempty"`
+	DeadLetterQueue string `json:"deadLetterQueue,omitempty"`
 }
 
 // FluxMonitorDefaultInitiatorParams are the default parameters for Flux
@@ -266,6 +418,46 @@ func (t Topics) Value() (driver.Value, error) {
 	return string(j), nil
 }
 
+// GasPriceThreshold is a point on a flux monitor's gas price curve: the
+// threshold, in percent, a polled answer must deviate by to be submitted at
+// the gas price.
+type GasPriceThreshold struct {
+	GasPrice  *utils.Big `json:"gasPrice"`
+	Threshold float32    `json:"threshold"`
+}
+
+// GasPriceThresholds handle the serialization of a flux monitor's gas price
+// curve to and from the data store. Its points are in order of gas price.
+type GasPriceThresholds []GasPriceThreshold
+
+// Scan coerces the value returned from the data store to the proper data
+// in this instance.
+func (t *GasPriceThresholds) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case string:
+		return errors.Wrapf(json.Unmarshal([]byte(v), t), "Unable to convert %v of %T to GasPriceThresholds", value, value)
+	case []byte:
+		return errors.Wrapf(json.Unmarshal(v, t), "Unable to convert %v of %T to GasPriceThresholds", value, value)
+	default:
+		return fmt.Errorf("Unable to convert %v of %T to GasPriceThresholds", value, value)
+	}
+}
+
+// Value returns this instance serialized for database storage.
+func (t GasPriceThresholds) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	j, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
 // NewInitiatorFromRequest creates an Initiator from the corresponding
 // parameters in a InitiatorRequest
 func NewInitiatorFromRequest(
@@ -308,6 +500,8 @@ type TaskSpec struct {
 	Type          TaskType      `json:"type" gorm:"index;not null"`
 	Confirmations clnull.Uint32 `json:"confirmations"`
 	Params        JSON          `json:"params" gorm:"type:text"`
+	// Version is the version of the job spec this task belongs to.
+	Version uint32 `json:"-" gorm:"not null;default:1"`
 }
 
 // TaskType defines what Adapter a TaskSpec will use.
@@ -0,0 +1,348 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// JobSpecFormat is a document format a JobSpecRequest can be written in.
+// TOML and YAML documents are converted to the equivalent JSON before they
+// are parsed, so a spec means the same thing in every format.
+type JobSpecFormat string
+
+const (
+	// JobSpecFormatJSON is the default format of a job spec
+	JobSpecFormatJSON = JobSpecFormat("json")
+	// JobSpecFormatTOML writes a job spec as TOML
+	JobSpecFormatTOML = JobSpecFormat("toml")
+	// JobSpecFormatYAML writes a job spec as YAML
+	JobSpecFormatYAML = JobSpecFormat("yaml")
+)
+
+// ParseJobSpecFormat returns the format with the given name.
+func ParseJobSpecFormat(name string) (JobSpecFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "json":
+		return JobSpecFormatJSON, nil
+	case "toml":
+		return JobSpecFormatTOML, nil
+	case "yaml", "yml":
+		return JobSpecFormatYAML, nil
+	}
+	return "", fmt.Errorf("unknown job spec format '%s', must be one of json, toml or yaml", name)
+}
+
+// JobSpecFormatFromContentType returns the format of a request body with the
+// given Content-Type, defaulting to JSON.
+func JobSpecFormatFromContentType(contentType string) JobSpecFormat {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return JobSpecFormatJSON
+	}
+	switch mediaType {
+	case "application/toml", "application/x-toml", "text/toml", "text/x-toml":
+		return JobSpecFormatTOML
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return JobSpecFormatYAML
+	}
+	return JobSpecFormatJSON
+}
+
+// JobSpecFormatFromPath returns the format of a job spec file from its
+// extension, defaulting to JSON.
+func JobSpecFormatFromPath(path string) JobSpecFormat {
+	format, err := ParseJobSpecFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return JobSpecFormatJSON
+	}
+	return format
+}
+
+// ContentType returns the media type of documents in the format.
+func (f JobSpecFormat) ContentType() string {
+	switch f {
+	case JobSpecFormatTOML:
+		return "application/toml"
+	case JobSpecFormatYAML:
+		return "application/x-yaml"
+	}
+	return "application/json"
+}
+
+// JobSpecToJSON converts a job spec document in the given format to JSON.
+func JobSpecToJSON(document []byte, format JobSpecFormat) ([]byte, error) {
+	switch format {
+	case JobSpecFormatJSON:
+		return document, nil
+	case JobSpecFormatTOML:
+		tree, err := toml.LoadBytes(document)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid TOML job spec")
+		}
+		return json.Marshal(tree.ToMap())
+	case JobSpecFormatYAML:
+		var value interface{}
+		if err := yaml.Unmarshal(document, &value); err != nil {
+			return nil, errors.Wrap(err, "invalid YAML job spec")
+		}
+		value, err := yamlToJSONValue(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid YAML job spec")
+		}
+		return json.Marshal(value)
+	}
+	return nil, fmt.Errorf("unknown job spec format '%s'", format)
+}
+
+// ParseJobSpecRequest parses a job spec document in the given format.
+func ParseJobSpecRequest(document []byte, format JobSpecFormat) (JobSpecRequest, error) {
+	var jsr JobSpecRequest
+	b, err := JobSpecToJSON(document, format)
+	if err != nil {
+		return jsr, err
+	}
+	err = json.Unmarshal(b, &jsr)
+	return jsr, err
+}
+
+// FormatJobSpecRequest writes the job spec as a document in the given format.
+// Nulls are left out of TOML documents, which have no way to express them.
+func FormatJobSpecRequest(jsr JobSpecRequest, format JobSpecFormat) ([]byte, error) {
+	b, err := json.Marshal(jsr)
+	if err != nil {
+		return nil, err
+	}
+	if format == JobSpecFormatJSON {
+		var out bytes.Buffer
+		err = json.Indent(&out, b, "", "  ")
+		return out.Bytes(), err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err = decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case JobSpecFormatTOML:
+		var out bytes.Buffer
+		err = writeTOMLTable(&out, nil, doc)
+		return out.Bytes(), err
+	case JobSpecFormatYAML:
+		return yaml.Marshal(jsonToYAMLValue(doc))
+	}
+	return nil, fmt.Errorf("unknown job spec format '%s'", format)
+}
+
+// JobSpecDocument is a job spec written out in one of the JobSpecFormats.
+type JobSpecDocument struct {
+	Format JobSpecFormat `json:"format"`
+	Spec   string        `json:"spec"`
+}
+
+func yamlToJSONValue(value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("map key %v must be a string", k)
+			}
+			converted, err := yamlToJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = converted
+		}
+		return m, nil
+	case []interface{}:
+		l := make([]interface{}, len(typed))
+		for i, v := range typed {
+			converted, err := yamlToJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			l[i] = converted
+		}
+		return l, nil
+	}
+	return value, nil
+}
+
+func jsonToYAMLValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			typed[k] = jsonToYAMLValue(v)
+		}
+	case []interface{}:
+		for i, v := range typed {
+			typed[i] = jsonToYAMLValue(v)
+		}
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(typed.String(), 10, 64); err == nil {
+			return u
+		}
+		f, _ := typed.Float64()
+		return f
+	}
+	return value
+}
+
+var bareTOMLKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func writeTOMLTable(out *bytes.Buffer, path []string, table map[string]interface{}) error {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Plain values have to come before any sub tables, which would otherwise
+	// claim them.
+	var tables, tableArrays []string
+	for _, key := range keys {
+		switch value := table[key].(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			tables = append(tables, key)
+			continue
+		case []interface{}:
+			if isTOMLTableArray(value) {
+				tableArrays = append(tableArrays, key)
+				continue
+			}
+		}
+		value, err := tomlValue(table[key])
+		if err != nil {
+			return errors.Wrapf(err, "cannot write %s as TOML", strings.Join(append(path, key), "."))
+		}
+		fmt.Fprintf(out, "%s = %s\n", tomlKey(key), value)
+	}
+
+	for _, key := range tables {
+		subpath := append(append([]string{}, path...), key)
+		fmt.Fprintf(out, "\n[%s]\n", tomlPath(subpath))
+		if err := writeTOMLTable(out, subpath, table[key].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	for _, key := range tableArrays {
+		subpath := append(append([]string{}, path...), key)
+		for _, elem := range table[key].([]interface{}) {
+			fmt.Fprintf(out, "\n[[%s]]\n", tomlPath(subpath))
+			if err := writeTOMLTable(out, subpath, elem.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isTOMLTableArray(list []interface{}) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, elem := range list {
+		if _, ok := elem.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		var out bytes.Buffer
+		encoder := json.NewEncoder(&out)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(typed); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(out.String(), "\n"), nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case json.Number:
+		return typed.String(), nil
+	case []interface{}:
+		elems := make([]string, len(typed))
+		var kind string
+		for i, elem := range typed {
+			if i > 0 && tomlKind(elem) != kind {
+				return "", errors.New("TOML arrays cannot mix types")
+			}
+			kind = tomlKind(elem)
+			s, err := tomlValue(elem)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = s
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key, elem := range typed {
+			if elem != nil {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		elems := make([]string, len(keys))
+		for i, key := range keys {
+			s, err := tomlValue(typed[key])
+			if err != nil {
+				return "", err
+			}
+			elems[i] = tomlKey(key) + " = " + s
+		}
+		return "{" + strings.Join(elems, ", ") + "}", nil
+	case nil:
+		return "", errors.New("TOML has no null value")
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+func tomlKind(value interface{}) string {
+	if n, ok := value.(json.Number); ok {
+		if strings.ContainsAny(n.String(), ".eE") {
+			return "float"
+		}
+		return "integer"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func tomlKey(key string) string {
+	if bareTOMLKey.MatchString(key) {
+		return key
+	}
+	s, _ := tomlValue(key)
+	return s
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	return strings.Join(keys, ".")
+}
@@ -0,0 +1,158 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobSpecFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    models.JobSpecFormat
+		wantErr bool
+	}{
+		{"", models.JobSpecFormatJSON, false},
+		{"JSON", models.JobSpecFormatJSON, false},
+		{"toml", models.JobSpecFormatTOML, false},
+		{"yml", models.JobSpecFormatYAML, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			format, err := models.ParseJobSpecFormat(test.name)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, format)
+			}
+		})
+	}
+}
+
+func TestJobSpecFormatFromContentType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, models.JobSpecFormatTOML, models.JobSpecFormatFromContentType("application/toml; charset=utf-8"))
+	assert.Equal(t, models.JobSpecFormatYAML, models.JobSpecFormatFromContentType("text/yaml"))
+	assert.Equal(t, models.JobSpecFormatJSON, models.JobSpecFormatFromContentType("application/json"))
+	assert.Equal(t, models.JobSpecFormatJSON, models.JobSpecFormatFromContentType(""))
+}
+
+func TestParseJobSpecRequest_WithComments(t *testing.T) {
+	t.Parallel()
+
+	tomlSpec := `
+# fetches the price every minute
+[[initiators]]
+type = "cron"
+params = { schedule = "* * * * *" }
+
+[[tasks]]
+type = "httpget"
+[tasks.params]
+get = "https://example.com/price" # the data source
+path = ["data", "price"]
+
+[[tasks]]
+type = "multiply"
+params = { times = 100 }
+`
+	yamlSpec := `
+# fetches the price every minute
+initiators:
+  - type: cron
+    params: {schedule: "* * * * *"}
+tasks:
+  - type: httpget
+    params:
+      get: https://example.com/price # the data source
+      path: [data, price]
+  - type: multiply
+    params: {times: 100}
+`
+	jsonSpec := `{
+		"initiators": [{"type": "cron", "params": {"schedule": "* * * * *"}}],
+		"tasks": [
+			{"type": "httpget", "params": {"get": "https://example.com/price", "path": ["data", "price"]}},
+			{"type": "multiply", "params": {"times": 100}}
+		]
+	}`
+
+	want, err := models.ParseJobSpecRequest([]byte(jsonSpec), models.JobSpecFormatJSON)
+	require.NoError(t, err)
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	for format, spec := range map[models.JobSpecFormat]string{
+		models.JobSpecFormatTOML: tomlSpec,
+		models.JobSpecFormatYAML: yamlSpec,
+	} {
+		jsr, err := models.ParseJobSpecRequest([]byte(spec), format)
+		require.NoError(t, err, string(format))
+		got, err := json.Marshal(jsr)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(wantJSON), string(got), string(format))
+	}
+}
+
+func TestFormatJobSpecRequest_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	spec := `{
+		"initiators": [
+			{"type": "runlog", "params": {"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"}},
+			{"type": "cron", "params": {"schedule": "0 0 1 1 *"}}
+		],
+		"tasks": [
+			{"type": "httpget", "confirmations": 3, "params": {"get": "https://example.com/?a=<b>&c=\"d\"", "headers": {"X-API-Key": ["secret"]}}},
+			{"type": "multiply", "params": {"times": 100.5}},
+			{"type": "ethtx"}
+		],
+		"startAt": "2020-01-01T00:00:00Z",
+		"minPayment": "1000000000000000000",
+		"maxRuns": 10,
+		"maxRunsWindow": "1h"
+	}`
+	jsr, err := models.ParseJobSpecRequest([]byte(spec), models.JobSpecFormatJSON)
+	require.NoError(t, err)
+	want, err := json.Marshal(jsr)
+	require.NoError(t, err)
+
+	for _, format := range []models.JobSpecFormat{models.JobSpecFormatJSON, models.JobSpecFormatTOML, models.JobSpecFormatYAML} {
+		doc, err := models.FormatJobSpecRequest(jsr, format)
+		require.NoError(t, err, string(format))
+
+		parsed, err := models.ParseJobSpecRequest(doc, format)
+		require.NoError(t, err, string(format))
+		got, err := json.Marshal(parsed)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got), string(format))
+	}
+}
+
+func TestFormatJobSpecRequest_TOMLMixedArray(t *testing.T) {
+	t.Parallel()
+
+	spec := `{
+		"initiators": [{"type": "fluxmonitor", "params": {"feeds": ["https://example.com", {"bridge": "price"}]}}],
+		"tasks": [{"type": "noop"}]
+	}`
+	jsr, err := models.ParseJobSpecRequest([]byte(spec), models.JobSpecFormatJSON)
+	require.NoError(t, err)
+
+	_, err = models.FormatJobSpecRequest(jsr, models.JobSpecFormatTOML)
+	assert.Error(t, err)
+
+	_, err = models.FormatJobSpecRequest(jsr, models.JobSpecFormatYAML)
+	assert.NoError(t, err)
+}
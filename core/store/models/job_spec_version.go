@@ -0,0 +1,165 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// JobSpecVersion is an immutable snapshot of a version of a JobSpec, taken
+// when the job is created and each time it is updated. The Spec is the
+// JobSpecRequest the version would be created from.
+type JobSpecVersion struct {
+	ID        uint      `json:"-" gorm:"primary_key"`
+	JobSpecID *ID       `json:"jobSpecId" gorm:"not null"`
+	Version   uint32    `json:"version" gorm:"not null"`
+	CreatedAt time.Time `json:"createdAt"`
+	Spec      JSON      `json:"spec" gorm:"type:text"`
+}
+
+// NewJobSpecVersion returns a snapshot of the job's current version.
+func NewJobSpecVersion(job JobSpec) (JobSpecVersion, error) {
+	b, err := json.Marshal(job.Request())
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	spec, err := ParseJSON(b)
+	if err != nil {
+		return JobSpecVersion{}, err
+	}
+	return JobSpecVersion{
+		JobSpecID: job.ID,
+		Version:   job.Version,
+		CreatedAt: time.Now(),
+		Spec:      spec,
+	}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetID() string {
+	return strconv.FormatUint(uint64(v.Version), 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (v JobSpecVersion) GetName() string {
+	return "job_spec_versions"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (v *JobSpecVersion) SetID(value string) error {
+	version, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return err
+	}
+	v.Version = uint32(version)
+	return nil
+}
+
+// JobSpecDiff lists the changes between two versions of a JobSpec.
+type JobSpecDiff struct {
+	JobSpecID *ID             `json:"jobSpecId"`
+	From      uint32          `json:"from"`
+	To        uint32          `json:"to"`
+	Changes   []JobSpecChange `json:"changes"`
+}
+
+// JobSpecChange is a value added, removed or changed between two versions of
+// a JobSpec. Path is the dotted path to the value in the spec, with list
+// elements addressed by their index, as in "tasks.1.params.url".
+type JobSpecChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// NewJobSpecDiff returns the changes made to a JobSpec between the from and to
+// versions.
+func NewJobSpecDiff(from, to JobSpecVersion) (JobSpecDiff, error) {
+	var fromSpec, toSpec interface{}
+	if err := json.Unmarshal([]byte(from.Spec.String()), &fromSpec); err != nil {
+		return JobSpecDiff{}, err
+	}
+	if err := json.Unmarshal([]byte(to.Spec.String()), &toSpec); err != nil {
+		return JobSpecDiff{}, err
+	}
+
+	return JobSpecDiff{
+		JobSpecID: to.JobSpecID,
+		From:      from.Version,
+		To:        to.Version,
+		Changes:   diffValues("", fromSpec, toSpec, []JobSpecChange{}),
+	}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (d JobSpecDiff) GetID() string {
+	return fmt.Sprintf("%d-%d", d.From, d.To)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (d JobSpecDiff) GetName() string {
+	return "job_spec_diffs"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (d *JobSpecDiff) SetID(value string) error {
+	_, err := fmt.Sscanf(value, "%d-%d", &d.From, &d.To)
+	return err
+}
+
+func diffValues(path string, from, to interface{}, changes []JobSpecChange) []JobSpecChange {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]struct{}{}
+		for key := range fromValue {
+			keys[key] = struct{}{}
+		}
+		for key := range toValue {
+			keys[key] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			changes = diffValues(joinPath(path, key), fromValue[key], toValue[key], changes)
+		}
+		return changes
+	case []interface{}:
+		toValue, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(fromValue) || i < len(toValue); i++ {
+			var fromElem, toElem interface{}
+			if i < len(fromValue) {
+				fromElem = fromValue[i]
+			}
+			if i < len(toValue) {
+				toElem = toValue[i]
+			}
+			changes = diffValues(joinPath(path, strconv.Itoa(i)), fromElem, toElem, changes)
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		changes = append(changes, JobSpecChange{Path: path, From: from, To: to})
+	}
+	return changes
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJobSpecVersion_OmitsRan(t *testing.T) {
+	t.Parallel()
+
+	job := cltest.NewJobWithRunAtInitiator(cltest.ParseISO8601(t, "2020-01-01T00:00:00.000Z"))
+	job.Initiators[0].Ran = true
+
+	version, err := models.NewJobSpecVersion(job)
+	require.NoError(t, err)
+
+	assert.Equal(t, job.ID, version.JobSpecID)
+	assert.Equal(t, uint32(1), version.Version)
+	assert.Equal(t, "runat", version.Spec.Get("initiators.0.type").String())
+	assert.False(t, version.Spec.Get("initiators.0.params.ran").Exists())
+}
+
+func TestNewJobSpecDiff(t *testing.T) {
+	t.Parallel()
+
+	from := models.JobSpecVersion{
+		Version: 1,
+		Spec: cltest.JSONFromString(t, `{
+			"initiators": [{"type": "web"}],
+			"tasks": [{"type": "httpget", "params": {"get": "https://a.example"}}, {"type": "noop"}],
+			"maxRuns": 1
+		}`),
+	}
+	to := models.JobSpecVersion{
+		Version: 2,
+		Spec: cltest.JSONFromString(t, `{
+			"initiators": [{"type": "web"}],
+			"tasks": [{"type": "httpget", "params": {"get": "https://b.example"}}],
+			"minPayment": "100"
+		}`),
+	}
+
+	diff, err := models.NewJobSpecDiff(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(1), diff.From)
+	assert.Equal(t, uint32(2), diff.To)
+	assert.Equal(t, []models.JobSpecChange{
+		{Path: "maxRuns", From: float64(1), To: nil},
+		{Path: "minPayment", From: nil, To: "100"},
+		{Path: "tasks.0.params.get", From: "https://a.example", To: "https://b.example"},
+		{Path: "tasks.1", From: map[string]interface{}{"type": "noop"}, To: nil},
+	}, diff.Changes)
+}
+
+func TestNewJobSpecDiff_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	spec := cltest.JSONFromString(t, `{"initiators": [{"type": "web"}], "tasks": [{"type": "noop"}]}`)
+	diff, err := models.NewJobSpecDiff(
+		models.JobSpecVersion{Version: 1, Spec: spec},
+		models.JobSpecVersion{Version: 2, Spec: spec},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"chainlink/core/assets"
+	"chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// ethereumMessageHashPrefix is prefixed to the hash signed by the
+// store.KeyStore.
+const ethereumMessageHashPrefix = "\x19Ethereum Signed Message:\n32"
+
+// NodeBundleVersion is the version of the NodeBundle format written by this
+// node. Bundles of a later version are refused.
+const NodeBundleVersion = 1
+
+// NodeBundle is a signed snapshot of a node's configuration, for moving it to
+// another node or restoring it after a loss. The Contents are signed by the
+// node's account so that a bundle cannot be altered on its way to the node
+// importing it.
+type NodeBundle struct {
+	Version   int             `json:"version"`
+	Signer    common.Address  `json:"signer"`
+	Signature Signature       `json:"signature"`
+	Contents  json.RawMessage `json:"contents"`
+}
+
+// NodeBundleContents holds everything a NodeBundle carries. Secrets, the
+// bridges' and external initiators' outgoing tokens, are encrypted together
+// with the bundle's passphrase. Keys are carried as encrypted by the node, and
+// so need the node's passwords to be used.
+type NodeBundleContents struct {
+	CreatedAt          time.Time               `json:"createdAt"`
+	Jobs               []JobSpec               `json:"jobs"`
+	ServiceAgreements  []ServiceAgreement      `json:"serviceAgreements"`
+	BridgeTypes        []NodeBundleBridgeType  `json:"bridgeTypes"`
+	ExternalInitiators []NodeBundleInitiator   `json:"externalInitiators"`
+	Configuration      map[string]string       `json:"configuration"`
+	Secrets            *keystore.CryptoJSON    `json:"secrets,omitempty"`
+	Keys               []Key                   `json:"keys,omitempty"`
+	VRFKeys            []EncryptedSecretVRFKey `json:"vrfKeys,omitempty"`
+}
+
+// NodeBundleBridgeType is a BridgeType as carried by a NodeBundle.
+type NodeBundleBridgeType struct {
+	Name                   TaskType     `json:"name"`
+	URL                    WebURL       `json:"url"`
+	Confirmations          uint32       `json:"confirmations"`
+	IncomingTokenHash      string       `json:"incomingTokenHash"`
+	Salt                   string       `json:"salt"`
+	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
+}
+
+// NodeBundleInitiator is an ExternalInitiator as carried by a NodeBundle.
+type NodeBundleInitiator struct {
+	Name         string  `json:"name"`
+	URL          *WebURL `json:"url,omitempty"`
+	AccessKey    string  `json:"accessKey"`
+	Salt         string  `json:"salt"`
+	HashedSecret string  `json:"hashedSecret"`
+}
+
+// NodeBundleSecrets are the secrets of a NodeBundle, encrypted into its
+// Secrets.
+type NodeBundleSecrets struct {
+	BridgeTokens       map[string]string                 `json:"bridgeTokens"`
+	ExternalInitiators map[string]NodeBundleOutgoingAuth `json:"externalInitiators"`
+}
+
+// NodeBundleOutgoingAuth is the credentials a node uses to call an external
+// initiator.
+type NodeBundleOutgoingAuth struct {
+	OutgoingToken  string `json:"outgoingToken"`
+	OutgoingSecret string `json:"outgoingSecret"`
+}
+
+// NodeBundleSigner signs the hash of a NodeBundle's contents, as the
+// store.KeyStore does.
+type NodeBundleSigner interface {
+	SignHash(hash common.Hash) (Signature, error)
+}
+
+// NewNodeBundle returns the contents as a bundle signed by the account.
+func NewNodeBundle(contents NodeBundleContents, signer NodeBundleSigner, account common.Address) (NodeBundle, error) {
+	b, err := json.Marshal(contents)
+	if err != nil {
+		return NodeBundle{}, err
+	}
+	b, err = canonicalNodeBundleContents(b)
+	if err != nil {
+		return NodeBundle{}, err
+	}
+	signature, err := signer.SignHash(nodeBundleHash(b))
+	if err != nil {
+		return NodeBundle{}, errors.Wrap(err, "failed to sign node bundle")
+	}
+	return NodeBundle{
+		Version:   NodeBundleVersion,
+		Signer:    account,
+		Signature: signature,
+		Contents:  b,
+	}, nil
+}
+
+// Open checks the bundle's version and that it was signed by its signer, and
+// returns its contents.
+func (b NodeBundle) Open() (NodeBundleContents, error) {
+	var contents NodeBundleContents
+	if b.Version < 1 || b.Version > NodeBundleVersion {
+		return contents, fmt.Errorf("unsupported node bundle version %d, this node reads up to version %d", b.Version, NodeBundleVersion)
+	}
+
+	canonical, err := canonicalNodeBundleContents(b.Contents)
+	if err != nil {
+		return contents, errors.Wrap(err, "invalid node bundle contents")
+	}
+	prefixed, err := utils.Keccak256(append([]byte(ethereumMessageHashPrefix), nodeBundleHash(canonical).Bytes()...))
+	if err != nil {
+		return contents, err
+	}
+	sig := b.Signature.Bytes()
+	if sig[64] >= 27 {
+		sig = append(append([]byte{}, sig[:64]...), sig[64]-27)
+	}
+	pubkey, err := crypto.SigToPub(prefixed, sig)
+	if err != nil {
+		return contents, errors.Wrap(err, "invalid node bundle signature")
+	}
+	if crypto.PubkeyToAddress(*pubkey) != b.Signer {
+		return contents, fmt.Errorf("node bundle was not signed by %s", b.Signer.Hex())
+	}
+
+	err = json.Unmarshal(canonical, &contents)
+	return contents, err
+}
+
+// EncryptSecrets encrypts the secrets into the contents with the passphrase.
+func (c *NodeBundleContents) EncryptSecrets(secrets NodeBundleSecrets, passphrase string, scryptN, scryptP int) error {
+	b, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	encrypted, err := keystore.EncryptDataV3(b, []byte(passphrase), scryptN, scryptP)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt node bundle secrets")
+	}
+	c.Secrets = &encrypted
+	return nil
+}
+
+// DecryptSecrets returns the secrets of the contents, decrypted with the
+// passphrase.
+func (c NodeBundleContents) DecryptSecrets(passphrase string) (NodeBundleSecrets, error) {
+	var secrets NodeBundleSecrets
+	if c.Secrets == nil {
+		return secrets, nil
+	}
+	b, err := keystore.DecryptDataV3(*c.Secrets, passphrase)
+	if err != nil {
+		return secrets, errors.Wrap(err, "failed to decrypt node bundle secrets")
+	}
+	err = json.Unmarshal(b, &secrets)
+	return secrets, err
+}
+
+// canonicalNodeBundleContents returns the contents as compact, HTML escaped
+// JSON, as json.Marshal writes it, so that a bundle reformatted on its way to
+// the node importing it still matches its signature.
+func canonicalNodeBundleContents(contents []byte) ([]byte, error) {
+	var compacted, escaped bytes.Buffer
+	if err := json.Compact(&compacted, contents); err != nil {
+		return nil, err
+	}
+	json.HTMLEscape(&escaped, compacted.Bytes())
+	return escaped.Bytes(), nil
+}
+
+func nodeBundleHash(contents []byte) common.Hash {
+	return crypto.Keccak256Hash(contents)
+}
@@ -0,0 +1,129 @@
+package models_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+
+	"chainlink/core/store/models"
+	"chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nodeBundleSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s nodeBundleSigner) SignHash(hash common.Hash) (models.Signature, error) {
+	prefixed, err := utils.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), hash.Bytes()...))
+	if err != nil {
+		return models.Signature{}, err
+	}
+	b, err := crypto.Sign(prefixed, s.key)
+	if err != nil {
+		return models.Signature{}, err
+	}
+	var sig models.Signature
+	sig.SetBytes(b)
+	return sig, nil
+}
+
+func newNodeBundle(t *testing.T) (models.NodeBundle, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account := crypto.PubkeyToAddress(key.PublicKey)
+
+	contents := models.NodeBundleContents{
+		BridgeTypes: []models.NodeBundleBridgeType{
+			{Name: models.MustNewTaskType("prices"), IncomingTokenHash: "hash", Salt: "salt"},
+		},
+		Configuration: map[string]string{"ETH_GAS_PRICE_DEFAULT": "25000000000"},
+	}
+	bundle, err := models.NewNodeBundle(contents, nodeBundleSigner{key}, account)
+	require.NoError(t, err)
+	return bundle, account
+}
+
+func TestNodeBundle_Open(t *testing.T) {
+	t.Parallel()
+
+	bundle, account := newNodeBundle(t)
+	assert.Equal(t, models.NodeBundleVersion, bundle.Version)
+	assert.Equal(t, account, bundle.Signer)
+
+	contents, err := bundle.Open()
+	require.NoError(t, err)
+	require.Len(t, contents.BridgeTypes, 1)
+	assert.Equal(t, "prices", contents.BridgeTypes[0].Name.String())
+	assert.Equal(t, "25000000000", contents.Configuration["ETH_GAS_PRICE_DEFAULT"])
+}
+
+func TestNodeBundle_Open_Reformatted(t *testing.T) {
+	t.Parallel()
+
+	bundle, _ := newNodeBundle(t)
+	b, err := json.MarshalIndent(bundle, "", "    ")
+	require.NoError(t, err)
+
+	var reread models.NodeBundle
+	require.NoError(t, json.Unmarshal(b, &reread))
+	_, err = reread.Open()
+	assert.NoError(t, err)
+}
+
+func TestNodeBundle_Open_Rejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*models.NodeBundle)
+	}{
+		{"tampered contents", func(b *models.NodeBundle) {
+			b.Contents = bytes.Replace(b.Contents, []byte("25000000000"), []byte("1"), 1)
+		}},
+		{"other signer", func(b *models.NodeBundle) {
+			b.Signer = common.HexToAddress("0x3cb8e3FD9d27e39a5e9e6852b0e96160061fd4ea")
+		}},
+		{"later version", func(b *models.NodeBundle) { b.Version = models.NodeBundleVersion + 1 }},
+		{"no version", func(b *models.NodeBundle) { b.Version = 0 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundle, _ := newNodeBundle(t)
+			test.modify(&bundle)
+			_, err := bundle.Open()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNodeBundleContents_Secrets(t *testing.T) {
+	t.Parallel()
+
+	secrets := models.NodeBundleSecrets{
+		BridgeTokens: map[string]string{"prices": "outgoing"},
+		ExternalInitiators: map[string]models.NodeBundleOutgoingAuth{
+			"bitcoin": {OutgoingToken: "token", OutgoingSecret: "secret"},
+		},
+	}
+	var contents models.NodeBundleContents
+	require.NoError(t, contents.EncryptSecrets(secrets, "passphrase", 2, 1))
+	require.NotNil(t, contents.Secrets)
+
+	b, err := json.Marshal(contents)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "outgoing")
+
+	decrypted, err := contents.DecryptSecrets("passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, secrets, decrypted)
+
+	_, err = contents.DecryptSecrets("wrong")
+	assert.Error(t, err)
+}
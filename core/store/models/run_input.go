@@ -8,9 +8,11 @@ import (
 
 // RunInput represents the input for performing a Task
 type RunInput struct {
-	jobRunID ID
-	data     JSON
-	status   RunStatus
+	jobRunID     ID
+	taskRunID    *ID
+	recordedHTTP []HTTPAuditRecord
+	data         JSON
+	status       RunStatus
 }
 
 // NewRunInput creates a new RunInput with arbitrary data
@@ -22,6 +24,22 @@ func NewRunInput(jobRunID *ID, data JSON, status RunStatus) *RunInput {
 	}
 }
 
+// NewTaskRunInput creates a new RunInput for performing the given TaskRun,
+// which adapters can use to record what they did on its behalf
+func NewTaskRunInput(jobRunID, taskRunID *ID, data JSON, status RunStatus) *RunInput {
+	input := NewRunInput(jobRunID, data, status)
+	input.taskRunID = taskRunID
+	return input
+}
+
+// NewReplayRunInput creates a new RunInput whose HTTP requests are answered
+// from the given recorded responses instead of being sent
+func NewReplayRunInput(jobRunID *ID, data JSON, status RunStatus, recordedHTTP []HTTPAuditRecord) *RunInput {
+	input := NewRunInput(jobRunID, data, status)
+	input.recordedHTTP = recordedHTTP
+	return input
+}
+
 // NewRunInputWithResult creates a new RunInput with a value in the "result" field
 func NewRunInputWithResult(jobRunID *ID, value interface{}, status RunStatus) *RunInput {
 	data, err := JSON{}.Add("result", value)
@@ -63,3 +81,14 @@ func (ri RunInput) Data() JSON {
 func (ri RunInput) JobRunID() *ID {
 	return &ri.jobRunID
 }
+
+// TaskRunID returns the ID of the TaskRun this RunInput is for, if any
+func (ri RunInput) TaskRunID() *ID {
+	return ri.taskRunID
+}
+
+// RecordedHTTP returns the recorded HTTP responses this RunInput is replayed
+// against, or nil if its requests are to be sent
+func (ri RunInput) RecordedHTTP() []HTTPAuditRecord {
+	return ri.recordedHTTP
+}
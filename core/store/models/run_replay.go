@@ -0,0 +1,51 @@
+package models
+
+import (
+	null "gopkg.in/guregu/null.v3"
+)
+
+// RunReplay is the outcome of re-executing a persisted JobRun without side
+// effects, compared task by task with the original. It is never persisted.
+type RunReplay struct {
+	JobRunID       *ID          `json:"jobRunId"`
+	Spec           string       `json:"spec"`
+	OriginalStatus RunStatus    `json:"originalStatus"`
+	Status         RunStatus    `json:"status"`
+	Result         RunResult    `json:"result"`
+	TaskRuns       []TaskReplay `json:"taskRuns"`
+}
+
+// TaskReplay compares the original and replayed results of a single task.
+// Recorded is set when the task's HTTP requests were answered from the
+// original run's HTTP audit trail rather than sent.
+type TaskReplay struct {
+	Type        TaskType         `json:"type"`
+	Recorded    bool             `json:"recorded"`
+	Original    TaskReplayResult `json:"original"`
+	Replayed    TaskReplayResult `json:"replayed"`
+	Changed     bool             `json:"changed"`
+	ChangedKeys []string         `json:"changedKeys"`
+}
+
+// TaskReplayResult is the outcome of one execution of a task.
+type TaskReplayResult struct {
+	Status RunStatus   `json:"status"`
+	Output JSON        `json:"output"`
+	Error  null.String `json:"error"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (rr RunReplay) GetID() string {
+	return rr.JobRunID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (rr RunReplay) GetName() string {
+	return "replays"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (rr *RunReplay) SetID(value string) error {
+	rr.JobRunID = &ID{}
+	return rr.JobRunID.UnmarshalText([]byte(value))
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	null "gopkg.in/guregu/null.v3"
+)
+
+// RunSimulationRequest represents a schema for the incoming request to
+// simulate a job spec against sample request data.
+type RunSimulationRequest struct {
+	Spec  JobSpecRequest `json:"spec"`
+	Input JSON           `json:"input"`
+}
+
+// RunSimulation is the outcome of executing a job spec's tasks without side
+// effects. It is never persisted.
+type RunSimulation struct {
+	ID       *ID              `json:"id"`
+	Status   RunStatus        `json:"status"`
+	Result   RunResult        `json:"result"`
+	TaskRuns []TaskSimulation `json:"taskRuns"`
+}
+
+// TaskSimulation records the input and output of a single simulated task.
+type TaskSimulation struct {
+	Type   TaskType    `json:"type"`
+	Params JSON        `json:"params"`
+	Input  JSON        `json:"input"`
+	Output JSON        `json:"output"`
+	Status RunStatus   `json:"status"`
+	Error  null.String `json:"error"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (rs RunSimulation) GetID() string {
+	return rs.ID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (rs RunSimulation) GetName() string {
+	return "simulations"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (rs *RunSimulation) SetID(value string) error {
+	rs.ID = &ID{}
+	return rs.ID.UnmarshalText([]byte(value))
+}
@@ -0,0 +1,364 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// SpecTemplate is a job spec with {{variable}} placeholders, from which many
+// similar jobs can be created. A placeholder standing alone as a string value
+// is replaced by the variable's typed value, and a placeholder inside a longer
+// string by its text. Each job created from the template is linked back to it
+// with the variables it was created from, so that new versions of the
+// template can be rolled out to it.
+type SpecTemplate struct {
+	ID        *ID                   `json:"id" gorm:"primary_key;not null"`
+	Name      string                `json:"name" gorm:"not null"`
+	Variables SpecTemplateVariables `json:"variables" gorm:"type:text;not null"`
+	Spec      JSON                  `json:"spec" gorm:"type:text;not null"`
+	Version   uint32                `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// SpecTemplateRequest is the API schema for creating and updating a
+// SpecTemplate.
+type SpecTemplateRequest struct {
+	Name      string                `json:"name"`
+	Variables SpecTemplateVariables `json:"variables"`
+	Spec      JSON                  `json:"spec"`
+}
+
+// NewSpecTemplate returns a new template from the request.
+func NewSpecTemplate(str SpecTemplateRequest) SpecTemplate {
+	return SpecTemplate{
+		ID:        NewID(),
+		Name:      str.Name,
+		Variables: str.Variables,
+		Spec:      str.Spec,
+		Version:   1,
+	}
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (t SpecTemplate) GetID() string {
+	return t.ID.String()
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (t SpecTemplate) GetName() string {
+	return "spec_templates"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (t *SpecTemplate) SetID(value string) error {
+	t.ID = &ID{}
+	return t.ID.UnmarshalText([]byte(value))
+}
+
+// SpecTemplateInstancesRequest holds the variables for each job to create
+// from a template.
+type SpecTemplateInstancesRequest struct {
+	Instances []map[string]interface{} `json:"instances"`
+}
+
+// SpecTemplateVariableType is the type of value a template variable takes.
+type SpecTemplateVariableType string
+
+const (
+	// SpecTemplateString is any string
+	SpecTemplateString = SpecTemplateVariableType("string")
+	// SpecTemplateInteger is a whole number, given as a JSON number or a
+	// decimal string for numbers too large for a JSON number
+	SpecTemplateInteger = SpecTemplateVariableType("integer")
+	// SpecTemplateNumber is any JSON number
+	SpecTemplateNumber = SpecTemplateVariableType("number")
+	// SpecTemplateBoolean is true or false
+	SpecTemplateBoolean = SpecTemplateVariableType("boolean")
+	// SpecTemplateAddress is a hex encoded ethereum address
+	SpecTemplateAddress = SpecTemplateVariableType("address")
+	// SpecTemplateURL is an http or https URL
+	SpecTemplateURL = SpecTemplateVariableType("url")
+)
+
+// SpecTemplateVariable declares a variable of a SpecTemplate. A variable with
+// a default may be left out when creating a job.
+type SpecTemplateVariable struct {
+	Name        string                   `json:"name"`
+	Type        SpecTemplateVariableType `json:"type"`
+	Description string                   `json:"description,omitempty"`
+	Default     interface{}              `json:"default,omitempty"`
+}
+
+// SpecTemplateVariables is the list of variables a template declares.
+type SpecTemplateVariables []SpecTemplateVariable
+
+// Scan reads the database value and returns an instance.
+func (v *SpecTemplateVariables) Scan(value interface{}) error {
+	jsonStr, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("Unable to convert %v of %T to SpecTemplateVariables", value, value)
+	}
+
+	err := json.Unmarshal([]byte(jsonStr), &v)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to convert %v of %T to SpecTemplateVariables", value, value)
+	}
+	return nil
+}
+
+// Value returns this instance serialized for database storage.
+func (v SpecTemplateVariables) Value() (driver.Value, error) {
+	if v == nil {
+		v = SpecTemplateVariables{}
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+var (
+	templateVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	templatePlaceholder  = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// ValidateVariables returns an error for each variable with an invalid name,
+// type or default, and for each placeholder in the spec that has no variable.
+func (t SpecTemplate) ValidateVariables() error {
+	fe := NewJSONAPIErrors()
+	declared := map[string]bool{}
+	for _, v := range t.Variables {
+		if !templateVariableName.MatchString(v.Name) {
+			fe.Add(fmt.Sprintf("Variable name '%s' must be alphanumeric and may contain '_'", v.Name))
+		}
+		if declared[v.Name] {
+			fe.Add(fmt.Sprintf("Variable '%s' is declared more than once", v.Name))
+		}
+		declared[v.Name] = true
+		if !v.Type.valid() {
+			fe.Add(fmt.Sprintf("Variable '%s' has unknown type '%s'", v.Name, v.Type))
+		} else if v.Default != nil {
+			if _, err := v.Type.value(v.Default); err != nil {
+				fe.Add(fmt.Sprintf("Default of variable '%s' %v", v.Name, err))
+			}
+		}
+	}
+	for _, name := range t.placeholders() {
+		if !declared[name] {
+			fe.Add(fmt.Sprintf("Placeholder '{{%s}}' has no variable", name))
+		}
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func (t SpecTemplate) placeholders() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(t.Spec.String(), -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Instantiate returns the job spec the template gives with the variables
+// set, along with the variables including any defaults used.
+func (t SpecTemplate) Instantiate(variables map[string]interface{}) (JobSpecRequest, JSON, error) {
+	values := map[string]templateValue{}
+	used := map[string]interface{}{}
+	fe := NewJSONAPIErrors()
+	for _, v := range t.Variables {
+		given, ok := variables[v.Name]
+		if !ok || given == nil {
+			given = v.Default
+		}
+		if given == nil {
+			fe.Add(fmt.Sprintf("Variable '%s' is required", v.Name))
+			continue
+		}
+		value, err := v.Type.value(given)
+		if err != nil {
+			fe.Add(fmt.Sprintf("Variable '%s' %v", v.Name, err))
+			continue
+		}
+		values[v.Name] = value
+		used[v.Name] = given
+	}
+	var undeclared []string
+	for name := range variables {
+		if _, ok := t.variable(name); !ok {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		fe.Add(fmt.Sprintf("Variable '%s' is not declared by the template", name))
+	}
+	if err := fe.CoerceEmptyToNil(); err != nil {
+		return JobSpecRequest{}, JSON{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(t.Spec.String())))
+	decoder.UseNumber()
+	var spec interface{}
+	if err := decoder.Decode(&spec); err != nil {
+		return JobSpecRequest{}, JSON{}, err
+	}
+	b, err := json.Marshal(substitute(spec, values))
+	if err != nil {
+		return JobSpecRequest{}, JSON{}, err
+	}
+	var jsr JobSpecRequest
+	if err = json.Unmarshal(b, &jsr); err != nil {
+		return JobSpecRequest{}, JSON{}, err
+	}
+
+	usedJSON, err := json.Marshal(used)
+	if err != nil {
+		return JobSpecRequest{}, JSON{}, err
+	}
+	usedVariables, err := ParseJSON(usedJSON)
+	return jsr, usedVariables, err
+}
+
+// NewJob returns a new job created from the template with the variables set,
+// linked back to the template.
+func (t SpecTemplate) NewJob(variables map[string]interface{}) (JobSpec, error) {
+	jsr, used, err := t.Instantiate(variables)
+	if err != nil {
+		return JobSpec{}, err
+	}
+	job := NewJobFromRequest(jsr)
+	job.SpecTemplateID = t.ID
+	job.SpecTemplateVersion = t.Version
+	job.TemplateVariables = &used
+	return job, nil
+}
+
+func (t SpecTemplate) variable(name string) (SpecTemplateVariable, bool) {
+	for _, v := range t.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return SpecTemplateVariable{}, false
+}
+
+// templateValue is a variable's value, as JSON to replace a placeholder that
+// is a whole string value, and as text to replace one inside a string.
+type templateValue struct {
+	raw  json.RawMessage
+	text string
+}
+
+func substitute(value interface{}, values map[string]templateValue) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			typed[k] = substitute(v, values)
+		}
+	case []interface{}:
+		for i, v := range typed {
+			typed[i] = substitute(v, values)
+		}
+	case string:
+		if loc := templatePlaceholder.FindStringSubmatchIndex(typed); loc != nil && loc[0] == 0 && loc[1] == len(typed) {
+			return values[typed[loc[2]:loc[3]]].raw
+		}
+		return templatePlaceholder.ReplaceAllStringFunc(typed, func(placeholder string) string {
+			return values[templatePlaceholder.FindStringSubmatch(placeholder)[1]].text
+		})
+	}
+	return value
+}
+
+func (vt SpecTemplateVariableType) valid() bool {
+	switch vt {
+	case SpecTemplateString, SpecTemplateInteger, SpecTemplateNumber,
+		SpecTemplateBoolean, SpecTemplateAddress, SpecTemplateURL:
+		return true
+	}
+	return false
+}
+
+func (vt SpecTemplateVariableType) value(given interface{}) (templateValue, error) {
+	switch vt {
+	case SpecTemplateString:
+		s, ok := given.(string)
+		if !ok {
+			return templateValue{}, errors.New("must be a string")
+		}
+		return textValue(s)
+	case SpecTemplateAddress:
+		s, ok := given.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return templateValue{}, errors.New("must be a hex encoded address")
+		}
+		return textValue(common.HexToAddress(s).Hex())
+	case SpecTemplateURL:
+		s, ok := given.(string)
+		if !ok {
+			return templateValue{}, errors.New("must be a URL")
+		}
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return templateValue{}, errors.New("must be an http or https URL")
+		}
+		return textValue(s)
+	case SpecTemplateBoolean:
+		b, ok := given.(bool)
+		if !ok {
+			return templateValue{}, errors.New("must be true or false")
+		}
+		return numericValue(strconv.FormatBool(b)), nil
+	case SpecTemplateInteger:
+		switch typed := given.(type) {
+		case float64:
+			if typed == math.Trunc(typed) {
+				return numericValue(big.NewFloat(typed).Text('f', 0)), nil
+			}
+		case string, json.Number:
+			if i, ok := new(big.Int).SetString(fmt.Sprint(typed), 10); ok {
+				return numericValue(i.String()), nil
+			}
+		}
+		return templateValue{}, errors.New("must be an integer")
+	case SpecTemplateNumber:
+		switch typed := given.(type) {
+		case float64:
+			return numericValue(strconv.FormatFloat(typed, 'f', -1, 64)), nil
+		case json.Number:
+			if _, err := typed.Float64(); err == nil {
+				return numericValue(typed.String()), nil
+			}
+		}
+		return templateValue{}, errors.New("must be a number")
+	}
+	return templateValue{}, fmt.Errorf("has unknown type '%s'", vt)
+}
+
+func textValue(s string) (templateValue, error) {
+	raw, err := json.Marshal(s)
+	return templateValue{raw: raw, text: s}, err
+}
+
+func numericValue(s string) templateValue {
+	return templateValue{raw: json.RawMessage(s), text: s}
+}
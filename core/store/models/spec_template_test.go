@@ -0,0 +1,106 @@
+package models_test
+
+import (
+	"testing"
+
+	"chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPriceTemplate(t *testing.T) models.SpecTemplate {
+	spec, err := models.ParseJSON([]byte(`{
+		"initiators": [{"type": "runlog", "params": {"address": "{{oracle}}"}}],
+		"tasks": [
+			{"type": "httpget", "params": {"get": "{{source}}/price?symbol={{symbol}}"}},
+			{"type": "jsonparse", "params": {"path": ["{{symbol}}", "usd"]}},
+			{"type": "multiply", "params": {"times": "{{times}}"}},
+			{"type": "ethuint256"}
+		]
+	}`))
+	require.NoError(t, err)
+	return models.NewSpecTemplate(models.SpecTemplateRequest{
+		Name: "price",
+		Variables: models.SpecTemplateVariables{
+			{Name: "oracle", Type: models.SpecTemplateAddress},
+			{Name: "source", Type: models.SpecTemplateURL, Default: "https://example.com"},
+			{Name: "symbol", Type: models.SpecTemplateString},
+			{Name: "times", Type: models.SpecTemplateInteger, Default: float64(100)},
+		},
+		Spec: spec,
+	})
+}
+
+func TestSpecTemplate_ValidateVariables(t *testing.T) {
+	t.Parallel()
+
+	template := newPriceTemplate(t)
+	assert.NoError(t, template.ValidateVariables())
+
+	template.Variables = append(template.Variables[1:],
+		models.SpecTemplateVariable{Name: "symbol", Type: models.SpecTemplateString},
+		models.SpecTemplateVariable{Name: "bad-name", Type: "date"},
+		models.SpecTemplateVariable{Name: "count", Type: models.SpecTemplateInteger, Default: 1.5},
+	)
+	err := template.ValidateVariables()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Variable 'symbol' is declared more than once")
+	assert.Contains(t, err.Error(), "Variable name 'bad-name' must be alphanumeric")
+	assert.Contains(t, err.Error(), "Variable 'bad-name' has unknown type 'date'")
+	assert.Contains(t, err.Error(), "Default of variable 'count' must be an integer")
+	assert.Contains(t, err.Error(), "Placeholder '{{oracle}}' has no variable")
+}
+
+func TestSpecTemplate_NewJob(t *testing.T) {
+	t.Parallel()
+
+	template := newPriceTemplate(t)
+	job, err := template.NewJob(map[string]interface{}{
+		"oracle": "0x3ccad4715152693fe3bc4460591e3d3fbd071b42",
+		"symbol": "ETH",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, template.ID, job.SpecTemplateID)
+	assert.Equal(t, uint32(1), job.SpecTemplateVersion)
+	require.Len(t, job.Initiators, 1)
+	assert.Equal(t, "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", job.Initiators[0].Address.Hex())
+	require.Len(t, job.Tasks, 4)
+	assert.Equal(t, "https://example.com/price?symbol=ETH", job.Tasks[0].Params.Get("get").String())
+	assert.Equal(t, `["ETH","usd"]`, job.Tasks[1].Params.Get("path").Raw)
+	assert.Equal(t, "100", job.Tasks[2].Params.Get("times").Raw)
+
+	require.NotNil(t, job.TemplateVariables)
+	assert.Equal(t, "ETH", job.TemplateVariables.Get("symbol").String())
+	assert.Equal(t, "https://example.com", job.TemplateVariables.Get("source").String())
+}
+
+func TestSpecTemplate_NewJob_InvalidVariables(t *testing.T) {
+	t.Parallel()
+
+	template := newPriceTemplate(t)
+	_, err := template.NewJob(map[string]interface{}{
+		"oracle": "not an address",
+		"times":  "1e18",
+		"extra":  true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Variable 'oracle' must be a hex encoded address")
+	assert.Contains(t, err.Error(), "Variable 'symbol' is required")
+	assert.Contains(t, err.Error(), "Variable 'times' must be an integer")
+	assert.Contains(t, err.Error(), "Variable 'extra' is not declared by the template")
+}
+
+func TestSpecTemplate_NewJob_LargeInteger(t *testing.T) {
+	t.Parallel()
+
+	template := newPriceTemplate(t)
+	job, err := template.NewJob(map[string]interface{}{
+		"oracle": "0x3ccad4715152693fe3bc4460591e3d3fbd071b42",
+		"symbol": "BTC",
+		"times":  "1000000000000000000000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000", job.Tasks[2].Params.Get("times").Raw)
+}
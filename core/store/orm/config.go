@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"chainlink/core/assets"
@@ -155,6 +156,18 @@ func (c Config) Dev() bool {
 	return c.viper.GetBool(EnvVarName("Dev"))
 }
 
+// EIHealthCheckInterval is how often the health of each
+// external initiator with a URL is checked.
+func (c Config) EIHealthCheckInterval() time.Duration {
+	return c.viper.GetDuration(EnvVarName("EIHealthCheckInterval"))
+}
+
+// EINotificationMaxAttempts is how many times a notification
+// is sent to an external initiator that does not accept it before it fails.
+func (c Config) EINotificationMaxAttempts() uint32 {
+	return c.viper.GetUint32(EnvVarName("EINotificationMaxAttempts"))
+}
+
 // FeatureExternalInitiators enables the External Initiator feature.
 func (c Config) FeatureExternalInitiators() bool {
 	return c.viper.GetBool(EnvVarName("FeatureExternalInitiators"))
@@ -165,6 +178,25 @@ func (c Config) FeatureFluxMonitor() bool {
 	return c.viper.GetBool(EnvVarName("FeatureFluxMonitor"))
 }
 
+// HTTPAuditBodyLimit is the number of bytes of each request and response
+// body kept in the HTTP audit trail. Longer bodies are truncated but hashed
+// in full.
+func (c Config) HTTPAuditBodyLimit() int64 {
+	return c.viper.GetInt64(EnvVarName("HTTPAuditBodyLimit"))
+}
+
+// HTTPAuditEnabled records the raw HTTP requests and responses made by the
+// httpget, httppost and bridge adapters against their task runs.
+func (c Config) HTTPAuditEnabled() bool {
+	return c.viper.GetBool(EnvVarName("HTTPAuditEnabled"))
+}
+
+// HTTPAuditRetention is how long HTTP audit records are kept before they are
+// reaped. Zero keeps them for as long as their task run.
+func (c Config) HTTPAuditRetention() time.Duration {
+	return c.viper.GetDuration(EnvVarName("HTTPAuditRetention"))
+}
+
 // MaxRPCCallsPerSecond returns the rate at which RPC calls can be fired
 func (c Config) MaxRPCCallsPerSecond() uint64 {
 	return c.viper.GetUint64(EnvVarName("MaxRPCCallsPerSecond"))
@@ -230,6 +262,24 @@ func (c Config) EthereumURL() string {
 	return c.viper.GetString(EnvVarName("EthereumURL"))
 }
 
+// JobApprovalKeys are the keys, by address or public key, that jobs may only
+// use once they are approved by an approver.
+func (c Config) JobApprovalKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(c.viper.GetString(EnvVarName("JobApprovalKeys")), ",") {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// JobApprovalPolicy decides which new jobs and job updates are held as
+// proposals until an approver approves them.
+func (c Config) JobApprovalPolicy() ApprovalPolicy {
+	return c.getWithFallback("JobApprovalPolicy", parseApprovalPolicy).(ApprovalPolicy)
+}
+
 // JSONConsole enables the JSON console.
 func (c Config) JSONConsole() bool {
 	return c.viper.GetBool(EnvVarName("JSONConsole"))
@@ -254,6 +304,21 @@ func (c Config) ExplorerURL() *url.URL {
 	}
 }
 
+// MessageQueueURL returns the URL of the message broker that messagequeue
+// initiators consume from, or nil.
+func (c Config) MessageQueueURL() *url.URL {
+	rval := c.getWithFallback("MessageQueueURL", parseURL)
+	switch t := rval.(type) {
+	case nil:
+		return nil
+	case *url.URL:
+		return t
+	default:
+		logger.Panicf("invariant: MessageQueueURL returned as type %T", rval)
+		return nil
+	}
+}
+
 // ExplorerAccessKey returns the access key for authenticating with explorer
 func (c Config) ExplorerAccessKey() string {
 	return c.viper.GetString(EnvVarName("ExplorerAccessKey"))
@@ -264,6 +329,15 @@ func (c Config) ExplorerSecret() string {
 	return c.viper.GetString(EnvVarName("ExplorerSecret"))
 }
 
+// NodeBundleSigner is the account whose node bundles are trusted to be
+// imported when no other signer is given.
+func (c Config) NodeBundleSigner() *common.Address {
+	if c.viper.GetString(EnvVarName("NodeBundleSigner")) == "" {
+		return nil
+	}
+	return c.getWithFallback("NodeBundleSigner", parseAddress).(*common.Address)
+}
+
 // OracleContractAddress represents the deployed Oracle contract's address.
 func (c Config) OracleContractAddress() *common.Address {
 	if c.viper.GetString(EnvVarName("OracleContractAddress")) == "" {
@@ -272,6 +346,13 @@ func (c Config) OracleContractAddress() *common.Address {
 	return c.getWithFallback("OracleContractAddress", parseAddress).(*common.Address)
 }
 
+// PausedJobRunLogPolicy decides what becomes of requests made to a paused
+// job by its log initiators: they are either dropped, queued until the job is
+// resumed, or saved as errored runs.
+func (c Config) PausedJobRunLogPolicy() PausedRunPolicy {
+	return c.getWithFallback("PausedJobRunLogPolicy", parsePausedRunPolicy).(PausedRunPolicy)
+}
+
 // LogLevel represents the maximum level of log messages to output.
 func (c Config) LogLevel() LogLevel {
 	return c.getWithFallback("LogLevel", parseLogLevel).(LogLevel)
@@ -492,6 +573,15 @@ func parseAddress(str string) (interface{}, error) {
 	return nil, fmt.Errorf("Unable to parse '%s' into EIP55-compliant address", str)
 }
 
+func parseApprovalPolicy(str string) (interface{}, error) {
+	policy := ApprovalPolicy(strings.ToLower(str))
+	switch policy {
+	case ApprovalPolicyOff, ApprovalPolicySensitive, ApprovalPolicyAll:
+		return policy, nil
+	}
+	return policy, fmt.Errorf("Unable to parse '%s' into a job approval policy, must be one of off, sensitive or all", str)
+}
+
 func parseLink(str string) (interface{}, error) {
 	i, ok := new(assets.Link).SetString(str, 10)
 	if !ok {
@@ -506,6 +596,15 @@ func parseLogLevel(str string) (interface{}, error) {
 	return lvl, err
 }
 
+func parsePausedRunPolicy(str string) (interface{}, error) {
+	policy := PausedRunPolicy(strings.ToLower(str))
+	switch policy {
+	case PausedRunPolicyDrop, PausedRunPolicyQueue, PausedRunPolicyError:
+		return policy, nil
+	}
+	return policy, fmt.Errorf("Unable to parse '%s' into a paused run policy, must be one of drop, queue or error", str)
+}
+
 func parseUint16(str string) (interface{}, error) {
 	d, err := strconv.ParseUint(str, 10, 16)
 	return uint16(d), err
@@ -531,6 +630,36 @@ func parseHomeDir(str string) (interface{}, error) {
 	return filepath.ToSlash(exp), nil
 }
 
+// PausedRunPolicy decides what becomes of requests made to a paused job by its
+// log initiators.
+type PausedRunPolicy string
+
+const (
+	// PausedRunPolicyDrop ignores requests made while the job is paused.
+	PausedRunPolicyDrop = PausedRunPolicy("drop")
+	// PausedRunPolicyQueue saves the runs requested while the job is paused,
+	// starting them once it is resumed.
+	PausedRunPolicyQueue = PausedRunPolicy("queue")
+	// PausedRunPolicyError saves the runs requested while the job is paused
+	// as errored.
+	PausedRunPolicyError = PausedRunPolicy("error")
+)
+
+// ApprovalPolicy decides which new jobs and job updates must be approved
+// before their initiators are started.
+type ApprovalPolicy string
+
+const (
+	// ApprovalPolicyOff starts every job without approval.
+	ApprovalPolicyOff = ApprovalPolicy("off")
+	// ApprovalPolicySensitive holds the jobs that send transactions or use one
+	// of the JobApprovalKeys until they are approved.
+	ApprovalPolicySensitive = ApprovalPolicy("sensitive")
+	// ApprovalPolicyAll holds every new job and job update until it is
+	// approved.
+	ApprovalPolicyAll = ApprovalPolicy("all")
+)
+
 // LogLevel determines the verbosity of the events to be logged.
 type LogLevel struct {
 	zapcore.Level
@@ -22,8 +22,13 @@ type ConfigReader interface {
 	DatabaseURL() string
 	DefaultHTTPLimit() int64
 	Dev() bool
+	EIHealthCheckInterval() time.Duration
+	EINotificationMaxAttempts() uint32
 	FeatureExternalInitiators() bool
 	FeatureFluxMonitor() bool
+	HTTPAuditBodyLimit() int64
+	HTTPAuditEnabled() bool
+	HTTPAuditRetention() time.Duration
 	MaximumServiceDuration() time.Duration
 	MinimumServiceDuration() time.Duration
 	EthGasBumpPercent() uint16
@@ -33,12 +38,17 @@ type ConfigReader interface {
 	EthMaxGasPriceWei() *big.Int
 	SetEthGasPriceDefault(value *big.Int) error
 	EthereumURL() string
+	JobApprovalKeys() []string
+	JobApprovalPolicy() ApprovalPolicy
 	JSONConsole() bool
 	LinkContractAddress() string
 	ExplorerURL() *url.URL
 	ExplorerAccessKey() string
 	ExplorerSecret() string
+	MessageQueueURL() *url.URL
+	NodeBundleSigner() *common.Address
 	OracleContractAddress() *common.Address
+	PausedJobRunLogPolicy() PausedRunPolicy
 	LogLevel() LogLevel
 	LogToDisk() bool
 	LogSQLStatements() bool
@@ -132,6 +132,40 @@ func TestStore_levelParser(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestStore_pausedRunPolicyParser(t *testing.T) {
+	val, err := parsePausedRunPolicy("Drop")
+	assert.NoError(t, err)
+	assert.Equal(t, PausedRunPolicyDrop, val)
+
+	val, err = parsePausedRunPolicy("queue")
+	assert.NoError(t, err)
+	assert.Equal(t, PausedRunPolicyQueue, val)
+
+	_, err = parsePausedRunPolicy("retry")
+	assert.Error(t, err)
+}
+
+func TestStore_approvalPolicyParser(t *testing.T) {
+	val, err := parseApprovalPolicy("Sensitive")
+	assert.NoError(t, err)
+	assert.Equal(t, ApprovalPolicySensitive, val)
+
+	val, err = parseApprovalPolicy("all")
+	assert.NoError(t, err)
+	assert.Equal(t, ApprovalPolicyAll, val)
+
+	_, err = parseApprovalPolicy("some")
+	assert.Error(t, err)
+}
+
+func TestConfig_JobApprovalKeys(t *testing.T) {
+	config := NewConfig()
+	assert.Empty(t, config.JobApprovalKeys())
+
+	config.Set("JOB_APPROVAL_KEYS", "0x3CB8e3FD9d27e39a5e9e6852b0e96160061fd4ea, ,0xABCD")
+	assert.Equal(t, []string{"0x3cb8e3fd9d27e39a5e9e6852b0e96160061fd4ea", "0xabcd"}, config.JobApprovalKeys())
+}
+
 func TestStore_urlParser(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,244 @@
+package orm
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Cursor marks the last record of a page, for the next page to start after
+// it. Unlike an offset, a cursor is not thrown off by records created while
+// paging.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        *models.ID
+}
+
+// String returns the cursor encoded for use in a URL.
+func (c Cursor) String() string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID.String())
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor parses a cursor encoded by Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	id, err := models.NewIDFromString(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &Cursor{CreatedAt: time.Unix(0, nanos), ID: id}, nil
+}
+
+// Page selects a page of records, starting after the Cursor if one is given
+// and at the Offset otherwise.
+type Page struct {
+	Offset int
+	Limit  int
+	Cursor *Cursor
+}
+
+// ArchivedFilter selects jobs by whether they are archived.
+type ArchivedFilter string
+
+const (
+	// ExcludeArchived selects the jobs that are not archived
+	ExcludeArchived = ArchivedFilter("exclude")
+	// OnlyArchived selects the jobs that are archived
+	OnlyArchived = ArchivedFilter("only")
+	// IncludeArchived selects jobs whether or not they are archived
+	IncludeArchived = ArchivedFilter("include")
+)
+
+// JobSpecFilter narrows the jobs returned by JobsFiltered. Each field left
+// empty matches every job.
+type JobSpecFilter struct {
+	// Labels matches the jobs that have every one of the labels.
+	Labels        []string
+	InitiatorType string
+	TaskType      string
+	// Bridge matches the jobs with a task that uses the bridge.
+	Bridge   string
+	Archived ArchivedFilter
+}
+
+// JobRunFilter narrows the runs returned by JobRunsFiltered. Each field left
+// empty matches every run.
+type JobRunFilter struct {
+	Statuses      []models.RunStatus
+	JobSpecID     *models.ID
+	InitiatorType string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Requester     *common.Address
+	// TxHash matches the runs requested in the transaction, and those that
+	// sent it.
+	TxHash *common.Hash
+}
+
+// JobsFiltered returns a page of the jobs matching the filter, ordered by
+// creation date, with the number of jobs matching it and the cursor of the
+// next page, if there is one.
+func (orm *ORM) JobsFiltered(filter JobSpecFilter, sort SortType, page Page) ([]models.JobSpec, int, *Cursor, error) {
+	orm.MustEnsureAdvisoryLock()
+	scope := orm.db.Table("job_specs")
+	switch filter.Archived {
+	case OnlyArchived:
+		scope = scope.Where("job_specs.deleted_at IS NOT NULL")
+	case IncludeArchived:
+	default:
+		scope = scope.Where("job_specs.deleted_at IS NULL")
+	}
+	if len(filter.Labels) > 0 {
+		scope = scope.Where("job_specs.labels @> ?", pq.Array(filter.Labels))
+	}
+	if filter.InitiatorType != "" {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM initiators
+			WHERE CAST(initiators.job_spec_id AS uuid) = job_specs.id
+			AND initiators.version = job_specs.version AND initiators.type = ?)`,
+			strings.ToLower(filter.InitiatorType))
+	}
+	if filter.TaskType != "" {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM task_specs
+			WHERE task_specs.job_spec_id = job_specs.id
+			AND task_specs.version = job_specs.version AND task_specs.type = ?)`,
+			strings.ToLower(filter.TaskType))
+	}
+	if filter.Bridge != "" {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM task_specs
+			JOIN bridge_types ON bridge_types.name = task_specs.type
+			WHERE task_specs.job_spec_id = job_specs.id
+			AND task_specs.version = job_specs.version AND task_specs.type = ?)`,
+			strings.ToLower(filter.Bridge))
+	}
+
+	var count int
+	if err := scope.Count(&count).Error; err != nil {
+		return nil, 0, nil, err
+	}
+	ids, next, err := pageOf(scope, "job_specs", sort, page)
+	if err != nil || len(ids) == 0 {
+		return []models.JobSpec{}, count, next, err
+	}
+
+	var jobs []models.JobSpec
+	err = orm.preloadJobs().Unscoped().
+		Where("id IN (?)", ids).
+		Order(fmt.Sprintf("created_at %s, id %s", sort, sort)).
+		Find(&jobs).Error
+	return jobs, count, next, err
+}
+
+// JobRunsFiltered returns a page of the runs matching the filter, ordered by
+// creation date, with the number of runs matching it and the cursor of the
+// next page, if there is one.
+func (orm *ORM) JobRunsFiltered(filter JobRunFilter, sort SortType, page Page) ([]models.JobRun, int, *Cursor, error) {
+	orm.MustEnsureAdvisoryLock()
+	scope := orm.db.Table("job_runs").Where("job_runs.deleted_at IS NULL")
+	if len(filter.Statuses) > 0 {
+		scope = scope.Where("job_runs.status IN (?)", filter.Statuses)
+	}
+	if filter.JobSpecID != nil {
+		scope = scope.Where("job_runs.job_spec_id = ?", filter.JobSpecID)
+	}
+	if filter.InitiatorType != "" {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM initiators
+			WHERE initiators.id = job_runs.initiator_id AND initiators.type = ?)`,
+			strings.ToLower(filter.InitiatorType))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		scope = scope.Where("job_runs.created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		scope = scope.Where("job_runs.created_at < ?", filter.CreatedBefore)
+	}
+	if filter.Requester != nil {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM run_requests
+			WHERE run_requests.id = job_runs.run_request_id AND run_requests.requester = ?)`,
+			*filter.Requester)
+	}
+	if filter.TxHash != nil {
+		scope = scope.Where(`EXISTS (SELECT 1 FROM run_requests
+			WHERE run_requests.id = job_runs.run_request_id AND run_requests.tx_hash = ?)
+			OR EXISTS (SELECT 1 FROM txes JOIN tx_attempts ON tx_attempts.tx_id = txes.id
+			WHERE txes.surrogate_id = replace(CAST(job_runs.id AS text), '-', '') AND tx_attempts.hash = ?)`,
+			*filter.TxHash, *filter.TxHash)
+	}
+
+	var count int
+	if err := scope.Count(&count).Error; err != nil {
+		return nil, 0, nil, err
+	}
+	ids, next, err := pageOf(scope, "job_runs", sort, page)
+	if err != nil || len(ids) == 0 {
+		return []models.JobRun{}, count, next, err
+	}
+
+	var runs []models.JobRun
+	err = orm.preloadJobRuns().
+		Where("id IN (?)", ids).
+		Order(fmt.Sprintf("created_at %s, id %s", sort, sort)).
+		Find(&runs).Error
+	return runs, count, next, err
+}
+
+// pageOf returns the IDs of the page of the table's records selected by the
+// scope, and the cursor of the next page, if there is one.
+func pageOf(scope *gorm.DB, table string, sort SortType, page Page) ([]string, *Cursor, error) {
+	if page.Cursor != nil {
+		comparison := ">"
+		if sort == Descending {
+			comparison = "<"
+		}
+		scope = scope.Where(
+			fmt.Sprintf("(%s.created_at, %s.id) %s (?, ?)", table, table, comparison),
+			page.Cursor.CreatedAt, page.Cursor.ID)
+	} else {
+		scope = scope.Offset(page.Offset)
+	}
+
+	var rows []struct {
+		ID        *models.ID
+		CreatedAt time.Time
+	}
+	err := scope.
+		Select(fmt.Sprintf("%s.id, %s.created_at", table, table)).
+		Order(fmt.Sprintf("%s.created_at %s, %s.id %s", table, sort, table, sort)).
+		Limit(page.Limit + 1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID.String()
+	}
+	return ids, next, nil
+}
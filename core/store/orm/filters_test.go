@@ -0,0 +1,27 @@
+package orm_test
+
+import (
+	"testing"
+	"time"
+
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := orm.Cursor{CreatedAt: time.Now(), ID: models.NewID()}
+	parsed, err := orm.ParseCursor(cursor.String())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(parsed.CreatedAt))
+	assert.Equal(t, cursor.ID, parsed.ID)
+
+	for _, invalid := range []string{"", "!", "MTIz", "YWJjOmRlZg"} {
+		_, err := orm.ParseCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
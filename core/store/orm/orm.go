@@ -2,8 +2,10 @@ package orm
 
 import (
 	"crypto/subtle"
+	"database/sql"
 	"encoding"
 	"fmt"
+	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -25,6 +27,7 @@ import (
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v3"
 )
 
 // BatchSize is the safe number of records to cache during Batch calls for
@@ -54,6 +57,9 @@ type ORM struct {
 	advisoryLockTimeout time.Duration
 	dialectName         DialectName
 	closeOnce           sync.Once
+
+	jobRunListener      func(models.JobRun)
+	jobRunListenerMutex sync.RWMutex
 }
 
 var (
@@ -228,6 +234,89 @@ func (orm *ORM) FindJob(id *models.ID) (models.JobSpec, error) {
 	return job, orm.preloadJobs().First(&job, "id = ?", id).Error
 }
 
+// JobRunInitiatorsFor returns the jobrun initiators of the current versions of
+// jobs that are triggered by runs of the given job.
+func (orm *ORM) JobRunInitiatorsFor(jobSpecID *models.ID) ([]models.Initiator, error) {
+	orm.MustEnsureAdvisoryLock()
+	var initrs []models.Initiator
+	err := orm.db.
+		Where("type = ? AND upstream_job_id = ?", models.InitiatorJobRun, jobSpecID).
+		Where("initiators.version = (SELECT version FROM job_specs WHERE job_specs.id = CAST(initiators.job_spec_id AS uuid))").
+		Order("id asc").
+		Find(&initrs).Error
+	return initrs, err
+}
+
+// JobRunExistsAt returns whether the blockinterval initiator, or one with the
+// same interval and offset of a version the job was updated from, has created
+// a run at the given creation height.
+func (orm *ORM) JobRunExistsAt(initr models.Initiator, creationHeight *big.Int) (bool, error) {
+	orm.MustEnsureAdvisoryLock()
+	var count int
+	err := orm.db.Unscoped().
+		Model(&models.JobRun{}).
+		Joins("JOIN initiators ON initiators.id = job_runs.initiator_id").
+		Where("job_runs.job_spec_id = ? AND job_runs.creation_height = ?", initr.JobSpecID, utils.NewBig(creationHeight)).
+		Where("initiators.type = ? AND initiators.every = ? AND initiators.block_offset = ?", initr.Type, initr.Every, initr.Offset).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// LastJobRunCreatedAt returns when an initiator of the given type last
+// created a run of the job, if one has. Initiators of every version of the
+// job count, as each version has its own.
+func (orm *ORM) LastJobRunCreatedAt(jobSpecID *models.ID, initiatorType string) (null.Time, error) {
+	orm.MustEnsureAdvisoryLock()
+	var run models.JobRun
+	err := orm.db.Unscoped().
+		Joins("JOIN initiators ON initiators.id = job_runs.initiator_id").
+		Where("job_runs.job_spec_id = ? AND initiators.type = ?", jobSpecID, initiatorType).
+		Order("job_runs.created_at desc").
+		First(&run).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return null.Time{}, nil
+	}
+	return null.TimeFrom(run.CreatedAt), err
+}
+
+// FindBalanceWatch returns the balance the initiator last saw at the address.
+func (orm *ORM) FindBalanceWatch(initiatorID uint, address common.Address) (models.BalanceWatch, error) {
+	orm.MustEnsureAdvisoryLock()
+	var watch models.BalanceWatch
+	return watch, orm.db.First(&watch, "initiator_id = ? AND address = ?", initiatorID, address).Error
+}
+
+// SaveBalanceWatch saves the balance the initiator last saw at an address.
+func (orm *ORM) SaveBalanceWatch(watch *models.BalanceWatch) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Save(watch).Error
+}
+
+// FindFluxMonitorState returns what the flux monitor initiator last did.
+func (orm *ORM) FindFluxMonitorState(initiatorID uint) (models.FluxMonitorState, error) {
+	orm.MustEnsureAdvisoryLock()
+	var state models.FluxMonitorState
+	return state, orm.db.First(&state, "initiator_id = ?", initiatorID).Error
+}
+
+// FluxMonitorStatesFor returns what each of the flux monitor initiators of the
+// job's current version last did.
+func (orm *ORM) FluxMonitorStatesFor(jobSpecID *models.ID) ([]models.FluxMonitorState, error) {
+	orm.MustEnsureAdvisoryLock()
+	var states []models.FluxMonitorState
+	return states, orm.db.
+		Where("job_spec_id = ?", jobSpecID).
+		Where("initiator_id IN (SELECT initiators.id FROM initiators JOIN job_specs ON job_specs.id = CAST(initiators.job_spec_id AS uuid) WHERE initiators.version = job_specs.version)").
+		Order("initiator_id asc").
+		Find(&states).Error
+}
+
+// SaveFluxMonitorState saves what the flux monitor initiator last did.
+func (orm *ORM) SaveFluxMonitorState(state *models.FluxMonitorState) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Save(state).Error
+}
+
 // FindInitiator returns the single initiator defined by the passed ID.
 func (orm *ORM) FindInitiator(ID uint) (models.Initiator, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -237,13 +326,28 @@ func (orm *ORM) FindInitiator(ID uint) (models.Initiator, error) {
 		First(&initr, "id = ?", ID).Error
 }
 
+// IsInitiatorRetired returns true if the initiator was soft deleted, as the
+// job it belongs to was updated or archived, or could not be found.
+func (orm *ORM) IsInitiatorRetired(ID uint) bool {
+	orm.MustEnsureAdvisoryLock()
+	var count int
+	err := orm.db.Model(&models.Initiator{}).Where("id = ?", ID).Count(&count).Error
+	return err != nil || count == 0
+}
+
+// preloadJobs loads the initiators and tasks of each job's current version,
+// which are soft deleted along with archived jobs.
 func (orm *ORM) preloadJobs() *gorm.DB {
 	return orm.db.
 		Preload("Initiators", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped().Order(`"id" asc`)
+			return db.Unscoped().
+				Where("initiators.version = (SELECT version FROM job_specs WHERE job_specs.id = CAST(initiators.job_spec_id AS uuid))").
+				Order(`"id" asc`)
 		}).
 		Preload("Tasks", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped().Order("id asc")
+			return db.Unscoped().
+				Where("task_specs.version = (SELECT version FROM job_specs WHERE job_specs.id = task_specs.job_spec_id)").
+				Order("id asc")
 		})
 }
 
@@ -305,8 +409,12 @@ func (orm *ORM) AllSyncEvents(cb func(*models.SyncEvent) error) error {
 // Encourages the use of transactions for gorm calls that translate
 // into multiple sql calls, i.e. orm.SaveJobRun(run), which are better suited
 // in a database transaction.
+// Within Transaction the callback joins the transaction already begun.
 func (orm *ORM) convenientTransaction(callback func(*gorm.DB) error) error {
 	orm.MustEnsureAdvisoryLock()
+	if _, ok := orm.db.CommonDB().(*sql.Tx); ok {
+		return callback(orm.db)
+	}
 	dbtx := orm.db.Begin()
 	if dbtx.Error != nil {
 		return dbtx.Error
@@ -321,6 +429,39 @@ func (orm *ORM) convenientTransaction(callback func(*gorm.DB) error) error {
 	return dbtx.Commit().Error
 }
 
+// Transaction calls fn with an ORM making every call in a single database
+// transaction, which is committed if fn returns nil and rolled back
+// otherwise. Job runs saved with it are passed to the job run listener once
+// the transaction is committed.
+func (orm *ORM) Transaction(fn func(*ORM) error) error {
+	var saved []models.JobRun
+	err := orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		return fn(&ORM{
+			db:                  dbtx,
+			lockingStrategy:     orm.lockingStrategy,
+			advisoryLockTimeout: orm.advisoryLockTimeout,
+			dialectName:         orm.dialectName,
+			jobRunListener: func(run models.JobRun) {
+				saved = append(saved, run)
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, run := range saved {
+		orm.notifyJobRunListener(run)
+	}
+	return nil
+}
+
+// LockJobSpec locks the job's row until the end of the transaction, so that
+// the transactions counting and creating the job's runs take turns.
+func (orm *ORM) LockJobSpec(id *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Exec("SELECT id FROM job_specs WHERE id = ? FOR UPDATE", id).Error
+}
+
 // OptimisticUpdateConflictError is returned when a record update failed
 // because another update occurred while the model was in memory and the
 // differences must be reconciled.
@@ -329,7 +470,7 @@ var OptimisticUpdateConflictError = errors.New("conflict while updating record")
 // SaveJobRun updates UpdatedAt for a JobRun and saves it
 func (orm *ORM) SaveJobRun(run *models.JobRun) error {
 	orm.MustEnsureAdvisoryLock()
-	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+	err := orm.convenientTransaction(func(dbtx *gorm.DB) error {
 		result := dbtx.Unscoped().
 			Model(run).
 			Where("updated_at = ?", run.UpdatedAt).
@@ -340,12 +481,37 @@ func (orm *ORM) SaveJobRun(run *models.JobRun) error {
 		}
 		return result.Error
 	})
+	if err == nil {
+		orm.notifyJobRunListener(*run)
+	}
+	return err
 }
 
 // CreateJobRun inserts a new JobRun
 func (orm *ORM) CreateJobRun(run *models.JobRun) error {
 	orm.MustEnsureAdvisoryLock()
-	return orm.db.Create(run).Error
+	err := orm.db.Create(run).Error
+	if err == nil {
+		orm.notifyJobRunListener(*run)
+	}
+	return err
+}
+
+// SetJobRunListener sets the function called with each job run created or
+// saved, once it has been committed. It replaces any previous listener, and
+// nil removes it.
+func (orm *ORM) SetJobRunListener(listener func(models.JobRun)) {
+	orm.jobRunListenerMutex.Lock()
+	defer orm.jobRunListenerMutex.Unlock()
+	orm.jobRunListener = listener
+}
+
+func (orm *ORM) notifyJobRunListener(run models.JobRun) {
+	orm.jobRunListenerMutex.RLock()
+	defer orm.jobRunListenerMutex.RUnlock()
+	if orm.jobRunListener != nil {
+		orm.jobRunListener(run)
+	}
 }
 
 // LinkEarnedFor shows the total link earnings for a job
@@ -372,6 +538,9 @@ func (orm *ORM) LinkEarnedFor(spec *models.JobSpec) (*assets.Link, error) {
 // CreateExternalInitiator inserts a new external initiator
 func (orm *ORM) CreateExternalInitiator(externalInitiator *models.ExternalInitiator) error {
 	orm.MustEnsureAdvisoryLock()
+	if externalInitiator.Status == "" {
+		externalInitiator.Status = models.ExternalInitiatorStatusUnknown
+	}
 	err := orm.db.Create(externalInitiator).Error
 	return mapError(err)
 }
@@ -404,6 +573,92 @@ func (orm *ORM) FindExternalInitiatorByName(iname string) (models.ExternalInitia
 	return exi, orm.db.First(&exi, "lower(name) = lower(?)", iname).Error
 }
 
+// ExternalInitiators returns all external initiators, ordered by name.
+func (orm *ORM) ExternalInitiators() ([]models.ExternalInitiator, error) {
+	orm.MustEnsureAdvisoryLock()
+	var exis []models.ExternalInitiator
+	return exis, orm.db.Order("name asc").Find(&exis).Error
+}
+
+// ExternalInitiatorsPaginated returns a page of external initiators, ordered
+// by name, and how many there are in all.
+func (orm *ORM) ExternalInitiatorsPaginated(offset, limit int) ([]models.ExternalInitiator, int, error) {
+	orm.MustEnsureAdvisoryLock()
+	count, err := orm.CountOf(&models.ExternalInitiator{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var exis []models.ExternalInitiator
+	err = orm.getRecords(&exis, "name asc", offset, limit)
+	return exis, count, err
+}
+
+// UpdateExternalInitiatorHealth records the result of the external
+// initiator's health check.
+func (orm *ORM) UpdateExternalInitiatorHealth(name, status, checkError string, checkedAt time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Model(&models.ExternalInitiator{}).
+		Where("name = ?", name).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"check_error": checkError,
+			"checked_at":  checkedAt,
+		}).Error
+}
+
+// CreateExternalInitiatorNotification adds the notification to the outbox.
+func (orm *ORM) CreateExternalInitiatorNotification(notification *models.ExternalInitiatorNotification) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(notification).Error
+}
+
+// SaveExternalInitiatorNotification saves the outcome of an attempt to send
+// the notification.
+func (orm *ORM) SaveExternalInitiatorNotification(notification *models.ExternalInitiatorNotification) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Save(notification).Error
+}
+
+// PendingExternalInitiatorNotifications returns the notifications that have
+// neither been sent nor failed, oldest first.
+func (orm *ORM) PendingExternalInitiatorNotifications() ([]models.ExternalInitiatorNotification, error) {
+	orm.MustEnsureAdvisoryLock()
+	var notifications []models.ExternalInitiatorNotification
+	return notifications, orm.db.
+		Where("sent_at IS NULL AND failed_at IS NULL").
+		Order("id asc").
+		Find(&notifications).Error
+}
+
+// CountPendingExternalInitiatorNotifications returns how many notifications
+// to the external initiator have neither been sent nor failed.
+func (orm *ORM) CountPendingExternalInitiatorNotifications(name string) (int, error) {
+	orm.MustEnsureAdvisoryLock()
+	var count int
+	return count, orm.db.Model(&models.ExternalInitiatorNotification{}).
+		Where("external_initiator_name = ? AND sent_at IS NULL AND failed_at IS NULL", name).
+		Count(&count).Error
+}
+
+// ServiceAgreements returns all service agreements with their jobs, oldest
+// first.
+func (orm *ORM) ServiceAgreements() ([]models.ServiceAgreement, error) {
+	orm.MustEnsureAdvisoryLock()
+	var sas []models.ServiceAgreement
+	err := orm.db.Set("gorm:auto_preload", true).Order("created_at asc").Find(&sas).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range sas {
+		sas[i].JobSpec, err = orm.Unscoped().FindJob(sas[i].JobSpecID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find job for service agreement %s", sas[i].ID)
+		}
+	}
+	return sas, nil
+}
+
 // FindServiceAgreement looks up a ServiceAgreement by its ID.
 func (orm *ORM) FindServiceAgreement(id string) (models.ServiceAgreement, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -419,7 +674,7 @@ func (orm *ORM) Jobs(cb func(*models.JobSpec) bool, initrTypes ...string) error
 		if len(initrTypes) > 0 {
 			scope = scope.Where("initiators.type IN (?)", initrTypes)
 			if dbutil.IsPostgres(orm.db) {
-				scope = scope.Joins("JOIN initiators ON job_specs.id = initiators.job_spec_id::uuid")
+				scope = scope.Joins("JOIN initiators ON job_specs.id = initiators.job_spec_id::uuid AND initiators.version = job_specs.version AND initiators.deleted_at IS NULL")
 			} else {
 				scope = scope.Joins("JOIN initiators ON job_specs.id = initiators.job_spec_id")
 			}
@@ -519,6 +774,13 @@ func (orm *ORM) SetConfigValue(field string, value encoding.TextMarshaler) error
 		FirstOrCreate(&models.Configuration{}).Error
 }
 
+// ConfigValues returns all configuration entries set with SetConfigValue.
+func (orm *ORM) ConfigValues() ([]models.Configuration, error) {
+	orm.MustEnsureAdvisoryLock()
+	var configs []models.Configuration
+	return configs, orm.db.Order("name asc").Find(&configs).Error
+}
+
 // CreateJob saves a job to the database and adds IDs to associated tables.
 func (orm *ORM) CreateJob(job *models.JobSpec) error {
 	orm.MustEnsureAdvisoryLock()
@@ -529,11 +791,246 @@ func (orm *ORM) CreateJob(job *models.JobSpec) error {
 
 func (orm *ORM) createJob(tx *gorm.DB, job *models.JobSpec) error {
 	orm.MustEnsureAdvisoryLock()
+	if job.Version == 0 {
+		job.Version = 1
+	}
+	if !job.Proposed() {
+		job.ApprovedVersion = job.Version
+	}
 	for i := range job.Initiators {
 		job.Initiators[i].JobSpecID = job.ID
+		job.Initiators[i].Version = job.Version
+	}
+	for i := range job.Tasks {
+		job.Tasks[i].Version = job.Version
+	}
+
+	if err := tx.Create(job).Error; err != nil {
+		return err
+	}
+	return createJobSpecVersion(tx, *job)
+}
+
+func createJobSpecVersion(tx *gorm.DB, job models.JobSpec) error {
+	version, err := models.NewJobSpecVersion(job)
+	if err != nil {
+		return errors.Wrap(err, "failed to snapshot job spec version")
+	}
+	return tx.Create(&version).Error
+}
+
+// UpdateJob saves the job as a new version of the existing job with its ID.
+// The tasks of the current version are soft deleted and replaced by the
+// job's, so that runs already created finish on the version they were created
+// from, and a paused job stays paused. The current version's initiators keep
+// running the job until RetireInitiators is called, once the new version's
+// have been subscribed to in their place.
+// OptimisticUpdateConflictError is returned if the job was updated
+// concurrently.
+func (orm *ORM) UpdateJob(job *models.JobSpec) error {
+	orm.MustEnsureAdvisoryLock()
+	current, err := orm.FindJob(job.ID)
+	if err != nil {
+		return err
+	}
+
+	job.CreatedAt = current.CreatedAt
+	job.PausedAt = current.PausedAt
+	job.Version = current.Version + 1
+	if job.Proposed() {
+		job.ApprovedVersion = current.ApprovedVersion
+	} else {
+		job.ApprovedVersion = job.Version
+	}
+	if job.SpecTemplateID == nil {
+		job.SpecTemplateID = current.SpecTemplateID
+		job.SpecTemplateVersion = current.SpecTemplateVersion
+		job.TemplateVariables = current.TemplateVariables
+	}
+	for i := range job.Initiators {
+		initr := &job.Initiators[i]
+		initr.ID = 0
+		initr.JobSpecID = job.ID
+		initr.Version = job.Version
+		initr.Ran = ranBefore(current, *initr)
+	}
+	for i := range job.Tasks {
+		job.Tasks[i].ID = 0
+		job.Tasks[i].JobSpecID = job.ID
+		job.Tasks[i].Version = job.Version
+	}
+
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		result := dbtx.Model(&models.JobSpec{}).
+			Where("id = ? AND version = ?", job.ID, current.Version).
+			Updates(map[string]interface{}{
+				"version":               job.Version,
+				"name":                  job.Name,
+				"description":           job.Description,
+				"labels":                job.Labels,
+				"start_at":              job.StartAt,
+				"end_at":                job.EndAt,
+				"min_payment":           job.MinPayment,
+				"max_runs":              job.MaxRuns,
+				"max_runs_window":       job.MaxRunsWindow,
+				"max_daily_gas_spend":   job.MaxDailyGasSpend,
+				"spec_template_id":      job.SpecTemplateID,
+				"spec_template_version": job.SpecTemplateVersion,
+				"template_variables":    job.TemplateVariables,
+				"proposed_at":           job.ProposedAt,
+				"proposed_by":           job.ProposedBy,
+				"approved_version":      job.ApprovedVersion,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return OptimisticUpdateConflictError
+		}
+
+		// Jobs created before versioning have no snapshot of their first version
+		err := dbtx.Where(models.JobSpecVersion{JobSpecID: current.ID, Version: current.Version}).
+			First(&models.JobSpecVersion{}).Error
+		if err == gorm.ErrRecordNotFound {
+			err = createJobSpecVersion(dbtx, current)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := dbtx.Where("job_spec_id = ?", job.ID).Delete(&models.TaskSpec{}).Error; err != nil {
+			return err
+		}
+		for i := range job.Initiators {
+			if err := dbtx.Create(&job.Initiators[i]).Error; err != nil {
+				return err
+			}
+		}
+		for i := range job.Tasks {
+			if err := dbtx.Create(&job.Tasks[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := carryInitiatorState(dbtx, current, *job); err != nil {
+			return err
+		}
+		return createJobSpecVersion(dbtx, *job)
+	})
+}
+
+// carryInitiatorState copies the balances watched and flux monitor state of
+// the current version's initiators to the new version's initiators taking
+// over from them: those of the same type in the same position among the
+// initiators of their type.
+func carryInitiatorState(tx *gorm.DB, current, job models.JobSpec) error {
+	positions := map[string]int{}
+	for _, initr := range job.Initiators {
+		position := positions[initr.Type]
+		positions[initr.Type]++
+		previous := current.InitiatorsFor(initr.Type)
+		if position >= len(previous) {
+			continue
+		}
+		from := previous[position].ID
+
+		var watches []models.BalanceWatch
+		if err := tx.Where("initiator_id = ?", from).Find(&watches).Error; err != nil {
+			return err
+		}
+		for i := range watches {
+			watches[i].InitiatorID = initr.ID
+			if err := tx.Create(&watches[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		var state models.FluxMonitorState
+		err := tx.First(&state, "initiator_id = ?", from).Error
+		if err == gorm.ErrRecordNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		state.InitiatorID = initr.ID
+		if err := tx.Create(&state).Error; err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return tx.Create(job).Error
+// RetireInitiators soft deletes the initiators of the versions the job was
+// updated from, so that they can no longer run it.
+func (orm *ORM) RetireInitiators(jobSpecID *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.
+		Where("job_spec_id = ? AND version < (SELECT version FROM job_specs WHERE job_specs.id = CAST(initiators.job_spec_id AS uuid))", jobSpecID).
+		Delete(&models.Initiator{}).Error
+}
+
+// ranBefore returns true if a runat initiator of the job's current version
+// has already run at the same time as the given initiator.
+func ranBefore(current models.JobSpec, initr models.Initiator) bool {
+	if initr.Type != models.InitiatorRunAt {
+		return false
+	}
+	for _, previous := range current.InitiatorsFor(models.InitiatorRunAt) {
+		if previous.Ran && previous.Time.Time.Equal(initr.Time.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+// JobSpecVersions returns the snapshots of each version of a job, oldest
+// first. Jobs created before versioning that have not been updated since are
+// given a snapshot of their current version.
+func (orm *ORM) JobSpecVersions(jobSpecID *models.ID) ([]models.JobSpecVersion, error) {
+	orm.MustEnsureAdvisoryLock()
+	versions := []models.JobSpecVersion{}
+	err := orm.db.
+		Where("job_spec_id = ?", jobSpecID).
+		Order("version asc").
+		Find(&versions).Error
+	if err != nil || len(versions) > 0 {
+		return versions, err
+	}
+
+	version, err := orm.currentJobSpecVersion(jobSpecID)
+	if err != nil {
+		return nil, err
+	}
+	return append(versions, version), nil
+}
+
+func (orm *ORM) currentJobSpecVersion(jobSpecID *models.ID) (models.JobSpecVersion, error) {
+	job, err := orm.Unscoped().FindJob(jobSpecID)
+	if err != nil {
+		return models.JobSpecVersion{}, err
+	}
+	version, err := models.NewJobSpecVersion(job)
+	version.CreatedAt = job.CreatedAt
+	return version, err
+}
+
+// FindJobSpecVersion returns the snapshot of a version of a job, falling back
+// to the current version as JobSpecVersions does.
+func (orm *ORM) FindJobSpecVersion(jobSpecID *models.ID, version uint32) (models.JobSpecVersion, error) {
+	orm.MustEnsureAdvisoryLock()
+	var v models.JobSpecVersion
+	err := orm.db.First(&v, "job_spec_id = ? AND version = ?", jobSpecID, version).Error
+	if err != gorm.ErrRecordNotFound {
+		return v, err
+	}
+
+	current, err := orm.currentJobSpecVersion(jobSpecID)
+	if err != nil {
+		return v, err
+	}
+	if current.Version != version {
+		return v, ErrorNotFound
+	}
+	return current, nil
 }
 
 // ArchiveJob soft deletes the job and its associated job runs.
@@ -554,6 +1051,258 @@ func (orm *ORM) ArchiveJob(ID *models.ID) error {
 	})
 }
 
+// CreateSpecTemplate saves the job spec template.
+func (orm *ORM) CreateSpecTemplate(template *models.SpecTemplate) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(template).Error
+}
+
+// FindSpecTemplate returns the job spec template with the given ID.
+func (orm *ORM) FindSpecTemplate(ID *models.ID) (models.SpecTemplate, error) {
+	orm.MustEnsureAdvisoryLock()
+	var template models.SpecTemplate
+	return template, orm.db.First(&template, "id = ?", ID).Error
+}
+
+// FindSpecTemplateByName returns the job spec template with the given name.
+func (orm *ORM) FindSpecTemplateByName(name string) (models.SpecTemplate, error) {
+	orm.MustEnsureAdvisoryLock()
+	var template models.SpecTemplate
+	return template, orm.db.First(&template, "name = ?", name).Error
+}
+
+// SpecTemplates returns job spec templates ordered by name.
+func (orm *ORM) SpecTemplates(offset int, limit int) ([]models.SpecTemplate, int, error) {
+	orm.MustEnsureAdvisoryLock()
+	count, err := orm.CountOf(&models.SpecTemplate{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var templates []models.SpecTemplate
+	err = orm.getRecords(&templates, "name asc", offset, limit)
+	return templates, count, err
+}
+
+// UpdateSpecTemplate saves the template as its next version.
+// OptimisticUpdateConflictError is returned if the template was updated
+// concurrently.
+func (orm *ORM) UpdateSpecTemplate(template *models.SpecTemplate) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.db.Model(&models.SpecTemplate{}).
+		Where("id = ? AND version = ?", template.ID, template.Version).
+		Updates(map[string]interface{}{
+			"name":       template.Name,
+			"variables":  template.Variables,
+			"spec":       template.Spec,
+			"version":    template.Version + 1,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return OptimisticUpdateConflictError
+	}
+	template.Version++
+	return nil
+}
+
+// SpecTemplateInstances returns the jobs created from the job spec template.
+func (orm *ORM) SpecTemplateInstances(templateID *models.ID) ([]models.JobSpec, error) {
+	orm.MustEnsureAdvisoryLock()
+	var jobs []models.JobSpec
+	err := orm.preloadJobs().
+		Where("spec_template_id = ?", templateID).
+		Order("created_at asc").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// PauseJob marks the job as paused, keeping the time it was first paused at
+// if it already is.
+func (orm *ORM) PauseJob(ID *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.db.Model(&models.JobSpec{}).
+		Where("id = ?", ID).
+		Update("paused_at", gorm.Expr("COALESCE(paused_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// ErrorJobNotPaused is returned when resuming a job that is not paused.
+var ErrorJobNotPaused = errors.New("job is not paused")
+
+// ResumeJob clears the job's paused mark, returning ErrorJobNotPaused if it
+// is not paused.
+func (orm *ORM) ResumeJob(ID *models.ID) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.db.Model(&models.JobSpec{}).
+		Where("id = ? AND paused_at IS NOT NULL", ID).
+		Update("paused_at", gorm.Expr("NULL"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := orm.FindJob(ID); err != nil {
+			return err
+		}
+		return ErrorJobNotPaused
+	}
+	return nil
+}
+
+// QueuedJobRuns returns the job's runs that were queued, unstarted, while it
+// was paused, oldest first.
+func (orm *ORM) QueuedJobRuns(jobSpecID *models.ID) ([]models.JobRun, error) {
+	orm.MustEnsureAdvisoryLock()
+	var runs []models.JobRun
+	err := orm.preloadJobRuns().
+		Where("job_spec_id = ? AND status = ?", jobSpecID, models.RunStatusUnstarted).
+		Order("created_at asc").
+		Find(&runs).Error
+	return runs, err
+}
+
+// ApproveJob approves the job's proposed version, clearing its proposed mark
+// and recording the approval in its audit trail. OptimisticUpdateConflictError
+// is returned if the version is no longer the job's current one, and
+// ErrorJobNotProposed if it is not awaiting approval.
+func (orm *ORM) ApproveJob(ID *models.ID, version uint32, actor, reason string) error {
+	orm.MustEnsureAdvisoryLock()
+	job, err := orm.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if err := checkProposed(orm.db, job, version); err != nil {
+		return err
+	}
+
+	return orm.convenientTransaction(func(dbtx *gorm.DB) error {
+		result := dbtx.Model(&models.JobSpec{}).
+			Where("id = ? AND version = ? AND proposed_at IS NOT NULL", ID, version).
+			Updates(map[string]interface{}{
+				"proposed_at":      gorm.Expr("NULL"),
+				"approved_version": version,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return OptimisticUpdateConflictError
+		}
+		return dbtx.Create(&models.JobApprovalEvent{
+			JobSpecID: ID,
+			Version:   version,
+			Action:    models.JobApprovalApproved,
+			Actor:     actor,
+			Detail:    reason,
+		}).Error
+	})
+}
+
+// RejectJob records the rejection of the job's proposed version in its audit
+// trail. The job stays proposed, and its initiators stopped, until it is
+// updated to a new version or archived.
+func (orm *ORM) RejectJob(ID *models.ID, version uint32, actor, reason string) error {
+	orm.MustEnsureAdvisoryLock()
+	job, err := orm.FindJob(ID)
+	if err != nil {
+		return err
+	}
+	if err := checkProposed(orm.db, job, version); err != nil {
+		return err
+	}
+
+	return orm.db.Create(&models.JobApprovalEvent{
+		JobSpecID: ID,
+		Version:   version,
+		Action:    models.JobApprovalRejected,
+		Actor:     actor,
+		Detail:    reason,
+	}).Error
+}
+
+// ErrorJobNotProposed is returned when deciding on a version of a job that is
+// not awaiting approval.
+var ErrorJobNotProposed = errors.New("job version is not awaiting approval")
+
+func checkProposed(db *gorm.DB, job models.JobSpec, version uint32) error {
+	if job.Version != version {
+		return OptimisticUpdateConflictError
+	}
+	if !job.Proposed() {
+		return ErrorJobNotProposed
+	}
+	var rejections int
+	err := db.Model(&models.JobApprovalEvent{}).
+		Where("job_spec_id = ? AND version = ? AND action = ?", job.ID, version, models.JobApprovalRejected).
+		Count(&rejections).Error
+	if err != nil {
+		return err
+	}
+	if rejections > 0 {
+		return ErrorJobNotProposed
+	}
+	return nil
+}
+
+// CreateJobApprovalEvent records a step in the approval of a job.
+func (orm *ORM) CreateJobApprovalEvent(event *models.JobApprovalEvent) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(event).Error
+}
+
+// JobApprovalEvents returns the audit trail of the job's approvals, oldest
+// first.
+func (orm *ORM) JobApprovalEvents(jobSpecID *models.ID) ([]models.JobApprovalEvent, error) {
+	orm.MustEnsureAdvisoryLock()
+	events := []models.JobApprovalEvent{}
+	err := orm.db.
+		Where("job_spec_id = ?", jobSpecID).
+		Order("created_at asc, id asc").
+		Find(&events).Error
+	return events, err
+}
+
+// CreateJobApprover saves an approver of proposed jobs.
+func (orm *ORM) CreateJobApprover(approver *models.JobApprover) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(approver).Error
+}
+
+// FindJobApprover looks up an approver by their email.
+func (orm *ORM) FindJobApprover(email string) (models.JobApprover, error) {
+	orm.MustEnsureAdvisoryLock()
+	var approver models.JobApprover
+	return approver, orm.db.First(&approver, "email = ?", email).Error
+}
+
+// JobApprovers returns every approver of proposed jobs, ordered by email.
+func (orm *ORM) JobApprovers() ([]models.JobApprover, error) {
+	orm.MustEnsureAdvisoryLock()
+	approvers := []models.JobApprover{}
+	return approvers, orm.db.Order("email asc").Find(&approvers).Error
+}
+
+// DeleteJobApprover removes an approver of proposed jobs.
+func (orm *ORM) DeleteJobApprover(email string) error {
+	orm.MustEnsureAdvisoryLock()
+	result := orm.db.Where("email = ?", email).Delete(&models.JobApprover{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
 // CreateServiceAgreement saves a Service Agreement, its JobSpec and its
 // associations to the database.
 func (orm *ORM) CreateServiceAgreement(sa *models.ServiceAgreement) error {
@@ -694,6 +1443,15 @@ func (orm *ORM) FindTx(ID uint64) (*models.Tx, error) {
 	return tx, err
 }
 
+// FindTxBySurrogateID returns the transaction with the surrogate ID, such as
+// that of the job run that created it.
+func (orm *ORM) FindTxBySurrogateID(surrogateID string) (*models.Tx, error) {
+	orm.MustEnsureAdvisoryLock()
+	tx := &models.Tx{}
+	err := orm.db.First(tx, "surrogate_id = ?", surrogateID).Error
+	return tx, err
+}
+
 // FindAllTxsInNonceRange returns an array of transactions matching the inclusive range between beginningNonce and endingNonce
 func (orm *ORM) FindAllTxsInNonceRange(beginningNonce uint, endingNonce uint) ([]models.Tx, error) {
 	orm.MustEnsureAdvisoryLock()
@@ -1008,6 +1766,13 @@ func (orm *ORM) BridgeTypes(offset int, limit int) ([]models.BridgeType, int, er
 	return bridges, count, err
 }
 
+// AllBridgeTypes returns all bridge types, ordered by name.
+func (orm *ORM) AllBridgeTypes() ([]models.BridgeType, error) {
+	orm.MustEnsureAdvisoryLock()
+	var bridges []models.BridgeType
+	return bridges, orm.db.Order("name asc").Find(&bridges).Error
+}
+
 // SaveUser saves the user.
 func (orm *ORM) SaveUser(user *models.User) error {
 	orm.MustEnsureAdvisoryLock()
@@ -1265,3 +2030,70 @@ func Batch(chunkSize uint, cb func(offset, limit uint) (uint, error)) error {
 		offset += limit
 	}
 }
+
+// CreateHTTPAuditRecord saves a raw HTTP request and response made for a
+// task run.
+func (orm *ORM) CreateHTTPAuditRecord(record *models.HTTPAuditRecord) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Create(record).Error
+}
+
+// HTTPAuditRecordsFor returns the HTTP audit trail of every task in the job
+// run, oldest first.
+func (orm *ORM) HTTPAuditRecordsFor(runID *models.ID) ([]models.HTTPAuditRecord, error) {
+	orm.MustEnsureAdvisoryLock()
+	records := []models.HTTPAuditRecord{}
+	err := orm.db.
+		Joins("INNER JOIN task_runs ON task_runs.id = http_audit_records.task_run_id").
+		Where("task_runs.job_run_id = ?", runID).
+		Order("http_audit_records.created_at asc, http_audit_records.id asc").
+		Find(&records).Error
+	return records, err
+}
+
+// DeleteHTTPAuditRecordsBefore deletes all HTTP audit records created before
+// the passed time.
+func (orm *ORM) DeleteHTTPAuditRecordsBefore(before time.Time) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.db.Where("created_at < ?", before).Delete(models.HTTPAuditRecord{}).Error
+}
+
+// AdmittedJobRunsCountSince returns the number of the job's runs created
+// since the given time that were admitted for execution. Runs that errored
+// before any of their tasks started, such as those refused by the job's
+// limits, are not counted.
+func (orm *ORM) AdmittedJobRunsCountSince(jobSpecID *models.ID, since time.Time) (int, error) {
+	orm.MustEnsureAdvisoryLock()
+	var count int
+	err := orm.db.Model(&models.JobRun{}).
+		Where("job_spec_id = ? AND created_at >= ?", jobSpecID, since).
+		Where(`status <> ? OR EXISTS (
+			SELECT 1 FROM task_runs
+			WHERE task_runs.job_run_id = job_runs.id AND task_runs.status <> ?
+		)`, models.RunStatusErrored, models.RunStatusUnstarted).
+		Count(&count).Error
+	return count, err
+}
+
+// JobGasSpendSince returns the wei the job's runs have committed to gas in
+// transactions first attempted since the given time. Gas used is not
+// recorded, so each transaction counts at its gas limit and latest gas price.
+func (orm *ORM) JobGasSpendSince(jobSpecID *models.ID, since time.Time) (*big.Int, error) {
+	orm.MustEnsureAdvisoryLock()
+	var txs []models.Tx
+	err := orm.db.
+		Select("gas_price, gas_limit").
+		Where(`surrogate_id IN (SELECT REPLACE(CAST(id AS text), '-', '') FROM job_runs WHERE job_spec_id = ?)`, jobSpecID).
+		Where(`id IN (SELECT tx_id FROM tx_attempts GROUP BY tx_id HAVING MIN(created_at) >= ?)`, since).
+		Find(&txs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	spend := new(big.Int)
+	for _, tx := range txs {
+		cost := new(big.Int).SetUint64(tx.GasLimit)
+		spend.Add(spend, cost.Mul(cost, tx.GasPrice.ToInt()))
+	}
+	return spend, nil
+}
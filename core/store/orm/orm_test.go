@@ -1,6 +1,7 @@
 package orm_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -145,6 +146,254 @@ func TestORM_ArchiveJob(t *testing.T) {
 	require.NoError(t, utils.JustError(orm.FindJobRun(run.ID)))
 }
 
+func TestORM_Transaction(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	missing := cltest.NewJobWithWebInitiator()
+	err := store.Transaction(func(tx *orm.ORM) error {
+		require.NoError(t, tx.CreateJob(&job))
+		return tx.UpdateJob(&missing)
+	})
+	require.Equal(t, orm.ErrorNotFound, err)
+
+	_, err = store.FindJob(job.ID)
+	assert.Equal(t, orm.ErrorNotFound, err)
+
+	err = store.Transaction(func(tx *orm.ORM) error {
+		return tx.CreateJob(&job)
+	})
+	require.NoError(t, err)
+	_, err = store.FindJob(job.ID)
+	assert.NoError(t, err)
+}
+
+func TestORM_UpdateJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	run := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&run))
+
+	update := cltest.NewJobWithSchedule("* * * * *")
+	update.ID = job.ID
+	update.Tasks = []models.TaskSpec{cltest.NewTask(t, "httpget", `{"get": "https://example.com"}`)}
+	require.NoError(t, store.UpdateJob(&update))
+	assert.Equal(t, uint32(2), update.Version)
+
+	updated, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), updated.Version)
+	require.Len(t, updated.Initiators, 1)
+	assert.Equal(t, models.InitiatorCron, updated.Initiators[0].Type)
+	require.Len(t, updated.Tasks, 1)
+	assert.Equal(t, "httpget", updated.Tasks[0].Type.String())
+	assert.False(t, updated.HasInitiator(job.Initiators[0].ID))
+
+	// the first version's initiator runs the job until it is retired
+	assert.False(t, store.IsInitiatorRetired(job.Initiators[0].ID))
+	require.NoError(t, store.RetireInitiators(job.ID))
+	assert.True(t, store.IsInitiatorRetired(job.Initiators[0].ID))
+	assert.False(t, store.IsInitiatorRetired(updated.Initiators[0].ID))
+
+	// runs of the first version keep its initiator and tasks
+	run, err = store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), run.JobSpecVersion)
+	assert.Equal(t, models.InitiatorWeb, run.Initiator.Type)
+	require.Len(t, run.TaskRuns, 1)
+	assert.Equal(t, job.Tasks[0].Type, run.TaskRuns[0].TaskSpec.Type)
+
+	versions, err := store.JobSpecVersions(job.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, uint32(1), versions[0].Version)
+	assert.Equal(t, "web", versions[0].Spec.Get("initiators.0.type").String())
+	assert.Equal(t, uint32(2), versions[1].Version)
+	assert.Equal(t, "cron", versions[1].Spec.Get("initiators.0.type").String())
+
+	again := cltest.NewJobWithWebInitiator()
+	again.ID = job.ID
+	require.NoError(t, store.UpdateJob(&again))
+	assert.Equal(t, uint32(3), again.Version)
+}
+
+func TestORM_UpdateJob_NotFound(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	assert.Equal(t, orm.ErrorNotFound, store.UpdateJob(&job))
+}
+
+func TestORM_UpdateJob_CarriesInitiatorState(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithFluxMonitorInitiator()
+	job.Initiators = append(job.Initiators, models.Initiator{
+		Type:            models.InitiatorBlockInterval,
+		InitiatorParams: models.InitiatorParams{Every: 3, Offset: 1},
+	})
+	require.NoError(t, store.CreateJob(&job))
+	fluxMonitor, blockInterval := job.Initiators[0], job.Initiators[1]
+
+	require.NoError(t, store.SaveFluxMonitorState(&models.FluxMonitorState{
+		InitiatorID:      fluxMonitor.ID,
+		JobSpecID:        job.ID,
+		SubmittedRoundID: 3,
+	}))
+	address := cltest.NewAddress()
+	require.NoError(t, store.SaveBalanceWatch(&models.BalanceWatch{
+		InitiatorID: fluxMonitor.ID,
+		Address:     address,
+		Balance:     utils.NewBig(big.NewInt(1)),
+		Below:       true,
+	}))
+	run := cltest.NewJobRun(job)
+	run.InitiatorID = blockInterval.ID
+	run.CreationHeight = utils.NewBig(big.NewInt(10))
+	require.NoError(t, store.CreateJobRun(&run))
+
+	update := job
+	update.Initiators = []models.Initiator{blockInterval, fluxMonitor}
+	require.NoError(t, store.UpdateJob(&update))
+	updated, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	require.Len(t, updated.Initiators, 2)
+
+	// initiators take over from those in the same position among their type
+	newFluxMonitor := updated.InitiatorsFor(models.InitiatorFluxMonitor)[0]
+	state, err := store.FindFluxMonitorState(newFluxMonitor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), state.SubmittedRoundID)
+	states, err := store.FluxMonitorStatesFor(job.ID)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, newFluxMonitor.ID, states[0].InitiatorID)
+
+	watch, err := store.FindBalanceWatch(newFluxMonitor.ID, address)
+	require.NoError(t, err)
+	assert.True(t, watch.Below)
+
+	newBlockInterval := updated.InitiatorsFor(models.InitiatorBlockInterval)[0]
+	exists, err := store.JobRunExistsAt(newBlockInterval, big.NewInt(10))
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = store.JobRunExistsAt(newBlockInterval, big.NewInt(13))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestORM_ApproveJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	assert.Equal(t, uint32(1), job.ApprovedVersion)
+
+	update := cltest.NewJobWithWebInitiator()
+	update.ID = job.ID
+	update.ProposedAt = null.TimeFrom(time.Now())
+	update.ProposedBy = "proposer@chain.link"
+	require.NoError(t, store.UpdateJob(&update))
+
+	proposed, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, proposed.Proposed())
+	assert.Equal(t, "proposer@chain.link", proposed.ProposedBy)
+	assert.Equal(t, uint32(1), proposed.ApprovedVersion)
+
+	assert.Equal(t, orm.OptimisticUpdateConflictError, store.ApproveJob(job.ID, 1, "approver@chain.link", ""))
+	require.NoError(t, store.ApproveJob(job.ID, 2, "approver@chain.link", "looks good"))
+	assert.Equal(t, orm.ErrorJobNotProposed, store.ApproveJob(job.ID, 2, "approver@chain.link", ""))
+
+	approved, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.False(t, approved.Proposed())
+	assert.Equal(t, uint32(2), approved.ApprovedVersion)
+
+	events, err := store.JobApprovalEvents(job.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.JobApprovalApproved, events[0].Action)
+	assert.Equal(t, "approver@chain.link", events[0].Actor)
+	assert.Equal(t, "looks good", events[0].Detail)
+}
+
+func TestORM_RejectJob(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	job.ProposedAt = null.TimeFrom(time.Now())
+	require.NoError(t, store.CreateJob(&job))
+	assert.Equal(t, uint32(0), job.ApprovedVersion)
+
+	require.NoError(t, store.RejectJob(job.ID, 1, "approver@chain.link", "drains funds"))
+	assert.Equal(t, orm.ErrorJobNotProposed, store.ApproveJob(job.ID, 1, "approver@chain.link", ""))
+
+	rejected, err := store.FindJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, rejected.Proposed())
+}
+
+func TestORM_JobApprovers(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	approver, err := models.NewJobApprover("approver@chain.link", "p4SsW0rD1!@#_")
+	require.NoError(t, err)
+	require.NoError(t, store.CreateJobApprover(&approver))
+
+	found, err := store.FindJobApprover("approver@chain.link")
+	require.NoError(t, err)
+	assert.Equal(t, approver.HashedPassword, found.HashedPassword)
+
+	approvers, err := store.JobApprovers()
+	require.NoError(t, err)
+	assert.Len(t, approvers, 1)
+
+	require.NoError(t, store.DeleteJobApprover("approver@chain.link"))
+	assert.Equal(t, orm.ErrorNotFound, store.DeleteJobApprover("approver@chain.link"))
+}
+
+func TestORM_UpdateSpecTemplate(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	template := models.NewSpecTemplate(models.SpecTemplateRequest{
+		Name: "noop",
+		Spec: cltest.JSONFromString(t, `{"initiators":[{"type":"web"}],"tasks":[{"type":"noop"}]}`),
+	})
+	require.NoError(t, store.CreateSpecTemplate(&template))
+
+	stale := template
+	template.Name = "renamed"
+	require.NoError(t, store.UpdateSpecTemplate(&template))
+	assert.Equal(t, uint32(2), template.Version)
+
+	found, err := store.FindSpecTemplate(template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", found.Name)
+	assert.Equal(t, uint32(2), found.Version)
+	assert.Equal(t, models.SpecTemplateVariables{}, found.Variables)
+
+	assert.Equal(t, orm.OptimisticUpdateConflictError, store.UpdateSpecTemplate(&stale))
+}
+
 func TestORM_CreateJobRun_CreatesRunRequest(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -312,6 +561,149 @@ func TestORM_JobRunsSortedFor(t *testing.T) {
 	assert.Equal(t, []*models.ID{jr2.ID, jr1.ID}, actual)
 }
 
+func TestORM_JobsFiltered(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	_, bt := cltest.NewBridgeType(t, "prices")
+	require.NoError(t, store.CreateBridgeType(bt))
+
+	webJob := cltest.NewJobWithWebInitiator()
+	webJob.Labels = []string{"env:prod", "eth-usd"}
+	webJob.CreatedAt = time.Now().AddDate(0, 0, -2)
+	require.NoError(t, store.CreateJob(&webJob))
+	bridgeJob := cltest.NewJobWithWebInitiator()
+	bridgeJob.Labels = []string{"env:prod"}
+	bridgeJob.Tasks = []models.TaskSpec{{Type: bt.Name}}
+	bridgeJob.CreatedAt = time.Now().AddDate(0, 0, -1)
+	require.NoError(t, store.CreateJob(&bridgeJob))
+	cronJob := cltest.NewJobWithSchedule("9 9 9 9 6")
+	require.NoError(t, store.CreateJob(&cronJob))
+	archivedJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&archivedJob))
+	require.NoError(t, store.ArchiveJob(archivedJob.ID))
+
+	tests := []struct {
+		name   string
+		filter orm.JobSpecFilter
+		want   []*models.ID
+	}{
+		{"none", orm.JobSpecFilter{}, []*models.ID{webJob.ID, bridgeJob.ID, cronJob.ID}},
+		{"label", orm.JobSpecFilter{Labels: []string{"env:prod"}}, []*models.ID{webJob.ID, bridgeJob.ID}},
+		{"labels", orm.JobSpecFilter{Labels: []string{"env:prod", "eth-usd"}}, []*models.ID{webJob.ID}},
+		{"initiator type", orm.JobSpecFilter{InitiatorType: models.InitiatorCron}, []*models.ID{cronJob.ID}},
+		{"task type", orm.JobSpecFilter{TaskType: "prices"}, []*models.ID{bridgeJob.ID}},
+		{"bridge", orm.JobSpecFilter{Bridge: "Prices"}, []*models.ID{bridgeJob.ID}},
+		{"only archived", orm.JobSpecFilter{Archived: orm.OnlyArchived}, []*models.ID{archivedJob.ID}},
+		{"with archived", orm.JobSpecFilter{Archived: orm.IncludeArchived}, []*models.ID{webJob.ID, bridgeJob.ID, cronJob.ID, archivedJob.ID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobs, count, next, err := store.JobsFiltered(test.filter, orm.Ascending, orm.Page{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, len(test.want), count)
+			assert.Nil(t, next)
+			var ids []*models.ID
+			for _, j := range jobs {
+				ids = append(ids, j.ID)
+			}
+			assert.Equal(t, test.want, ids)
+		})
+	}
+}
+
+func TestORM_JobsFiltered_Cursor(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var want []*models.ID
+	for i := 0; i < 5; i++ {
+		j := cltest.NewJobWithWebInitiator()
+		j.CreatedAt = time.Now().AddDate(0, 0, i-5)
+		require.NoError(t, store.CreateJob(&j))
+		want = append(want, j.ID)
+	}
+
+	var got []*models.ID
+	page := orm.Page{Limit: 2}
+	for {
+		jobs, count, next, err := store.JobsFiltered(orm.JobSpecFilter{}, orm.Descending, page)
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+		for _, j := range jobs {
+			got = append(got, j.ID)
+		}
+		if next == nil {
+			break
+		}
+		page.Cursor, err = orm.ParseCursor(next.String())
+		require.NoError(t, err)
+	}
+	for i, j := 0, len(want)-1; i < j; i, j = i+1, j-1 {
+		want[i], want[j] = want[j], want[i]
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestORM_JobRunsFiltered(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+
+	requester := cltest.NewAddress()
+	txHash := cltest.NewHash()
+	requested := cltest.NewJobRun(job)
+	requested.CreatedAt = time.Now().AddDate(0, 0, -2)
+	requested.RunRequest.Requester = &requester
+	requested.RunRequest.TxHash = &txHash
+	require.NoError(t, store.CreateJobRun(&requested))
+	errored := cltest.NewJobRun(job)
+	errored.CreatedAt = time.Now().AddDate(0, 0, -1)
+	errored.SetError(errors.New("failed"))
+	require.NoError(t, store.CreateJobRun(&errored))
+	other := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&other))
+
+	tests := []struct {
+		name   string
+		filter orm.JobRunFilter
+		want   []*models.ID
+	}{
+		{"none", orm.JobRunFilter{}, []*models.ID{requested.ID, errored.ID, other.ID}},
+		{"status", orm.JobRunFilter{Statuses: []models.RunStatus{models.RunStatusErrored}}, []*models.ID{errored.ID}},
+		{"job", orm.JobRunFilter{JobSpecID: otherJob.ID}, []*models.ID{other.ID}},
+		{"initiator type", orm.JobRunFilter{InitiatorType: models.InitiatorWeb}, []*models.ID{requested.ID, errored.ID, other.ID}},
+		{"created after", orm.JobRunFilter{CreatedAfter: time.Now().Add(-36 * time.Hour)}, []*models.ID{errored.ID, other.ID}},
+		{"created before", orm.JobRunFilter{CreatedBefore: time.Now().Add(-36 * time.Hour)}, []*models.ID{requested.ID}},
+		{"requester", orm.JobRunFilter{Requester: &requester}, []*models.ID{requested.ID}},
+		{"tx hash", orm.JobRunFilter{TxHash: &txHash}, []*models.ID{requested.ID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs, count, _, err := store.JobRunsFiltered(test.filter, orm.Ascending, orm.Page{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, len(test.want), count)
+			var ids []*models.ID
+			for _, r := range runs {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, test.want, ids)
+		})
+	}
+}
+
 func TestORM_UnscopedJobRunsWithStatus_Happy(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -1072,6 +1464,28 @@ func TestORM_FindTxsBySenderAndRecipient(t *testing.T) {
 	}
 }
 
+func TestORM_FindTxBySurrogateID(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	runID := models.NewID()
+	tx := cltest.NewTx(cltest.NewAddress(), 1)
+	tx.SurrogateID = null.StringFrom(runID.String())
+	tx, err := store.CreateTx(tx)
+	require.NoError(t, err)
+	cltest.CreateTxWithNonceAndGasPrice(t, store, cltest.NewAddress(), 2, 1, 1)
+
+	found, err := store.FindTxBySurrogateID(runID.String())
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, found.ID)
+	assert.Equal(t, tx.Hash, found.Hash)
+
+	_, err = store.FindTxBySurrogateID(models.NewID().String())
+	assert.True(t, gorm.IsRecordNotFoundError(err))
+}
+
 func TestORM_FindTxAttempt_CurrentAttempt(t *testing.T) {
 	t.Parallel()
 
@@ -1394,6 +1808,39 @@ func TestORM_FindAllTxsInNonceRange(t *testing.T) {
 	assert.Equal(t, "nonce-3", txs[1].SurrogateID.ValueOrZero())
 }
 
+func TestORM_JobGasSpendSince(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&job))
+	jr := cltest.NewJobRun(job)
+	require.NoError(t, store.CreateJobRun(&jr))
+	otherJob := cltest.NewJobWithWebInitiator()
+	require.NoError(t, store.CreateJob(&otherJob))
+	otherJR := cltest.NewJobRun(otherJob)
+	require.NoError(t, store.CreateJobRun(&otherJR))
+
+	for i, run := range []models.JobRun{jr, otherJR} {
+		tx := cltest.NewTransaction(uint64(i))
+		tx.SurrogateID = null.StringFrom(run.ID.String())
+		tx.GasPrice = utils.NewBig(big.NewInt(20))
+		tx, err := store.CreateTx(tx)
+		require.NoError(t, err)
+		_, err = store.AddTxAttempt(tx, tx)
+		require.NoError(t, err)
+	}
+
+	spend, err := store.JobGasSpendSince(job.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(50000*20), spend)
+
+	spend, err = store.JobGasSpendSince(job.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, spend.Sign())
+}
+
 func TestJobs_All(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v3"
 )
 
 type requestType int
@@ -283,6 +284,25 @@ func (job JobSpec) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// SpecTemplate holds a job spec template together with the IDs of the jobs
+// created from an earlier version of it, which a rollout would update.
+type SpecTemplate struct {
+	models.SpecTemplate
+	OutdatedJobIDs []string `json:"outdatedJobIds"`
+}
+
+// NewSpecTemplate returns the template presenter, given the jobs created
+// from the template.
+func NewSpecTemplate(template models.SpecTemplate, instances []models.JobSpec) SpecTemplate {
+	outdated := []string{}
+	for _, job := range instances {
+		if job.SpecTemplateVersion < template.Version {
+			outdated = append(outdated, job.ID.String())
+		}
+	}
+	return SpecTemplate{SpecTemplate: template, OutdatedJobIDs: outdated}
+}
+
 // FriendlyCreatedAt returns a human-readable string of the Job's
 // CreatedAt field.
 func (job JobSpec) FriendlyCreatedAt() string {
@@ -334,6 +354,9 @@ func (job JobSpec) FriendlyTasks() string {
 	return strings.Join(tasks, "\n")
 }
 
+// CronNextRuns is how many of a cron initiator's next times due are shown.
+const CronNextRuns = 3
+
 // Initiator holds the Job definition's Initiator.
 type Initiator struct {
 	models.Initiator
@@ -361,8 +384,12 @@ func initiatorParams(i Initiator) (interface{}, error) {
 		return struct{}{}, nil
 	case models.InitiatorCron:
 		return struct {
-			Schedule models.Cron `json:"schedule"`
-		}{i.Schedule}, nil
+			Schedule models.Cron     `json:"schedule"`
+			Timezone string          `json:"timezone,omitempty"`
+			Jitter   models.Duration `json:"jitter,omitempty"`
+			CatchUp  string          `json:"catchUp,omitempty"`
+			NextRuns []time.Time     `json:"nextRuns,omitempty"`
+		}{i.Schedule, i.Timezone, i.Jitter, i.CatchUp, i.NextRuns(time.Now())}, nil
 	case models.InitiatorRunAt:
 		return struct {
 			Time models.AnyTime `json:"time"`
@@ -380,15 +407,54 @@ func initiatorParams(i Initiator) (interface{}, error) {
 		}{i.Name}, nil
 	case models.InitiatorFluxMonitor:
 		return struct {
-			Address         common.Address  `json:"address"`
-			RequestData     models.JSON     `json:"requestData"`
-			Feeds           models.JSON     `json:"feeds"`
-			Threshold       float32         `json:"threshold"`
-			Precision       int32           `json:"precision"`
-			PollingInterval models.Duration `json:"pollingInterval"`
-		}{i.Address, i.RequestData, i.Feeds, i.Threshold, i.Precision, i.PollingInterval}, nil
+			Address             common.Address            `json:"address"`
+			RequestData         models.JSON               `json:"requestData"`
+			Feeds               models.JSON               `json:"feeds"`
+			Threshold           float32                   `json:"threshold"`
+			Precision           int32                     `json:"precision"`
+			PollingInterval     models.Duration           `json:"pollingInterval"`
+			Aggregation         string                    `json:"aggregation,omitempty"`
+			TrimPercent         float32                   `json:"trimPercent,omitempty"`
+			MinFeeds            uint32                    `json:"minFeeds,omitempty"`
+			OutlierThreshold    float32                   `json:"outlierThreshold,omitempty"`
+			FeedTimeout         models.Duration           `json:"feedTimeout,omitempty"`
+			AbsoluteThreshold   float32                   `json:"absoluteThreshold,omitempty"`
+			ThresholdMode       string                    `json:"thresholdMode,omitempty"`
+			IdleThresholdBlocks uint32                    `json:"idleThresholdBlocks,omitempty"`
+			GasThresholds       models.GasPriceThresholds `json:"gasThresholds,omitempty"`
+			GasPriceCeiling     *utils.Big                `json:"gasPriceCeiling,omitempty"`
+			EmergencyThreshold  float32                   `json:"emergencyThreshold,omitempty"`
+			OracleAddress       *common.Address           `json:"oracleAddress,omitempty"`
+		}{
+			i.Address, i.RequestData, i.Feeds, i.Threshold, i.Precision, i.PollingInterval,
+			i.Aggregation, i.TrimPercent, i.MinFeeds, i.OutlierThreshold, i.FeedTimeout,
+			i.AbsoluteThreshold, i.ThresholdMode, i.IdleThresholdBlocks,
+			i.GasThresholds, i.GasPriceCeiling, i.EmergencyThreshold, i.OracleAddress,
+		}, nil
 	case models.InitiatorRandomnessLog:
 		return struct{ Address common.Address }{i.Address}, nil
+	case models.InitiatorJobRun:
+		return struct {
+			JobID    *models.ID                 `json:"jobId"`
+			Statuses models.RunStatusCollection `json:"statuses,omitempty"`
+		}{i.JobID, i.Statuses}, nil
+	case models.InitiatorBlockInterval:
+		return struct {
+			Every  uint32 `json:"every"`
+			Offset uint32 `json:"offset"`
+		}{i.Every, i.Offset}, nil
+	case models.InitiatorBalance:
+		return struct {
+			Addresses        models.AddressCollection `json:"addresses"`
+			Token            *common.Address          `json:"token,omitempty"`
+			BalanceThreshold *utils.Big               `json:"balanceThreshold"`
+			Hysteresis       *utils.Big               `json:"hysteresis,omitempty"`
+		}{i.Addresses, i.Token, i.BalanceThreshold, i.Hysteresis}, nil
+	case models.InitiatorMessageQueue:
+		return struct {
+			Queue           string `json:"queue"`
+			DeadLetterQueue string `json:"deadLetterQueue,omitempty"`
+		}{i.Queue, i.DeadLetterQueue}, nil
 	default:
 		return nil, fmt.Errorf("Cannot marshal unsupported initiator type '%v'", i.Type)
 	}
@@ -402,6 +468,29 @@ func (i Initiator) FriendlyRunAt() string {
 	return ""
 }
 
+// NextRuns returns the next times after now that a cron initiator is due,
+// before jitter, in its time zone.
+func (i Initiator) NextRuns(now time.Time) []time.Time {
+	if i.Type != models.InitiatorCron {
+		return nil
+	}
+	schedule, err := models.NewCronSchedule(i.Initiator)
+	if err != nil {
+		return nil
+	}
+	return schedule.NextTimes(now, CronNextRuns)
+}
+
+// FriendlyNextRuns returns the next times a cron initiator is due, or a
+// blank string if it is not a cron initiator.
+func (i Initiator) FriendlyNextRuns(now time.Time) string {
+	runs := []string{}
+	for _, t := range i.NextRuns(now) {
+		runs = append(runs, t.Format(time.RFC3339))
+	}
+	return strings.Join(runs, "\n")
+}
+
 // FriendlyAddress returns the Ethereum address if present, and a blank
 // string if not.
 func (i Initiator) FriendlyAddress() string {
@@ -678,6 +767,51 @@ func (ei *ExternalInitiatorAuthentication) SetID(name string) error {
 	return nil
 }
 
+// ExternalInitiator holds the details of an external initiator, without its
+// secrets, along with its health and how many notifications to it are
+// waiting to be sent.
+type ExternalInitiator struct {
+	Name                 string         `json:"name"`
+	URL                  *models.WebURL `json:"url,omitempty"`
+	AccessKey            string         `json:"incomingAccessKey"`
+	Status               string         `json:"status"`
+	CheckedAt            null.Time      `json:"checkedAt"`
+	CheckError           string         `json:"checkError,omitempty"`
+	PendingNotifications int            `json:"pendingNotifications"`
+	CreatedAt            time.Time      `json:"createdAt"`
+}
+
+// NewExternalInitiator creates an instance of ExternalInitiator.
+func NewExternalInitiator(ei models.ExternalInitiator, pendingNotifications int) ExternalInitiator {
+	return ExternalInitiator{
+		Name:                 ei.Name,
+		URL:                  ei.URL,
+		AccessKey:            ei.AccessKey,
+		Status:               ei.Status,
+		CheckedAt:            ei.CheckedAt,
+		CheckError:           ei.CheckError,
+		PendingNotifications: pendingNotifications,
+		CreatedAt:            ei.CreatedAt,
+	}
+}
+
+// GetID returns the jsonapi ID.
+func (ei ExternalInitiator) GetID() string {
+	return ei.Name
+}
+
+// GetName returns the collection name for jsonapi.
+func (ExternalInitiator) GetName() string {
+	return "external initiators"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (ei *ExternalInitiator) SetID(name string) error {
+	ei.Name = name
+	return nil
+}
+
 // ExplorerStatus represents the connected server and status of the connection
 type ExplorerStatus struct {
 	Status string `json:"status"`
@@ -44,6 +44,9 @@ const (
 var (
 	// ErrPendingConnection is the error returned if TxManager is not connected.
 	ErrPendingConnection = errors.New("Cannot talk to chain, pending connection")
+	// ErrUnknownAccount is the error returned if a transaction is to be sent
+	// from an account that is not one of the node's keys.
+	ErrUnknownAccount = errors.New("account does not exist")
 
 	promNumGasBumps = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "tx_manager_num_gas_bumps",
@@ -76,6 +79,7 @@ type TxManager interface {
 
 	CreateTx(to common.Address, data []byte) (*models.Tx, error)
 	CreateTxWithGas(surrogateID null.String, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error)
+	CreateTxFromWithGas(surrogateID null.String, from, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error)
 	CreateTxWithEth(from, to common.Address, value *assets.Eth) (*models.Tx, error)
 	CheckAttempt(txAttempt *models.TxAttempt, blockHeight uint64) (*eth.TxReceipt, AttemptState, error)
 
@@ -210,7 +214,22 @@ func (txm *EthTxManager) CreateTxWithGas(surrogateID null.String, to common.Addr
 		return nil, err
 	}
 
-	gasPriceWei, gasLimit = normalizeGasParams(gasPriceWei, gasLimit, txm.config)
+	gasPriceWei, gasLimit = NormalizeGasParams(gasPriceWei, gasLimit, txm.config)
+	return txm.createTx(surrogateID, ma, to, data, gasPriceWei, gasLimit, nil)
+}
+
+// CreateTxFromWithGas signs and sends a transaction to the Ethereum
+// blockchain from the given account, rather than the next one in turn.
+func (txm *EthTxManager) CreateTxFromWithGas(surrogateID null.String, from, to common.Address, data []byte, gasPriceWei *big.Int, gasLimit uint64) (*models.Tx, error) {
+	if !txm.Connected() {
+		return nil, errors.Wrap(ErrPendingConnection, "EthTxManager#CreateTxFromWithGas")
+	}
+	ma := txm.getAccount(from)
+	if ma == nil {
+		return nil, errors.Wrap(ErrUnknownAccount, from.Hex())
+	}
+
+	gasPriceWei, gasLimit = NormalizeGasParams(gasPriceWei, gasLimit, txm.config)
 	return txm.createTx(surrogateID, ma, to, data, gasPriceWei, gasLimit, nil)
 }
 
@@ -237,7 +256,9 @@ func (txm *EthTxManager) nextAccount() (*ManagedAccount, error) {
 	return ma, nil
 }
 
-func normalizeGasParams(gasPriceWei *big.Int, gasLimit uint64, config orm.ConfigReader) (*big.Int, uint64) {
+// NormalizeGasParams returns the gas price and limit that will actually be
+// used for a transaction requested with the given values.
+func NormalizeGasParams(gasPriceWei *big.Int, gasLimit uint64, config orm.ConfigReader) (*big.Int, uint64) {
 	if !config.Dev() {
 		return config.EthGasPriceDefault(), DefaultGasLimit
 	}
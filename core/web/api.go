@@ -98,6 +98,29 @@ func NewPaginatedResponse(url url.URL, size, page, count int, resource interface
 	return json.Marshal(document)
 }
 
+// NewCursorPaginatedResponse returns a jsonapi.Document with a link to the
+// page starting after the next cursor, if there is one
+func NewCursorPaginatedResponse(url url.URL, size, count int, next string, resource interface{}) ([]byte, error) {
+	document, err := jsonapi.MarshalToStruct(resource, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource to struct: %+v", err)
+	}
+
+	document.Meta = make(jsonapi.Meta)
+	document.Meta["count"] = count
+
+	document.Links = make(jsonapi.Links)
+	if next != "" {
+		query := url.Query()
+		query.Set("size", strconv.Itoa(size))
+		query.Set("cursor", next)
+		query.Del("page")
+		url.RawQuery = query.Encode()
+		document.Links[KeyNextLink] = jsonapi.Link{Href: url.String()}
+	}
+	return json.Marshal(document)
+}
+
 // ParsePaginatedResponse parse a JSONAPI response for a document with links
 func ParsePaginatedResponse(input []byte, resource interface{}, links *jsonapi.Links) error {
 	err := ParseJSONAPIResponse(input, resource)
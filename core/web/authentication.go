@@ -18,10 +18,10 @@ const (
 	APISecret = "X-API-SECRET"
 	// ExternalInitiatorAccessKeyHeader is the header name for the access key
 	// used by external initiators to authenticate
-	ExternalInitiatorAccessKeyHeader = "X-Chainlink-EA-AccessKey"
+	ExternalInitiatorAccessKeyHeader = models.ExternalInitiatorAccessKeyHeader
 	// ExternalInitiatorSecretHeader is the header name for the secret used by
 	// external initiators to authenticate
-	ExternalInitiatorSecretHeader = "X-Chainlink-EA-Secret"
+	ExternalInitiatorSecretHeader = models.ExternalInitiatorSecretHeader
 )
 
 type AuthStorer interface {
@@ -19,6 +19,25 @@ type ExternalInitiatorsController struct {
 	App chainlink.Application
 }
 
+// Index lists external initiators, with their health and how many
+// notifications to them are waiting to be sent.
+// Example:
+//  "<application>/external_initiators"
+func (eic *ExternalInitiatorsController) Index(c *gin.Context, size, page, offset int) {
+	store := eic.App.GetStore()
+	exis, count, err := store.ExternalInitiatorsPaginated(offset, size)
+	peis := make([]presenters.ExternalInitiator, len(exis))
+	for i, ei := range exis {
+		pending, err := store.CountPendingExternalInitiatorNotifications(ei.Name)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		peis[i] = presenters.NewExternalInitiator(ei, pending)
+	}
+	paginatedResponse(c, "ExternalInitiators", size, page, peis, count, err)
+}
+
 // Create builds and saves a new service agreement record.
 func (eic *ExternalInitiatorsController) Create(c *gin.Context) {
 	eir := &models.ExternalInitiatorRequest{}
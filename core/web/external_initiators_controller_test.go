@@ -4,15 +4,68 @@ import (
 	"bytes"
 	"net/http"
 	"testing"
+	"time"
 
+	"chainlink/core/auth"
 	"chainlink/core/internal/cltest"
 	"chainlink/core/store/models"
 	"chainlink/core/store/presenters"
+	"chainlink/core/web"
 
+	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestExternalInitiatorsController_Index(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	store := app.GetStore()
+
+	url := cltest.WebURL(t, "http://ei.example.com/jobs")
+	healthy, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{Name: "bitcoin", URL: &url})
+	require.NoError(t, err)
+	require.NoError(t, store.CreateExternalInitiator(healthy))
+	require.NoError(t, store.UpdateExternalInitiatorHealth(healthy.Name, models.ExternalInitiatorStatusHealthy, "", time.Now()))
+	job := cltest.NewJobWithExternalInitiator(healthy)
+	require.NoError(t, store.CreateJob(&job))
+	require.NoError(t, store.CreateExternalInitiatorNotification(&models.ExternalInitiatorNotification{
+		ExternalInitiatorName: healthy.Name,
+		JobSpecID:             job.ID,
+		Action:                models.ExternalInitiatorNotificationCreate,
+		Body:                  cltest.JSONFromString(t, `{}`),
+		NextAttemptAt:         time.Now().Add(time.Hour),
+	}))
+
+	withoutURL, err := models.NewExternalInitiator(auth.NewToken(), &models.ExternalInitiatorRequest{Name: "ethereum"})
+	require.NoError(t, err)
+	require.NoError(t, store.CreateExternalInitiator(withoutURL))
+
+	client := app.NewHTTPClient()
+	resp, cleanup := client.Get("/v2/external_initiators")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var links jsonapi.Links
+	var eis []presenters.ExternalInitiator
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &eis, &links))
+	require.Len(t, eis, 2)
+
+	assert.Equal(t, "bitcoin", eis[0].Name)
+	assert.Equal(t, "http://ei.example.com/jobs", eis[0].URL.String())
+	assert.Equal(t, models.ExternalInitiatorStatusHealthy, eis[0].Status)
+	assert.True(t, eis[0].CheckedAt.Valid)
+	assert.Equal(t, 1, eis[0].PendingNotifications)
+
+	assert.Equal(t, "ethereum", eis[1].Name)
+	assert.Nil(t, eis[1].URL)
+	assert.Equal(t, models.ExternalInitiatorStatusUnknown, eis[1].Status)
+	assert.Equal(t, 0, eis[1].PendingNotifications)
+}
+
 func TestExternalInitiatorsController_Create_success(t *testing.T) {
 	t.Parallel()
 
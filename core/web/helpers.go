@@ -56,6 +56,53 @@ func paginatedResponse(
 	}
 }
 
+// cursorPaginatedResponse responds with a page of the resource that was
+// selected by cursor, linking to the page after it.
+func cursorPaginatedResponse(
+	c *gin.Context,
+	name string,
+	size int,
+	resource interface{},
+	count int,
+	next *orm.Cursor,
+	err error,
+) {
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("error getting paged %s: %+v", name, err))
+		return
+	}
+	var cursor string
+	if next != nil {
+		cursor = next.String()
+	}
+	if buffer, err := NewCursorPaginatedResponse(*c.Request.URL, size, count, cursor, resource); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("failed to marshal document: %+v", err))
+	} else {
+		c.Data(http.StatusOK, MediaType, buffer)
+	}
+}
+
+// pageFromRequest returns the page of records requested, which is selected
+// by cursor if the request has a cursor param, even an empty one for the
+// first page, and by offset otherwise.
+func pageFromRequest(c *gin.Context, size, offset int) (orm.Page, bool, error) {
+	page := orm.Page{Offset: offset, Limit: size}
+	cursor, ok := c.GetQuery("cursor")
+	if !ok {
+		return page, false, nil
+	}
+	page.Offset = 0
+	if cursor == "" {
+		return page, true, nil
+	}
+	parsed, err := orm.ParseCursor(cursor)
+	if err != nil {
+		return page, true, err
+	}
+	page.Cursor = parsed
+	return page, true, nil
+}
+
 func paginatedRequest(action func(*gin.Context, int, int, int)) func(*gin.Context) {
 	return func(c *gin.Context) {
 		size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
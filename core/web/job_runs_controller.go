@@ -4,50 +4,114 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
 
+	"chainlink/core/logger"
+	"chainlink/core/services"
 	"chainlink/core/services/chainlink"
 	"chainlink/core/store/models"
 	"chainlink/core/store/orm"
 	"chainlink/core/store/presenters"
 	"chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 )
 
+const (
+	// Time allowed to write a run update to the stream.
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the stream.
+	streamPongWait = 60 * time.Second
+
+	// Send pings to the stream with this period. Must be less than streamPongWait.
+	streamPingPeriod = (streamPongWait * 9) / 10
+)
+
+var streamUpgrader = websocket.Upgrader{}
+
 // JobRunsController manages JobRun requests in the node.
 type JobRunsController struct {
 	App chainlink.Application
 }
 
-// Index returns paginated JobRuns for a given JobSpec
+// Index returns paginated JobRuns. Runs can be filtered by status, job,
+// initiator type, creation date, requester and transaction hash, and paged
+// through by cursor by passing a cursor param, empty for the first page.
 // Example:
 //  "<application>/runs?jobSpecId=:jobSpecId&size=1&page=2"
+//  "<application>/runs?status=errored&createdAfter=2020-04-01T00:00:00Z&cursor="
 func (jrc *JobRunsController) Index(c *gin.Context, size, page, offset int) {
-	id := c.Query("jobSpecId")
-
 	order := orm.Ascending
 	if c.Query("sort") == "-createdAt" {
 		order = orm.Descending
 	}
 
-	store := jrc.App.GetStore()
-	var runs []models.JobRun
-	var count int
-	var err error
-	if id == "" {
-		runs, count, err = store.JobRunsSorted(order, offset, size)
+	filter, err := jobRunFilterFromRequest(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	p, byCursor, err := pageFromRequest(c, size, offset)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	runs, count, next, err := jrc.App.GetStore().JobRunsFiltered(filter, order, p)
+	if byCursor {
+		cursorPaginatedResponse(c, "JobRuns", size, runs, count, next, err)
 	} else {
-		runID, err := models.NewIDFromString(id)
+		paginatedResponse(c, "JobRuns", size, page, runs, count, err)
+	}
+}
+
+func jobRunFilterFromRequest(c *gin.Context) (orm.JobRunFilter, error) {
+	filter := orm.JobRunFilter{InitiatorType: c.Query("initiatorType")}
+	for _, param := range c.QueryArray("status") {
+		for _, status := range strings.Split(param, ",") {
+			filter.Statuses = append(filter.Statuses, models.RunStatus(status))
+		}
+	}
+	if id := c.Query("jobSpecId"); id != "" {
+		jobSpecID, err := models.NewIDFromString(id)
 		if err != nil {
-			jsonAPIError(c, http.StatusUnprocessableEntity, err)
-			return
+			return filter, err
 		}
-
-		runs, count, err = store.JobRunsSortedFor(runID, order, offset, size)
+		filter.JobSpecID = jobSpecID
 	}
-
-	paginatedResponse(c, "JobRuns", size, page, runs, count, err)
+	var err error
+	if after := c.Query("createdAfter"); after != "" {
+		if filter.CreatedAfter, err = time.Parse(time.RFC3339, after); err != nil {
+			return filter, errors.Wrap(err, "createdAfter must be an RFC3339 time")
+		}
+	}
+	if before := c.Query("createdBefore"); before != "" {
+		if filter.CreatedBefore, err = time.Parse(time.RFC3339, before); err != nil {
+			return filter, errors.Wrap(err, "createdBefore must be an RFC3339 time")
+		}
+	}
+	if requester := c.Query("requester"); requester != "" {
+		if !common.IsHexAddress(requester) {
+			return filter, errors.New("requester must be a hex encoded address")
+		}
+		address := common.HexToAddress(requester)
+		filter.Requester = &address
+	}
+	if txHash := c.Query("txHash"); txHash != "" {
+		b, err := hexutil.Decode(txHash)
+		if err != nil || len(b) != common.HashLength {
+			return filter, errors.New("txHash must be a hex encoded transaction hash")
+		}
+		hash := common.BytesToHash(b)
+		filter.TxHash = &hash
+	}
+	return filter, nil
 }
 
 // Create starts a new Run for the requested JobSpec.
@@ -76,6 +140,16 @@ func (jrc *JobRunsController) Create(c *gin.Context) {
 		return
 	}
 
+	if j.Paused() {
+		jsonAPIError(c, http.StatusConflict, errors.New("Job is paused"))
+		return
+	}
+
+	if j.Proposed() {
+		jsonAPIError(c, http.StatusConflict, errors.New("Job is awaiting approval"))
+		return
+	}
+
 	data, err := getRunData(c)
 	if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
@@ -145,6 +219,74 @@ func (jrc *JobRunsController) Show(c *gin.Context) {
 	jsonAPIResponse(c, presenters.JobRun{JobRun: jr}, "job run")
 }
 
+// Replay re-executes a JobRun without side effects, comparing each task's
+// result with the original. The spec query parameter selects whether the
+// run's original tasks or the job's current tasks are replayed.
+// Example:
+//  "<application>/runs/:RunID/replay?spec=current"
+func (jrc *JobRunsController) Replay(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("RunID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jrc.App.GetStore()
+	jr, err := store.FindJobRun(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	spec := services.ReplaySpec(c.DefaultQuery("spec", string(services.ReplaySpecOriginal)))
+	if spec != services.ReplaySpecOriginal && spec != services.ReplaySpecCurrent {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("spec must be %q or %q", services.ReplaySpecOriginal, services.ReplaySpecCurrent))
+		return
+	}
+
+	replay, err := services.ReplayRun(jr, spec, store)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job not found"))
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+	} else {
+		jsonAPIResponse(c, replay, "run replay")
+	}
+}
+
+// HTTPAudit returns the raw HTTP requests and responses recorded for the
+// tasks of a JobRun, as a file download.
+// Example:
+//  "<application>/runs/:RunID/http_audit"
+func (jrc *JobRunsController) HTTPAudit(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("RunID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jrc.App.GetStore()
+	if _, err = store.FindJobRun(id); errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	records, err := store.HTTPAuditRecordsFor(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="run-%s-http-audit.json"`, id))
+	jsonAPIResponse(c, records, "http audit records")
+}
+
 // Update allows external adapters to resume a JobRun, reporting the result of
 // the task and marking it no longer pending.
 // Example:
@@ -229,3 +371,108 @@ func (jrc *JobRunsController) Cancel(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.JobRun{JobRun: *jr}, "job run")
 }
+
+// Stream upgrades the request to a websocket and pushes every matching run,
+// with its task results, each time it is saved. Runs can be filtered by job
+// and by run. Streaming a single run starts with the run as it is, and ends
+// once it has finished.
+// Example:
+//  "<application>/run_updates?jobSpecId=:jobSpecId&runId=:runId"
+func (jrc *JobRunsController) Stream(c *gin.Context) {
+	var jobSpecID, runID *models.ID
+	var err error
+	if id := c.Query("jobSpecId"); id != "" {
+		if jobSpecID, err = models.NewIDFromString(id); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	if id := c.Query("runId"); id != "" {
+		if runID, err = models.NewIDFromString(id); err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+
+	// Subscribe before reading the run, so that no update after it is missed
+	sub := jrc.App.GetRunBroadcaster().Subscribe(jobSpecID, runID)
+	defer sub.Unsubscribe()
+
+	var snapshot *models.JobRun
+	if runID != nil {
+		run, err := jrc.App.GetStore().FindJobRun(runID)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			jsonAPIError(c, http.StatusNotFound, errors.New("Job run not found"))
+			return
+		} else if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		snapshot = &run
+	}
+
+	conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		logger.Warnw("Unable to open run update stream", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	closeStream := func() {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(streamWriteWait))
+	}
+	if snapshot != nil {
+		_ = conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+		if err := conn.WriteJSON(presenters.JobRun{JobRun: *snapshot}); err != nil {
+			return
+		}
+		if snapshot.Status.Finished() {
+			closeStream()
+			return
+		}
+	}
+
+	// The client sends nothing but pongs and close frames, which are only
+	// processed while reading.
+	closed := make(chan struct{})
+	_ = conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case run, ok := <-sub.Updates():
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(streamWriteWait))
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteJSON(presenters.JobRun{JobRun: run}); err != nil {
+				return
+			}
+			if runID != nil && run.Status.Finished() {
+				closeStream()
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
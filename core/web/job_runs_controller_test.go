@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"chainlink/core/store/presenters"
 	"chainlink/core/web"
 
+	"github.com/gorilla/websocket"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,6 +98,44 @@ func TestJobRunsController_Index(t *testing.T) {
 	assert.Equal(t, runA.ID, allJobRuns[2].ID, "expected runs ordered by created at descending")
 }
 
+func TestJobRunsController_Index_Filters(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	app.Start()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	runA, _, runC := setupJobRunsControllerIndex(t, app)
+	runC.Status = models.RunStatusCompleted
+	require.NoError(t, app.Store.SaveJobRun(runC))
+
+	resp, cleanup := client.Get("/v2/runs?status=completed")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var runs []models.JobRun
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &runs))
+	require.Len(t, runs, 1)
+	assert.Equal(t, runC.ID, runs[0].ID)
+
+	after := runA.CreatedAt.Add(time.Millisecond).UTC().Format(time.RFC3339Nano)
+	resp, cleanup = client.Get("/v2/runs?size=1&cursor=&createdAfter=" + url.QueryEscape(after))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var links jsonapi.Links
+	runs = []models.JobRun{}
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &runs, &links))
+	require.Len(t, runs, 1)
+	assert.NotEqual(t, runA.ID, runs[0].ID)
+	assert.NotEmpty(t, links["next"].Href)
+
+	for _, query := range []string{"createdAfter=yesterday", "requester=0x1", "txHash=0x12"} {
+		resp, cleanup = client.Get("/v2/runs?" + query)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+	}
+}
+
 func setupJobRunsControllerIndex(t assert.TestingT, app *cltest.TestApplication) (*models.JobRun, *models.JobRun, *models.JobRun) {
 	j1 := cltest.NewJobWithWebInitiator()
 	assert.Nil(t, app.Store.CreateJob(&j1))
@@ -555,3 +596,181 @@ func TestJobRunsController_Cancel(t *testing.T) {
 		assert.Equal(t, models.RunStatusCancelled, run.Status)
 	})
 }
+
+func TestJobRunsController_Stream(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+
+	streamURL := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/run_updates?jobSpecId=" + j.ID.String()
+	header := http.Header{}
+	header.Set("Cookie", cltest.MustGenerateSessionCookie(cltest.APISessionID).String())
+	conn, _, err := websocket.DefaultDialer.Dial(streamURL, header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Keep creating runs until one is streamed
+	runIDs := make(map[string]bool)
+	received := make(chan presenters.JobRun)
+	go func() {
+		var run presenters.JobRun
+		if conn.ReadJSON(&run) == nil {
+			received <- run
+		}
+		close(received)
+	}()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case run, ok := <-received:
+			require.True(t, ok)
+			assert.True(t, runIDs[run.ID.String()])
+			assert.Equal(t, j.ID, run.JobSpecID)
+			assert.Equal(t, models.InitiatorWeb, run.Initiator.Type)
+			return
+		case <-time.After(100 * time.Millisecond):
+			jr := cltest.NewJobRun(j)
+			require.NoError(t, app.Store.CreateJobRun(&jr))
+			runIDs[jr.ID.String()] = true
+		case <-timeout:
+			t.Fatal("timed out waiting for run update")
+		}
+	}
+}
+
+func TestJobRunsController_Stream_FinishedRun(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.CreateJobRunWithStatus(t, app.Store, j, models.RunStatusCompleted)
+
+	streamURL := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/run_updates?runId=" + jr.ID.String()
+	header := http.Header{}
+	header.Set("Cookie", cltest.MustGenerateSessionCookie(cltest.APISessionID).String())
+	conn, _, err := websocket.DefaultDialer.Dial(streamURL, header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	var run presenters.JobRun
+	require.NoError(t, conn.ReadJSON(&run))
+	assert.Equal(t, jr.ID, run.ID)
+	assert.Equal(t, models.RunStatusCompleted, run.Status)
+
+	err = conn.ReadJSON(&run)
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "expected the stream to be closed, got %v", err)
+}
+
+func TestJobRunsController_Stream_NotFound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	streamURL := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/run_updates?runId=" + models.NewID().String()
+	header := http.Header{}
+	header.Set("Cookie", cltest.MustGenerateSessionCookie(cltest.APISessionID).String())
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL, header)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobRunsController_Stream_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	streamURL := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/run_updates"
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJobRunsController_HTTPAudit(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRun(j)
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	record := models.HTTPAuditRecord{
+		TaskRunID:    jr.TaskRuns[0].ID,
+		CreatedAt:    time.Now(),
+		Method:       "GET",
+		URL:          "https://example.com/price?apiKey=REDACTED",
+		StatusCode:   http.StatusOK,
+		ResponseBody: `{"price":100}`,
+	}
+	require.NoError(t, app.Store.CreateHTTPAuditRecord(&record))
+
+	resp, cleanup := client.Get("/v2/runs/" + jr.ID.String() + "/http_audit")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	var records []models.HTTPAuditRecord
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, jr.TaskRuns[0].ID, records[0].TaskRunID)
+	assert.Equal(t, record.URL, records[0].URL)
+	assert.Equal(t, record.ResponseBody, records[0].ResponseBody)
+
+	resp, cleanup = client.Get("/v2/runs/" + models.NewID().String() + "/http_audit")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestJobRunsController_Replay(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRun(j)
+	jr.TaskRuns[0].ApplyOutput(models.NewRunOutputCompleteWithResult("100"))
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	resp, cleanup := client.Post("/v2/runs/"+jr.ID.String()+"/replay", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var replay models.RunReplay
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &replay))
+	assert.Equal(t, jr.ID, replay.JobRunID)
+	assert.Equal(t, "original", replay.Spec)
+	require.Len(t, replay.TaskRuns, 1)
+	assert.False(t, replay.TaskRuns[0].Changed)
+
+	resp, cleanup = client.Post("/v2/runs/"+jr.ID.String()+"/replay?spec=historical", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Post("/v2/runs/"+models.NewID().String()+"/replay", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -1,7 +1,11 @@
 package web
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"time"
 
 	"chainlink/core/services"
 	"chainlink/core/services/chainlink"
@@ -18,9 +22,12 @@ type JobSpecsController struct {
 	App chainlink.Application
 }
 
-// Index lists JobSpecs, one page at a time.
+// Index lists JobSpecs, one page at a time. Jobs can be filtered by label,
+// initiator type, task type, bridge and whether they are archived, and paged
+// through by cursor by passing a cursor param, empty for the first page.
 // Example:
 //  "<application>/specs?size=1&page=2"
+//  "<application>/specs?label=eth-usd&initiatorType=runlog&cursor="
 func (jsc *JobSpecsController) Index(c *gin.Context, size, page, offset int) {
 	var order orm.SortType
 	if c.Query("sort") == "-createdAt" {
@@ -29,13 +36,48 @@ func (jsc *JobSpecsController) Index(c *gin.Context, size, page, offset int) {
 		order = orm.Ascending
 	}
 
-	jobs, count, err := jsc.App.GetStore().JobsSorted(order, offset, size)
+	filter, err := jobSpecFilterFromRequest(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	p, byCursor, err := pageFromRequest(c, size, offset)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jobs, count, next, err := jsc.App.GetStore().JobsFiltered(filter, order, p)
 	pjs := make([]presenters.JobSpec, len(jobs))
 	for i, j := range jobs {
 		pjs[i] = presenters.JobSpec{JobSpec: j}
 	}
 
-	paginatedResponse(c, "Jobs", size, page, pjs, count, err)
+	if byCursor {
+		cursorPaginatedResponse(c, "Jobs", size, pjs, count, next, err)
+	} else {
+		paginatedResponse(c, "Jobs", size, page, pjs, count, err)
+	}
+}
+
+func jobSpecFilterFromRequest(c *gin.Context) (orm.JobSpecFilter, error) {
+	filter := orm.JobSpecFilter{
+		Labels:        c.QueryArray("label"),
+		InitiatorType: c.Query("initiatorType"),
+		TaskType:      c.Query("taskType"),
+		Bridge:        c.Query("bridge"),
+	}
+	switch c.Query("archived") {
+	case "", "false":
+		filter.Archived = orm.ExcludeArchived
+	case "true":
+		filter.Archived = orm.OnlyArchived
+	case "all":
+		filter.Archived = orm.IncludeArchived
+	default:
+		return filter, errors.New("archived must be one of true, false or all")
+	}
+	return filter, nil
 }
 
 // requireImplented verifies if a Job Spec's feature is enabled according to
@@ -55,8 +97,8 @@ func (jsc *JobSpecsController) requireImplemented(js models.JobSpec) error {
 // reflects the type of failure to be reported back to the client.
 func (jsc *JobSpecsController) getAndCheckJobSpec(
 	c *gin.Context) (js models.JobSpec, httpStatus int, err error) {
-	var jsr models.JobSpecRequest
-	if err := c.ShouldBindJSON(&jsr); err != nil {
+	jsr, err := bindJobSpecRequest(c)
+	if err != nil {
 		// TODO(alx): Better parsing and more specific error messages
 		// https://www.pivotaltracker.com/story/show/171164115
 		return models.JobSpec{}, http.StatusBadRequest, err
@@ -71,6 +113,23 @@ func (jsc *JobSpecsController) getAndCheckJobSpec(
 	return js, 0, nil
 }
 
+// bindJobSpecRequest parses the request body as a JobSpecRequest written in
+// the format given by its Content-Type, which is JSON unless it names TOML or
+// YAML.
+func bindJobSpecRequest(c *gin.Context) (models.JobSpecRequest, error) {
+	format := models.JobSpecFormatFromContentType(c.ContentType())
+	if format == models.JobSpecFormatJSON {
+		var jsr models.JobSpecRequest
+		err := c.ShouldBindJSON(&jsr)
+		return jsr, err
+	}
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		return models.JobSpecRequest{}, err
+	}
+	return models.ParseJobSpecRequest(body, format)
+}
+
 // Create adds validates, saves, and starts a new JobSpec.
 // Example:
 //  "<application>/specs"
@@ -80,16 +139,53 @@ func (jsc *JobSpecsController) Create(c *gin.Context) {
 		jsonAPIError(c, httpStatus, err)
 		return
 	}
-	if err := NotifyExternalInitiator(js, jsc.App.GetStore()); err != nil {
+	js.ProposedBy = proposer(c)
+	if err := jsc.App.AddJob(js); err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
-	if err := jsc.App.AddJob(js); err != nil {
+	// AddJob may have saved the job as proposed
+	j, err := jsc.App.GetStore().FindJob(js.ID)
+	if err != nil {
 		jsonAPIError(c, http.StatusInternalServerError, err)
 		return
 	}
 	// TODO: https://www.pivotaltracker.com/story/show/171169052
-	jsonAPIResponse(c, presenters.JobSpec{JobSpec: js}, "job")
+	jsonAPIResponse(c, presenters.JobSpec{JobSpec: j}, "job")
+}
+
+// proposer returns the email of the user making the request, who proposes the
+// jobs it creates or updates if they must be approved.
+func proposer(c *gin.Context) string {
+	if user, ok := authenticatedUser(c); ok {
+		return user.Email
+	}
+	return ""
+}
+
+// Simulate runs a job spec's tasks against sample request data without
+// saving the job or its run. Tasks that would send ethereum transactions
+// report the transaction instead.
+// Example:
+//  "<application>/spec_simulations"
+func (jsc *JobSpecsController) Simulate(c *gin.Context) {
+	var rsr models.RunSimulationRequest
+	if err := c.ShouldBindJSON(&rsr); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	js := models.NewJobFromRequest(rsr.Spec)
+	if err := services.ValidateJob(js, jsc.App.GetStore()); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	simulation, err := services.SimulateRun(js, rsr.Input, jsc.App.GetStore())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, simulation, "simulation")
 }
 
 // Show returns the details of a JobSpec.
@@ -115,6 +211,355 @@ func (jsc *JobSpecsController) Show(c *gin.Context) {
 	jsonAPIResponse(c, jobPresenter(jsc, j), "job")
 }
 
+// Update validates and saves a JobSpec as the next version of the job with
+// the given ID, keeping its ID and swapping its initiators for the new ones.
+// Example:
+//  "<application>/specs/:SpecID"
+func (jsc *JobSpecsController) Update(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	js, httpStatus, err := jsc.getAndCheckJobSpec(c)
+	if err != nil {
+		jsonAPIError(c, httpStatus, err)
+		return
+	}
+	js.ID = id
+	for i := range js.Initiators {
+		js.Initiators[i].JobSpecID = id
+	}
+	for i := range js.Tasks {
+		js.Tasks[i].JobSpecID = id
+	}
+
+	store := jsc.App.GetStore()
+	if _, err = store.FindJob(id); errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	js.ProposedBy = proposer(c)
+	err = jsc.App.UpdateJob(js)
+	if errors.Cause(err) == orm.OptimisticUpdateConflictError {
+		jsonAPIError(c, http.StatusConflict, errors.New("JobSpec was updated concurrently, please retry"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	j, err := store.FindJob(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobPresenter(jsc, j), "job")
+}
+
+// Versions lists the snapshots of each version of a JobSpec, oldest first.
+// Example:
+//  "<application>/specs/:SpecID/versions"
+func (jsc *JobSpecsController) Versions(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	versions, err := jsc.App.GetStore().JobSpecVersions(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, versions, "job_spec_versions")
+}
+
+// Diff lists the changes made to a JobSpec between two of its versions. The
+// "to" version defaults to the current one, and "from" to the one before it.
+// Example:
+//  "<application>/specs/:SpecID/diff?from=1&to=3"
+func (jsc *JobSpecsController) Diff(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	j, err := store.Unscoped().FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	to, err := versionParam(c, "to", j.Version)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	from, err := versionParam(c, "from", to-1)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var versions [2]models.JobSpecVersion
+	for i, version := range []uint32{from, to} {
+		versions[i], err = store.FindJobSpecVersion(id, version)
+		if errors.Cause(err) == orm.ErrorNotFound {
+			jsonAPIError(c, http.StatusNotFound, fmt.Errorf("JobSpec version %d not found", version))
+			return
+		}
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	diff, err := models.NewJobSpecDiff(versions[0], versions[1])
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, diff, "job_spec_diff")
+}
+
+func versionParam(c *gin.Context, name string, defaultVersion uint32) (uint32, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return defaultVersion, nil
+	}
+	version, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s version: %s", name, value)
+	}
+	return uint32(version), nil
+}
+
+// Consumption returns how much of its run rate limit and daily gas budget a
+// job spec has used.
+// Example:
+//  "<application>/specs/:SpecID/consumption"
+func (jsc *JobSpecsController) Consumption(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	j, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	consumption, err := services.JobConsumption(&j, store.ORM, time.Now())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, consumption, "consumption")
+}
+
+// FluxMonitor returns what each of the job's flux monitor initiators last
+// did, and why it did or did not submit.
+// Example:
+//  "<application>/specs/:SpecID/flux_monitor"
+func (jsc *JobSpecsController) FluxMonitor(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	j, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	saved, err := store.FluxMonitorStatesFor(j.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	byInitiator := map[uint]models.FluxMonitorState{}
+	for _, state := range saved {
+		byInitiator[state.InitiatorID] = state
+	}
+
+	states := []models.FluxMonitorState{}
+	for _, initr := range j.InitiatorsFor(models.InitiatorFluxMonitor) {
+		state, ok := byInitiator[initr.ID]
+		if !ok {
+			state = models.FluxMonitorState{InitiatorID: initr.ID, JobSpecID: j.ID}
+		}
+		states = append(states, state)
+	}
+
+	jsonAPIResponse(c, states, "flux_monitor_states")
+}
+
+// Pause stops a job spec from starting runs until it is resumed.
+// Example:
+//  "<application>/specs/:SpecID/pause"
+func (jsc *JobSpecsController) Pause(c *gin.Context) {
+	jsc.setPaused(c, jsc.App.PauseJob)
+}
+
+// Resume restarts a paused job spec, starting the runs queued while it was
+// paused.
+// Example:
+//  "<application>/specs/:SpecID/resume"
+func (jsc *JobSpecsController) Resume(c *gin.Context) {
+	jsc.setPaused(c, jsc.App.ResumeJob)
+}
+
+func (jsc *JobSpecsController) setPaused(c *gin.Context, action func(*models.ID) error) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = action(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if errors.Cause(err) == orm.ErrorJobNotPaused {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	j, err := jsc.App.GetStore().FindJob(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobPresenter(jsc, j), "job")
+}
+
+// Approval shows an approver what must be approved of a job spec: why it
+// needs approval, the changes made since its last approved version, and the
+// audit trail of its approvals.
+// Example:
+//  "<application>/specs/:SpecID/approval"
+func (jsc *JobSpecsController) Approval(c *gin.Context) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	store := jsc.App.GetStore()
+	j, err := store.FindJob(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	approval, err := services.NewJobApproval(j, store)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, approval, "job_approval")
+}
+
+// Approve approves the proposed version of a job spec on behalf of the
+// approver whose credentials are given, starting its initiators.
+// Example:
+//  "<application>/specs/:SpecID/approve"
+func (jsc *JobSpecsController) Approve(c *gin.Context) {
+	jsc.decideApproval(c, jsc.App.ApproveJob)
+}
+
+// Reject rejects the proposed version of a job spec on behalf of the approver
+// whose credentials are given.
+// Example:
+//  "<application>/specs/:SpecID/reject"
+func (jsc *JobSpecsController) Reject(c *gin.Context) {
+	jsc.decideApproval(c, jsc.App.RejectJob)
+}
+
+func (jsc *JobSpecsController) decideApproval(
+	c *gin.Context, decide func(*models.ID, models.JobApprovalRequest) error) {
+	id, err := models.NewIDFromString(c.Param("SpecID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var request models.JobApprovalRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = decide(id, request)
+	switch errors.Cause(err) {
+	case nil:
+	case orm.ErrorNotFound:
+		jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		return
+	case services.ErrJobApprovalDenied:
+		jsonAPIError(c, http.StatusForbidden, err)
+		return
+	case orm.OptimisticUpdateConflictError:
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("JobSpec is no longer at version %d", request.Version))
+		return
+	case orm.ErrorJobNotProposed:
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	default:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	j, err := jsc.App.GetStore().FindJob(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobPresenter(jsc, j), "job")
+}
+
 // Destroy soft deletes a job spec.
 // Example:
 //  "<application>/specs/:SpecID"
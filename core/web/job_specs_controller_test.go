@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"chainlink/core/adapters"
+	"chainlink/core/assets"
 	"chainlink/core/auth"
 	"chainlink/core/internal/cltest"
 	"chainlink/core/store/models"
@@ -17,6 +18,7 @@ import (
 	"chainlink/core/web"
 
 	"github.com/manyminds/api2go/jsonapi"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -147,6 +149,84 @@ func TestJobSpecsController_Index_sortCreatedAt(t *testing.T) {
 	assert.Equal(t, jobs[1].ID, descJobs[1].ID)
 }
 
+func TestJobSpecsController_Index_Filters(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	labelled := cltest.NewJobWithWebInitiator()
+	labelled.Labels = []string{"eth-usd"}
+	require.NoError(t, app.Store.CreateJob(&labelled))
+	cron := cltest.NewJobWithSchedule("9 9 9 9 6")
+	require.NoError(t, app.Store.CreateJob(&cron))
+
+	resp, cleanup := client.Get("/v2/specs?label=eth-usd")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	jobs := []models.JobSpec{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, labelled.ID, jobs[0].ID)
+	assert.Equal(t, []string{"eth-usd"}, []string(jobs[0].Labels))
+
+	resp, cleanup = client.Get("/v2/specs?initiatorType=cron")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	jobs = []models.JobSpec{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &jobs))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, cron.ID, jobs[0].ID)
+
+	resp, cleanup = client.Get("/v2/specs?archived=maybe")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestJobSpecsController_Index_Cursor(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+
+	j1, err := setupJobSpecsControllerIndex(app)
+	require.NoError(t, err)
+
+	resp, cleanup := client.Get("/v2/specs?size=1&cursor=")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	body := cltest.ParseResponseBody(t, resp)
+
+	metaCount, err := cltest.ParseJSONAPIResponseMetaCount(body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metaCount)
+
+	var links jsonapi.Links
+	jobs := []models.JobSpec{}
+	require.NoError(t, web.ParsePaginatedResponse(body, &jobs, &links))
+	require.Len(t, jobs, 1)
+	assert.Equal(t, j1.ID, jobs[0].ID)
+	require.NotEmpty(t, links["next"].Href)
+
+	resp, cleanup = client.Get(links["next"].Href)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	jobs = []models.JobSpec{}
+	links = jsonapi.Links{}
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &jobs, &links))
+	require.Len(t, jobs, 1)
+	assert.NotEqual(t, j1.ID, jobs[0].ID)
+	assert.Empty(t, links["next"])
+
+	resp, cleanup = client.Get("/v2/specs?cursor=invalid!")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
 func setupJobSpecsControllerIndex(app *cltest.TestApplication) (*models.JobSpec, error) {
 	j1 := cltest.NewJobWithSchedule("9 9 9 9 6")
 	j1.CreatedAt = time.Now().AddDate(0, 0, -1)
@@ -210,11 +290,13 @@ func TestJobSpecsController_Create_HappyPath(t *testing.T) {
 func TestJobSpecsController_CreateExternalInitiator_Success(t *testing.T) {
 	t.Parallel()
 
-	var eiReceived web.JobSpecNotice
+	eiReceived := make(chan models.JobSpecNotice, 1)
 	eiMockServer, assertCalled := cltest.NewHTTPMockServer(t, http.StatusOK, "POST", "",
 		func(header http.Header, body string) {
-			err := json.Unmarshal([]byte(body), &eiReceived)
+			var notice models.JobSpecNotice
+			err := json.Unmarshal([]byte(body), &notice)
 			require.NoError(t, err)
+			eiReceived <- notice
 		},
 	)
 	defer assertCalled()
@@ -235,12 +317,18 @@ func TestJobSpecsController_CreateExternalInitiator_Success(t *testing.T) {
 	require.NoError(t, err)
 
 	jobSpec := cltest.FixtureCreateJobViaWeb(t, app, "./testdata/external_initiator_job.json")
-	expected := web.JobSpecNotice{
+	expected := models.JobSpecNotice{
 		JobID:  jobSpec.ID,
 		Type:   models.InitiatorExternal,
+		Action: models.ExternalInitiatorNotificationCreate,
 		Params: cltest.JSONFromString(t, `{"foo":"bar"}`),
 	}
-	assert.Equal(t, expected, eiReceived)
+	select {
+	case notice := <-eiReceived:
+		assert.Equal(t, expected, notice)
+	case <-time.After(5 * time.Second):
+		t.Fatal("external initiator was not notified")
+	}
 
 	jobRun := cltest.CreateJobRunViaExternalInitiator(t, app, jobSpec, *eia, "")
 	_, err = app.Store.JobRunsFor(jobRun.ID)
@@ -469,6 +557,50 @@ func BenchmarkJobSpecsController_Show(b *testing.B) {
 	}
 }
 
+func TestJobSpecsController_Simulate(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	jsonStr := cltest.MustReadFile(t, "testdata/simulate_job.json")
+	resp, cleanup := client.Post("/v2/spec_simulations", bytes.NewBuffer(jsonStr))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var simulation models.RunSimulation
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &simulation))
+	assert.Equal(t, models.RunStatusCompleted, simulation.Status)
+	require.Len(t, simulation.TaskRuns, 2)
+	assert.Equal(t, "0x1234", simulation.TaskRuns[1].Input.Get("result").String())
+	assert.Equal(t,
+		"0x356a04bCe728ba4c62A30294A55E6A8600a320B3",
+		simulation.TaskRuns[1].Output.Get("simulatedTx.to").String())
+
+	jobCount, err := app.Store.ORM.CountOf(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Zero(t, jobCount)
+	runCount, err := app.Store.ORM.CountOf(&models.JobRun{})
+	require.NoError(t, err)
+	assert.Zero(t, runCount)
+}
+
+func TestJobSpecsController_Simulate_InvalidJob(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/spec_simulations", bytes.NewBufferString(`{"spec":{"initiators":[{"type":"web"}]}}`))
+	defer cleanup()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Response should be caller error")
+}
+
 func TestJobSpecsController_Show(t *testing.T) {
 	t.Parallel()
 
@@ -542,6 +674,217 @@ func TestJobSpecsController_Show_Unauthenticated(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Response should be forbidden")
 }
 
+func TestJobSpecsController_Update(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(j))
+
+	body := `{"initiators":[{"type":"cron","params":{"schedule":"0 0 1 1 *"}}],"tasks":[{"type":"NoOp"},{"type":"NoOp"}]}`
+	resp, cleanup := client.Patch("/v2/specs/"+j.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var respJob presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &respJob))
+	assert.Equal(t, j.ID, respJob.ID)
+	assert.Equal(t, uint32(2), respJob.Version)
+
+	updated, err := app.Store.FindJob(j.ID)
+	require.NoError(t, err)
+	require.Len(t, updated.Initiators, 1)
+	assert.Equal(t, models.InitiatorCron, updated.Initiators[0].Type)
+	assert.Len(t, updated.Tasks, 2)
+}
+
+func TestJobSpecsController_Update_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	body := `{"initiators":[{"type":"web"}],"tasks":[{"type":"NoOp"}]}`
+	resp, cleanup := client.Patch("/v2/specs/190AE4CE-40B6-4D60-A3DA-061C5ACD32D0", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobSpecsController_Update_InvalidJob(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(j))
+
+	body := `{"initiators":[{"type":"web"}],"tasks":[]}`
+	resp, cleanup := client.Patch("/v2/specs/"+j.ID.String(), bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	current, err := app.Store.FindJob(j.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), current.Version)
+}
+
+func TestJobSpecsController_VersionsAndDiff(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(j))
+	update := cltest.NewJobWithWebInitiator()
+	update.ID = j.ID
+	update.Tasks = []models.TaskSpec{cltest.NewTask(t, "httpget", `{"get": "https://example.com"}`)}
+	require.NoError(t, app.UpdateJob(update))
+
+	resp, cleanup := client.Get("/v2/specs/" + j.ID.String() + "/versions")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var versions []models.JobSpecVersion
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &versions))
+	require.Len(t, versions, 2)
+	assert.Equal(t, "noop", versions[0].Spec.Get("tasks.0.type").String())
+	assert.Equal(t, "httpget", versions[1].Spec.Get("tasks.0.type").String())
+
+	resp, cleanup = client.Get("/v2/specs/" + j.ID.String() + "/diff")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var diff models.JobSpecDiff
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &diff))
+	assert.Equal(t, uint32(1), diff.From)
+	assert.Equal(t, uint32(2), diff.To)
+	paths := []string{}
+	for _, change := range diff.Changes {
+		paths = append(paths, change.Path)
+	}
+	assert.Contains(t, paths, "tasks.0.type")
+	assert.Contains(t, paths, "tasks.0.params.get")
+
+	resp, cleanup = client.Get("/v2/specs/" + j.ID.String() + "/diff?from=1&to=5")
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobSpecsController_Consumption(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	j.MaxRuns = 5
+	j.MaxRunsWindow = models.Duration(time.Hour)
+	require.NoError(t, app.Store.CreateJob(&j))
+	jr := cltest.NewJobRun(j)
+	require.NoError(t, app.Store.CreateJobRun(&jr))
+
+	resp, cleanup := client.Get("/v2/specs/" + j.ID.String() + "/consumption")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var consumption models.JobConsumption
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &consumption))
+	assert.Equal(t, j.ID, consumption.JobSpecID)
+	assert.Equal(t, uint32(5), consumption.MaxRuns)
+	assert.Equal(t, uint32(1), consumption.Runs)
+	assert.Equal(t, 0, consumption.DailyGasSpend.Cmp(assets.NewEth(0)))
+}
+
+func TestJobSpecsController_Consumption_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/specs/190AE4CE-40B6-4D60-A3DA-061C5ACD32D0/consumption")
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobSpecsController_FluxMonitor(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithFluxMonitorInitiator()
+	j.Initiators = append(j.Initiators, j.Initiators[0])
+	require.NoError(t, app.Store.CreateJob(&j))
+	require.Len(t, j.Initiators, 2)
+
+	saved := models.FluxMonitorState{
+		InitiatorID:      j.Initiators[0].ID,
+		JobSpecID:        j.ID,
+		PolledAnswer:     decimal.NullDecimal{Decimal: decimal.NewFromFloat(101.5), Valid: true},
+		LatestAnswer:     decimal.NullDecimal{Decimal: decimal.NewFromInt(100), Valid: true},
+		Reason:           "deviation below threshold",
+		SubmittedRoundID: 3,
+		Feeds: models.FluxMonitorFeedResults{
+			{Feed: "https://example.com", Status: "included", Value: decimal.NullDecimal{Decimal: decimal.NewFromFloat(101.5), Valid: true}},
+			{Feed: "https://example.org", Status: "errored", Error: "bad gateway"},
+		},
+	}
+	require.NoError(t, app.Store.SaveFluxMonitorState(&saved))
+
+	resp, cleanup := client.Get("/v2/specs/" + j.ID.String() + "/flux_monitor")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var states []models.FluxMonitorState
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &states))
+	require.Len(t, states, 2)
+	assert.Equal(t, j.Initiators[0].ID, states[0].InitiatorID)
+	assert.Equal(t, "101.5", states[0].PolledAnswer.Decimal.String())
+	assert.Equal(t, "deviation below threshold", states[0].Reason)
+	assert.Equal(t, uint64(3), states[0].SubmittedRoundID)
+	require.Len(t, states[0].Feeds, 2)
+	assert.Equal(t, "included", states[0].Feeds[0].Status)
+	assert.Equal(t, "101.5", states[0].Feeds[0].Value.Decimal.String())
+	assert.Equal(t, "bad gateway", states[0].Feeds[1].Error)
+	assert.False(t, states[0].Feeds[1].Value.Valid)
+
+	// Initiators that have not polled yet have a blank state
+	assert.Equal(t, j.Initiators[1].ID, states[1].InitiatorID)
+	assert.False(t, states[1].PolledAt.Valid)
+	assert.Equal(t, "", states[1].Reason)
+}
+
+func TestJobSpecsController_FluxMonitor_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/specs/190AE4CE-40B6-4D60-A3DA-061C5ACD32D0/flux_monitor")
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestJobSpecsController_Destroy(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
@@ -558,3 +901,147 @@ func TestJobSpecsController_Destroy(t *testing.T) {
 	assert.Error(t, utils.JustError(app.Store.FindJob(job.ID)))
 	assert.Equal(t, 0, len(app.ChainlinkApplication.JobSubscriber.Jobs()))
 }
+
+func TestJobSpecsController_PauseResume(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(j))
+
+	resp, cleanup := client.Post("/v2/specs/"+j.ID.String()+"/pause", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var paused models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &paused))
+	assert.True(t, paused.Paused())
+
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/runs", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/resume", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var resumed models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &resumed))
+	assert.False(t, resumed.Paused())
+
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/resume", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestJobSpecsController_Pause_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/specs/190AE4CE-40B6-4D60-A3DA-061C5ACD32D0/pause", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobSpecsController_Update_TOML(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	j := cltest.NewJobWithWebInitiator()
+	require.NoError(t, app.AddJob(j))
+
+	body := `
+# replaces the job's tasks
+[[initiators]]
+type = "web"
+
+[[tasks]]
+type = "NoOp"
+
+[[tasks]]
+type = "NoOp"
+`
+	resp, cleanup := client.Patch(
+		"/v2/specs/"+j.ID.String(),
+		bytes.NewBufferString(body),
+		map[string]string{"Content-Type": "application/toml"},
+	)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	updated, err := app.Store.FindJob(j.ID)
+	require.NoError(t, err)
+	assert.Len(t, updated.Tasks, 2)
+	assert.Equal(t, uint32(2), updated.Version)
+}
+
+func TestJobSpecsController_ApprovalWorkflow(t *testing.T) {
+	t.Parallel()
+	config, cfgCleanup := cltest.NewConfig(t)
+	defer cfgCleanup()
+	config.Set("JOB_APPROVAL_POLICY", "all")
+	app, cleanup := cltest.NewApplicationWithConfig(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	approver, err := models.NewJobApprover("approver@chain.link", "p4SsW0rD1!@#_")
+	require.NoError(t, err)
+	require.NoError(t, app.Store.CreateJobApprover(&approver))
+
+	client := app.NewHTTPClient()
+	j := cltest.CreateJobSpecViaWeb(t, app, cltest.NewJobWithWebInitiator())
+	assert.True(t, j.Proposed())
+	assert.Equal(t, cltest.APIEmail, j.ProposedBy)
+
+	resp, cleanup := client.Post("/v2/specs/"+j.ID.String()+"/runs", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, cleanup = client.Get("/v2/specs/" + j.ID.String() + "/approval")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var approval models.JobApproval
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &approval))
+	assert.Equal(t, uint32(1), approval.Version)
+	assert.Equal(t, uint32(0), approval.ApprovedVersion)
+	assert.NotEmpty(t, approval.Reasons)
+	assert.NotEmpty(t, approval.Diff.Changes)
+	require.Len(t, approval.Events, 1)
+	assert.Equal(t, models.JobApprovalProposed, approval.Events[0].Action)
+
+	body := `{"email":"approver@chain.link","password":"wrong","version":1}`
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/approve", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	body = `{"email":"approver@chain.link","password":"p4SsW0rD1!@#_","version":1,"reason":"reviewed"}`
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/approve", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	var approved models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &approved))
+	assert.False(t, approved.Proposed())
+	assert.Equal(t, uint32(1), approved.ApprovedVersion)
+
+	resp, cleanup = client.Post("/v2/specs/"+j.ID.String()+"/approve", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	events, err := app.Store.JobApprovalEvents(j.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, models.JobApprovalDenied, events[1].Action)
+	assert.Equal(t, models.JobApprovalApproved, events[2].Action)
+}
@@ -212,17 +212,41 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
 
 		eia := ExternalInitiatorsController{app}
+		authv2.GET("/external_initiators", paginatedRequest(eia.Index))
 		authv2.POST("/external_initiators", eia.Create)
 		authv2.DELETE("/external_initiators/:Name", eia.Destroy)
 
 		authv2.POST("/specs", j.Create)
 		authv2.GET("/specs", paginatedRequest(j.Index))
 		authv2.GET("/specs/:SpecID", j.Show)
+		authv2.PATCH("/specs/:SpecID", j.Update)
+		authv2.GET("/specs/:SpecID/consumption", j.Consumption)
+		authv2.GET("/specs/:SpecID/flux_monitor", j.FluxMonitor)
+		authv2.GET("/specs/:SpecID/versions", j.Versions)
+		authv2.GET("/specs/:SpecID/diff", j.Diff)
+		authv2.POST("/specs/:SpecID/pause", j.Pause)
+		authv2.POST("/specs/:SpecID/resume", j.Resume)
+		authv2.GET("/specs/:SpecID/approval", j.Approval)
+		authv2.POST("/specs/:SpecID/approve", j.Approve)
+		authv2.POST("/specs/:SpecID/reject", j.Reject)
 		authv2.DELETE("/specs/:SpecID", j.Destroy)
+		authv2.POST("/spec_simulations", j.Simulate)
+
+		st := SpecTemplatesController{app}
+		authv2.GET("/spec_templates", paginatedRequest(st.Index))
+		authv2.POST("/spec_templates", st.Create)
+		authv2.GET("/spec_templates/:TemplateID", st.Show)
+		authv2.PATCH("/spec_templates/:TemplateID", st.Update)
+		authv2.GET("/spec_templates/:TemplateID/instances", st.Instances)
+		authv2.POST("/spec_templates/:TemplateID/instances", st.CreateInstances)
+		authv2.POST("/spec_templates/:TemplateID/rollout", st.Rollout)
 
 		authv2.GET("/runs", paginatedRequest(jr.Index))
 		authv2.GET("/runs/:RunID", jr.Show)
 		authv2.PUT("/runs/:RunID/cancellation", jr.Cancel)
+		authv2.GET("/runs/:RunID/http_audit", jr.HTTPAudit)
+		authv2.POST("/runs/:RunID/replay", jr.Replay)
+		authv2.GET("/run_updates", jr.Stream)
 
 		authv2.GET("/service_agreements/:SAID", sa.Show)
 
@@ -43,6 +43,7 @@ func (sac *ServiceAgreementsController) Create(c *gin.Context) {
 			jsonAPIError(c, http.StatusUnprocessableEntity, err)
 			return
 		}
+		sa.JobSpec.ProposedBy = proposer(c)
 		if err = sac.App.AddServiceAgreement(&sa); err != nil {
 			jsonAPIError(c, http.StatusInternalServerError, errors.Wrap(err, "#AddServiceAgreement"))
 			return
@@ -0,0 +1,271 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"chainlink/core/services"
+	"chainlink/core/services/chainlink"
+	"chainlink/core/store/models"
+	"chainlink/core/store/orm"
+	"chainlink/core/store/presenters"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// SpecTemplatesController manages job spec templates and the jobs created
+// from them.
+type SpecTemplatesController struct {
+	App chainlink.Application
+}
+
+// Index lists job spec templates, one page at a time.
+// Example:
+//  "<application>/spec_templates?size=1&page=2"
+func (stc *SpecTemplatesController) Index(c *gin.Context, size, page, offset int) {
+	templates, count, err := stc.App.GetStore().SpecTemplates(offset, size)
+	paginatedResponse(c, "SpecTemplates", size, page, templates, count, err)
+}
+
+// Create validates and saves a job spec template.
+// Example:
+//  "<application>/spec_templates"
+func (stc *SpecTemplatesController) Create(c *gin.Context) {
+	var str models.SpecTemplateRequest
+	if err := c.ShouldBindJSON(&str); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	template := models.NewSpecTemplate(str)
+	if err := services.ValidateSpecTemplate(template, stc.App.GetStore()); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := stc.App.GetStore().CreateSpecTemplate(&template); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewSpecTemplate(template, nil), "spec_template")
+}
+
+// Show returns the details of a job spec template, with the jobs created
+// from an earlier version of it.
+// Example:
+//  "<application>/spec_templates/:TemplateID"
+func (stc *SpecTemplatesController) Show(c *gin.Context) {
+	template, ok := stc.findTemplate(c)
+	if !ok {
+		return
+	}
+	instances, err := stc.App.GetStore().SpecTemplateInstances(template.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewSpecTemplate(template, instances), "spec_template")
+}
+
+// Update saves the job spec template as its next version. The jobs created
+// from it are left on the version they were created from until it is
+// rolled out to them, and are listed in the response.
+// Example:
+//  "<application>/spec_templates/:TemplateID"
+func (stc *SpecTemplatesController) Update(c *gin.Context) {
+	template, ok := stc.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	var str models.SpecTemplateRequest
+	if err := c.ShouldBindJSON(&str); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	template.Name = str.Name
+	template.Variables = str.Variables
+	template.Spec = str.Spec
+
+	store := stc.App.GetStore()
+	if err := services.ValidateSpecTemplate(template, store); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	err := store.UpdateSpecTemplate(&template)
+	if errors.Cause(err) == orm.OptimisticUpdateConflictError {
+		jsonAPIError(c, http.StatusConflict, errors.New("Spec template was updated concurrently, please retry"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	instances, err := store.SpecTemplateInstances(template.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewSpecTemplate(template, instances), "spec_template")
+}
+
+// Instances lists the jobs created from a job spec template.
+// Example:
+//  "<application>/spec_templates/:TemplateID/instances"
+func (stc *SpecTemplatesController) Instances(c *gin.Context) {
+	template, ok := stc.findTemplate(c)
+	if !ok {
+		return
+	}
+	jobs, err := stc.App.GetStore().SpecTemplateInstances(template.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobs, "jobs")
+}
+
+// CreateInstances creates a job from the template for each set of variables
+// given. No jobs are created unless every one of them is valid and saved.
+// Example:
+//  "<application>/spec_templates/:TemplateID/instances"
+func (stc *SpecTemplatesController) CreateInstances(c *gin.Context) {
+	template, ok := stc.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	var sir models.SpecTemplateInstancesRequest
+	if err := c.ShouldBindJSON(&sir); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if len(sir.Instances) == 0 {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("Must give the variables of at least one instance"))
+		return
+	}
+
+	store := stc.App.GetStore()
+	jobs := make([]models.JobSpec, len(sir.Instances))
+	for i, variables := range sir.Instances {
+		job, err := template.NewJob(variables)
+		if err == nil {
+			err = services.ValidateJob(job, store)
+		}
+		if err != nil {
+			jsonAPIError(c, http.StatusBadRequest, errors.Wrapf(err, "instance %d", i))
+			return
+		}
+		jobs[i] = job
+	}
+
+	for i := range jobs {
+		jobs[i].ProposedBy = proposer(c)
+	}
+	if err := stc.App.AddJobs(jobs); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, jobs, "jobs")
+}
+
+// Rollout updates each job created from an earlier version of the template
+// to a new version created from the current one, with the variables the job
+// was created from. No jobs are updated unless every new version is valid and
+// saved.
+// Example:
+//  "<application>/spec_templates/:TemplateID/rollout"
+func (stc *SpecTemplatesController) Rollout(c *gin.Context) {
+	template, ok := stc.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	store := stc.App.GetStore()
+	instances, err := store.SpecTemplateInstances(template.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var jobs []models.JobSpec
+	for _, instance := range instances {
+		if instance.SpecTemplateVersion >= template.Version {
+			continue
+		}
+		job, err := rolloutJob(template, instance)
+		if err == nil {
+			err = services.ValidateJob(job, store)
+		}
+		if err != nil {
+			jsonAPIError(c, http.StatusBadRequest, errors.Wrapf(err, "job %s", instance.ID))
+			return
+		}
+		jobs = append(jobs, job)
+	}
+
+	for i := range jobs {
+		jobs[i].ProposedBy = proposer(c)
+	}
+	err = stc.App.UpdateJobs(jobs)
+	if errors.Cause(err) == orm.OptimisticUpdateConflictError {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("%s, please retry", err))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	updated := []models.JobSpec{}
+	for _, job := range jobs {
+		j, err := store.FindJob(job.ID)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		updated = append(updated, j)
+	}
+	jsonAPIResponse(c, updated, "jobs")
+}
+
+// rolloutJob returns the next version of the instance, created from the
+// template with the instance's variables.
+func rolloutJob(template models.SpecTemplate, instance models.JobSpec) (models.JobSpec, error) {
+	variables := map[string]interface{}{}
+	if instance.TemplateVariables != nil {
+		for k, v := range instance.TemplateVariables.Map() {
+			variables[k] = v.Value()
+		}
+	}
+	job, err := template.NewJob(variables)
+	if err != nil {
+		return job, err
+	}
+	job.ID = instance.ID
+	for i := range job.Initiators {
+		job.Initiators[i].JobSpecID = instance.ID
+	}
+	for i := range job.Tasks {
+		job.Tasks[i].JobSpecID = instance.ID
+	}
+	return job, nil
+}
+
+func (stc *SpecTemplatesController) findTemplate(c *gin.Context) (models.SpecTemplate, bool) {
+	id, err := models.NewIDFromString(c.Param("TemplateID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return models.SpecTemplate{}, false
+	}
+	template, err := stc.App.GetStore().FindSpecTemplate(id)
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Spec template not found"))
+		return template, false
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return template, false
+	}
+	return template, true
+}
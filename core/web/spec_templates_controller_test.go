@@ -0,0 +1,169 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"chainlink/core/internal/cltest"
+	"chainlink/core/store/models"
+	"chainlink/core/store/presenters"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const priceSpecTemplate = `{
+	"name": "price",
+	"variables": [
+		{"name": "source", "type": "url"},
+		{"name": "times", "type": "integer", "default": 100}
+	],
+	"spec": {
+		"initiators": [{"type": "web"}],
+		"tasks": [
+			{"type": "httpget", "params": {"get": "{{source}}"}},
+			{"type": "multiply", "params": {"times": "{{times}}"}},
+			{"type": "noop"}
+		]
+	}
+}`
+
+func createSpecTemplate(t *testing.T, client cltest.HTTPClientCleaner, body string) presenters.SpecTemplate {
+	t.Helper()
+	resp, cleanup := client.Post("/v2/spec_templates", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var template presenters.SpecTemplate
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &template))
+	return template
+}
+
+func TestSpecTemplatesController_CreateInstancesAndRollout(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	template := createSpecTemplate(t, client, priceSpecTemplate)
+	assert.Equal(t, "price", template.Name)
+	assert.Equal(t, uint32(1), template.Version)
+
+	body := `{"instances": [
+		{"source": "https://a.example/eth"},
+		{"source": "https://b.example/btc", "times": 1000}
+	]}`
+	resp, cleanup := client.Post("/v2/spec_templates/"+template.ID.String()+"/instances", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	instances, err := app.Store.SpecTemplateInstances(template.ID)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	for _, job := range instances {
+		assert.Equal(t, template.ID, job.SpecTemplateID)
+		assert.Equal(t, uint32(1), job.SpecTemplateVersion)
+		require.Len(t, job.Tasks, 3)
+	}
+
+	updated := `{
+		"name": "price",
+		"variables": [
+			{"name": "source", "type": "url"},
+			{"name": "times", "type": "integer", "default": 100}
+		],
+		"spec": {
+			"initiators": [{"type": "web"}],
+			"tasks": [
+				{"type": "httpget", "params": {"get": "{{source}}"}},
+				{"type": "jsonparse", "params": {"path": ["price"]}},
+				{"type": "multiply", "params": {"times": "{{times}}"}},
+				{"type": "noop"}
+			]
+		}
+	}`
+	resp, cleanup = client.Patch("/v2/spec_templates/"+template.ID.String(), bytes.NewBufferString(updated))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &template))
+	assert.Equal(t, uint32(2), template.Version)
+	assert.Len(t, template.OutdatedJobIDs, 2)
+
+	resp, cleanup = client.Post("/v2/spec_templates/"+template.ID.String()+"/rollout", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	instances, err = app.Store.SpecTemplateInstances(template.ID)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	for _, job := range instances {
+		assert.Equal(t, uint32(2), job.Version)
+		assert.Equal(t, uint32(2), job.SpecTemplateVersion)
+		require.Len(t, job.Tasks, 4)
+		assert.Equal(t, job.TemplateVariables.Get("times").String(), job.Tasks[2].Params.Get("times").String())
+	}
+
+	resp, cleanup = client.Get("/v2/spec_templates/" + template.ID.String())
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &template))
+	assert.Len(t, template.OutdatedJobIDs, 0)
+}
+
+func TestSpecTemplatesController_CreateInstances_Invalid(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	template := createSpecTemplate(t, client, priceSpecTemplate)
+
+	body := `{"instances": [
+		{"source": "https://a.example/eth"},
+		{"source": "ftp://b.example/btc"}
+	]}`
+	resp, cleanup := client.Post("/v2/spec_templates/"+template.ID.String()+"/instances", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(cltest.ParseResponseBody(t, resp)), "instance 1")
+
+	jobs := cltest.AllJobs(t, app.Store)
+	assert.Len(t, jobs, 0)
+}
+
+func TestSpecTemplatesController_Create_Invalid(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	body := `{
+		"name": "price",
+		"variables": [{"name": "source", "type": "url"}],
+		"spec": {"initiators": [{"type": "web"}], "tasks": [{"type": "httpget", "params": {"get": "{{url}}"}}]}
+	}`
+	resp, cleanup := client.Post("/v2/spec_templates", bytes.NewBufferString(body))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	createSpecTemplate(t, client, priceSpecTemplate)
+	resp, cleanup = client.Post("/v2/spec_templates", bytes.NewBufferString(priceSpecTemplate))
+	defer cleanup()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSpecTemplatesController_Show_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+	resp, cleanup := client.Get("/v2/spec_templates/" + models.NewID().String())
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}